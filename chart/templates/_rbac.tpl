@@ -6,6 +6,15 @@
 {{- printf "vc-mn-%s-v-%s" .Release.Name .Release.Namespace | trunc 63 | trimSuffix "-" -}}
 {{- end -}}
 
+{{/*
+  Whether namespaces are synced to the host cluster, experimental.multiNamespaceMode is deprecated
+*/}}
+{{- define "vcluster.multiNamespaceMode" -}}
+{{- if or .Values.sync.toHost.namespaces.enabled .Values.experimental.multiNamespaceMode.enabled -}}
+{{- true -}}
+{{- end -}}
+{{- end -}}
+
 {{/*
   Whether to create a cluster role or not
 */}}
@@ -18,6 +27,7 @@
     (not (empty (include "vcluster.rbac.clusterRoleExtraRules" . )))
     (not (empty (include "vcluster.plugin.clusterRoleExtraRules" . )))
     (not (empty (include "vcluster.generic.clusterRoleExtraRules" . )))
+    (not (empty (include "vcluster.customResources.clusterRoleExtraRules" . )))
     .Values.networking.replicateServices.fromHost
     .Values.pro
     .Values.sync.toHost.storageClasses.enabled
@@ -27,14 +37,17 @@
     .Values.sync.toHost.volumeSnapshots.enabled
     .Values.controlPlane.advanced.virtualScheduler.enabled
     .Values.sync.fromHost.ingressClasses.enabled
+    .Values.sync.fromHost.gateways.enabled
+    .Values.sync.toHost.gateway.enabled
     (eq (toString .Values.sync.fromHost.storageClasses.enabled) "true")
     (eq (toString .Values.sync.fromHost.csiNodes.enabled) "true")
     (eq (toString .Values.sync.fromHost.csiDrivers.enabled) "true")
     (eq (toString .Values.sync.fromHost.csiStorageCapacities.enabled) "true")
+    .Values.sync.fromHost.volumeAttachments.enabled
     .Values.sync.fromHost.nodes.enabled
     .Values.integrations.kubeVirt.enabled
     (and .Values.integrations.metricsServer.enabled .Values.integrations.metricsServer.nodes)
-    .Values.experimental.multiNamespaceMode.enabled -}}
+    (include "vcluster.multiNamespaceMode" .) -}}
 {{- true -}}
 {{- end -}}
 {{- end -}}
@@ -133,6 +146,40 @@
 {{- end }}
 {{- end -}}
 
+{{/*
+  Role rules for custom resources synced to the host cluster
+*/}}
+{{- define "vcluster.customResources.roleExtraRules" -}}
+{{- range $crdName, $customResource := .Values.sync.toHost.customResources }}
+{{- if $customResource.enabled }}
+{{- $parts := splitn "." 2 $crdName }}
+- {{ toJson (dict "apiGroups" (list $parts._1) "resources" (list $parts._0 (printf "%s/status" $parts._0)) "verbs" (list "create" "delete" "patch" "update" "get" "list" "watch")) }}
+{{- end }}
+{{- end }}
+{{- end -}}
+
+{{/*
+  Cluster role rules for custom resources synced from the host cluster
+*/}}
+{{- define "vcluster.customResources.clusterRoleExtraRules" -}}
+{{- $enabled := false }}
+{{- range $crdName, $customResource := .Values.sync.toHost.customResources }}
+{{- if $customResource.enabled }}
+{{- $enabled = true }}
+{{- end }}
+{{- end }}
+{{- range $crdName, $customResource := .Values.sync.fromHost.customResources }}
+{{- if $customResource.enabled }}
+{{- $enabled = true }}
+{{- $parts := splitn "." 2 $crdName }}
+- {{ toJson (dict "apiGroups" (list $parts._1) "resources" (list $parts._0) "verbs" (list "get" "list" "watch")) }}
+{{- end }}
+{{- end }}
+{{- if $enabled }}
+- {{ toJson (dict "apiGroups" (list "apiextensions.k8s.io") "resources" (list "customresourcedefinitions") "verbs" (list "get" "list" "watch")) }}
+{{- end }}
+{{- end -}}
+
 {{/*
   Cluster Role rules defined on global level
 */}}
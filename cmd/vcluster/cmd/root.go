@@ -57,5 +57,6 @@ func BuildRoot() *cobra.Command {
 	// add top level commands
 	rootCmd.AddCommand(NewStartCommand())
 	rootCmd.AddCommand(NewCpCommand())
+	rootCmd.AddCommand(NewSnapshotCommand())
 	return rootCmd
 }
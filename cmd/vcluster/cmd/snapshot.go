@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/loft-sh/vcluster/pkg/config"
+	"github.com/loft-sh/vcluster/pkg/etcd"
+	"github.com/loft-sh/vcluster/pkg/pro"
+	"github.com/loft-sh/vcluster/pkg/setup"
+	"github.com/loft-sh/vcluster/pkg/snapshot"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+type SnapshotOptions struct {
+	Config string
+
+	// StartBackingStore starts the backing store before accessing it, as the control plane is stopped
+	StartBackingStore bool
+}
+
+// NewSnapshotCommand returns the snapshot command that is executed by the vcluster cli within the
+// vCluster pod. The snapshot archive is streamed via stdout and stdin.
+func NewSnapshotCommand() *cobra.Command {
+	options := &SnapshotOptions{}
+	cmd := &cobra.Command{
+		Use:    "snapshot",
+		Short:  "Snapshot and restore the vCluster backing store",
+		Args:   cobra.NoArgs,
+		Hidden: true,
+	}
+	cmd.PersistentFlags().StringVar(&options.Config, "config", "/var/vcluster/config.yaml", "The path where to find the vCluster config to load")
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "create",
+		Short: "Write a snapshot of the backing store to stdout",
+		Args:  cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, _ []string) error {
+			return CreateSnapshot(cobraCmd.Context(), options, os.Stdout)
+		},
+	})
+	restoreCmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Restore a snapshot of the backing store from stdin",
+		Args:  cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, _ []string) error {
+			return RestoreSnapshot(cobraCmd.Context(), options, os.Stdin)
+		},
+	}
+	restoreCmd.Flags().BoolVar(&options.StartBackingStore, "start-backing-store", false, "If enabled, starts the backing store of the stopped vCluster before restoring the snapshot")
+	cmd.AddCommand(restoreCmd)
+
+	return cmd
+}
+
+func CreateSnapshot(ctx context.Context, options *SnapshotOptions, w io.Writer) error {
+	vConfig, kubeClient, etcdClient, err := initSnapshot(ctx, options)
+	if err != nil {
+		return err
+	}
+	defer etcdClient.Close()
+
+	secrets, err := snapshot.GetSecrets(ctx, kubeClient, vConfig.ControlPlaneNamespace, vConfig.Name)
+	if err != nil {
+		return err
+	}
+
+	keys, err := snapshot.Create(ctx, etcdClient, snapshot.Metadata{
+		Name:      vConfig.Name,
+		Namespace: vConfig.ControlPlaneNamespace,
+		Distro:    vConfig.Distro(),
+	}, secrets, w)
+	if err != nil {
+		return fmt.Errorf("create snapshot: %w", err)
+	}
+
+	klog.Infof("Successfully created snapshot with %d keys", keys)
+	return nil
+}
+
+func RestoreSnapshot(ctx context.Context, options *SnapshotOptions, r io.Reader) error {
+	vConfig, kubeClient, etcdClient, err := initSnapshot(ctx, options)
+	if err != nil {
+		return err
+	}
+	defer etcdClient.Close()
+
+	restored, err := snapshot.Restore(ctx, etcdClient, r)
+	if err != nil {
+		return fmt.Errorf("restore snapshot: %w", err)
+	}
+	if restored.Metadata.Distro != "" && restored.Metadata.Distro != vConfig.Distro() {
+		klog.Warningf("Snapshot was taken from a %s vCluster, but is restored into a %s vCluster", restored.Metadata.Distro, vConfig.Distro())
+	}
+
+	// the certificates are only valid for the name and namespace of the vCluster the snapshot was taken from,
+	// so a new vCluster keeps its own ones, but needs the encryption config and service account keys of the snapshot
+	if (restored.Metadata.Name != "" && restored.Metadata.Name != vConfig.Name) || (restored.Metadata.Namespace != "" && restored.Metadata.Namespace != vConfig.ControlPlaneNamespace) {
+		current, err := snapshot.GetSecrets(ctx, kubeClient, vConfig.ControlPlaneNamespace, vConfig.Name)
+		if err != nil {
+			return err
+		} else if current.Certs == nil {
+			return fmt.Errorf("certificate secret of vCluster %s/%s not found, please start the vCluster once before restoring a snapshot of another vCluster", vConfig.ControlPlaneNamespace, vConfig.Name)
+		}
+
+		klog.Infof("Snapshot was taken from vCluster %s/%s, keeping the certificates of vCluster %s/%s", restored.Metadata.Namespace, restored.Metadata.Name, vConfig.ControlPlaneNamespace, vConfig.Name)
+		restored.Secrets.Certs = snapshot.MergePortableCerts(current.Certs, restored.Secrets.Certs)
+	}
+
+	err = snapshot.ApplySecrets(ctx, kubeClient, vConfig.ControlPlaneNamespace, vConfig.Name, restored.Secrets)
+	if err != nil {
+		return err
+	}
+
+	klog.Infof("Successfully restored %d keys from snapshot of vCluster %s taken at %s", restored.Keys, restored.Metadata.Name, restored.Metadata.CreationTimestamp.String())
+	return nil
+}
+
+func initSnapshot(ctx context.Context, options *SnapshotOptions) (*config.VirtualClusterConfig, kubernetes.Interface, etcd.Client, error) {
+	// parse vCluster config
+	vConfig, err := config.ParseConfig(options.Config, os.Getenv("VCLUSTER_NAME"), nil)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// get current namespace
+	vConfig.ControlPlaneConfig, vConfig.ControlPlaneNamespace, vConfig.ControlPlaneService, vConfig.WorkloadConfig, vConfig.WorkloadNamespace, vConfig.WorkloadService, err = pro.GetRemoteClient(vConfig)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(vConfig.ControlPlaneConfig)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if options.StartBackingStore {
+		err = setup.StartBackingStore(ctx, kubeClient, vConfig)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("start backing store: %w", err)
+		}
+	}
+
+	etcdClient, err := etcd.NewFromConfig(ctx, vConfig)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("create etcd client: %w", err)
+	}
+
+	return vConfig, kubeClient, etcdClient, nil
+}
@@ -0,0 +1,45 @@
+package certs
+
+import (
+	"fmt"
+
+	"github.com/loft-sh/log"
+	"github.com/loft-sh/vcluster/pkg/cli"
+	"github.com/loft-sh/vcluster/pkg/cli/config"
+	"github.com/loft-sh/vcluster/pkg/cli/flags"
+	"github.com/spf13/cobra"
+)
+
+type certsCmd struct {
+	*flags.GlobalFlags
+	cli.CertsOptions
+
+	log log.Logger
+}
+
+func NewCertsCmd(globalFlags *flags.GlobalFlags) *cobra.Command {
+	certsCmd := &cobra.Command{
+		Use:   "certs",
+		Short: "Check and rotate virtual cluster certificates",
+		Long: `#######################################################
+################### vcluster certs ####################
+#######################################################
+Check and rotate the certificates vCluster generated for
+the virtual control plane.
+	`,
+		Args: cobra.NoArgs,
+	}
+
+	certsCmd.AddCommand(check(globalFlags))
+	certsCmd.AddCommand(rotate(globalFlags))
+	return certsCmd
+}
+
+func (cmd *certsCmd) validateDriver() error {
+	cfg := cmd.LoadedConfig(cmd.log)
+	if cfg.Driver.Type == config.PlatformDriver {
+		return fmt.Errorf("certs is currently only supported with the helm driver")
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package certs
+
+import (
+	"context"
+
+	"github.com/loft-sh/log"
+	"github.com/loft-sh/vcluster/pkg/cli"
+	"github.com/loft-sh/vcluster/pkg/cli/completion"
+	"github.com/loft-sh/vcluster/pkg/cli/flags"
+	"github.com/spf13/cobra"
+)
+
+type checkCmd struct {
+	certsCmd
+}
+
+func check(globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &checkCmd{
+		certsCmd: certsCmd{
+			GlobalFlags: globalFlags,
+			log:         log.GetInstance(),
+		},
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:   "check VCLUSTER_NAME",
+		Short: "Shows the expiry of the virtual cluster certificates",
+		Long: `#######################################################
+################ vcluster certs check #################
+#######################################################
+Shows the expiry of all certificates stored in the certs
+secret of the given virtual cluster.
+
+Example:
+vcluster certs check test --namespace test
+#######################################################
+	`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.NewValidVClusterNameFunc(globalFlags),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+	}
+
+	cobraCmd.Flags().StringVar(&cmd.Output, "output", "table", "Choose the format of the output. [table|json]")
+	return cobraCmd
+}
+
+// Run executes the functionality
+func (cmd *checkCmd) Run(ctx context.Context, args []string) error {
+	err := cmd.validateDriver()
+	if err != nil {
+		return err
+	}
+
+	return cli.CertsCheckHelm(ctx, &cmd.CertsOptions, cmd.GlobalFlags, args[0], cmd.log)
+}
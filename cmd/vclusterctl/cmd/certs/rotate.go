@@ -0,0 +1,72 @@
+package certs
+
+import (
+	"context"
+	"time"
+
+	"github.com/loft-sh/log"
+	"github.com/loft-sh/vcluster/pkg/cli"
+	"github.com/loft-sh/vcluster/pkg/cli/completion"
+	"github.com/loft-sh/vcluster/pkg/cli/flags"
+	"github.com/spf13/cobra"
+)
+
+type rotateCmd struct {
+	certsCmd
+}
+
+func rotate(globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &rotateCmd{
+		certsCmd: certsCmd{
+			GlobalFlags: globalFlags,
+			log:         log.GetInstance(),
+		},
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:   "rotate VCLUSTER_NAME",
+		Short: "Renews the virtual cluster certificates",
+		Long: `#######################################################
+################ vcluster certs rotate ################
+#######################################################
+Renews the leaf certificates of the given virtual cluster
+with their current subject and SANs and restarts the
+virtual cluster pods one by one afterward. With --ca, a
+new cluster CA is generated as well. During the trust
+period, the new CA is trusted while all certificates are
+still issued by the previous CA, so that existing kube
+configs keep working. Afterward, all certificates are
+re-issued by the new CA. Without a trust period, the new
+CA is used right away and existing kube configs stop
+working.
+
+Certificates that are generated by k3s itself are renewed
+by k3s on restart.
+
+Example:
+vcluster certs rotate test --namespace test
+vcluster certs rotate test --namespace test --ca --ca-trust-period 48h
+#######################################################
+	`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.NewValidVClusterNameFunc(globalFlags),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+	}
+
+	cobraCmd.Flags().BoolVar(&cmd.RotateCA, "ca", false, "If enabled, rotates the cluster CA as well")
+	cobraCmd.Flags().DurationVar(&cmd.CATrustPeriod, "ca-trust-period", 24*time.Hour, "How long the previous CA keeps issuing and serving certificates after the CA was rotated, 0 switches to the new CA right away")
+	cobraCmd.Flags().BoolVar(&cmd.SkipRestart, "skip-restart", false, "If enabled, the virtual cluster pods are not restarted after the rotation")
+	return cobraCmd
+}
+
+// Run executes the functionality
+func (cmd *rotateCmd) Run(ctx context.Context, args []string) error {
+	err := cmd.validateDriver()
+	if err != nil {
+		return err
+	}
+
+	return cli.CertsRotateHelm(ctx, &cmd.CertsOptions, cmd.GlobalFlags, args[0], cmd.log)
+}
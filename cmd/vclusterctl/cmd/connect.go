@@ -45,6 +45,10 @@ vcluster connect test --namespace test
 # Open a new bash with the vcluster KUBECONFIG defined
 vcluster connect test -n test -- bash
 vcluster connect test -n test -- kubectl get ns
+# Log in via the OpenID provider of the virtual cluster
+vcluster connect test -n test --oidc
+# Issue a client certificate for a user that is valid for 8 hours and only grants access to namespace ns1
+vcluster connect test -n test --user alice --groups dev --ttl 8h --namespace-scope ns1
 #######################################################
 	`,
 		Args:              nameValidator,
@@ -97,6 +101,18 @@ func (cmd *ConnectCmd) validateFlags() error {
 	if cmd.ServiceAccountClusterRole != "" && cmd.ServiceAccount == "" {
 		return fmt.Errorf("expected --service-account to be defined as well")
 	}
+	if cmd.OIDC && cmd.ServiceAccount != "" {
+		return fmt.Errorf("--oidc and --service-account cannot be used together")
+	}
+	if cmd.User == "" && (len(cmd.Groups) > 0 || len(cmd.NamespaceScope) > 0 || cmd.AllowLongTTL) {
+		return fmt.Errorf("expected --user to be defined as well")
+	}
+	if cmd.User != "" && (cmd.OIDC || cmd.ServiceAccount != "") {
+		return fmt.Errorf("--user cannot be used together with --oidc or --service-account")
+	}
+	if cmd.User != "" {
+		return cli.ValidateUserOptions(&cmd.ConnectOptions)
+	}
 
 	return nil
 }
@@ -0,0 +1,45 @@
+package credentials
+
+import (
+	"fmt"
+
+	"github.com/loft-sh/log"
+	"github.com/loft-sh/vcluster/pkg/cli"
+	"github.com/loft-sh/vcluster/pkg/cli/config"
+	"github.com/loft-sh/vcluster/pkg/cli/flags"
+	"github.com/spf13/cobra"
+)
+
+type credentialsCmd struct {
+	*flags.GlobalFlags
+	cli.CredentialsOptions
+
+	log log.Logger
+}
+
+func NewCredentialsCmd(globalFlags *flags.GlobalFlags) *cobra.Command {
+	credentialsCmd := &cobra.Command{
+		Use:   "credentials",
+		Short: "List and revoke virtual cluster client certificates",
+		Long: `#######################################################
+################ vcluster credentials #################
+#######################################################
+List and revoke the client certificates that were issued
+for the virtual cluster.
+	`,
+		Args: cobra.NoArgs,
+	}
+
+	credentialsCmd.AddCommand(list(globalFlags))
+	credentialsCmd.AddCommand(revoke(globalFlags))
+	return credentialsCmd
+}
+
+func (cmd *credentialsCmd) validateDriver() error {
+	cfg := cmd.LoadedConfig(cmd.log)
+	if cfg.Driver.Type == config.PlatformDriver {
+		return fmt.Errorf("credentials is currently only supported with the helm driver")
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package credentials
+
+import (
+	"context"
+
+	"github.com/loft-sh/log"
+	"github.com/loft-sh/vcluster/pkg/cli"
+	"github.com/loft-sh/vcluster/pkg/cli/completion"
+	"github.com/loft-sh/vcluster/pkg/cli/flags"
+	"github.com/spf13/cobra"
+)
+
+type listCmd struct {
+	credentialsCmd
+}
+
+func list(globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &listCmd{
+		credentialsCmd: credentialsCmd{
+			GlobalFlags: globalFlags,
+			log:         log.GetInstance(),
+		},
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:   "list VCLUSTER_NAME",
+		Short: "Lists the issued client certificates",
+		Long: `#######################################################
+############### vcluster credentials list #############
+#######################################################
+Lists the client certificates that were issued for the
+given virtual cluster, which are the admin certificate
+and certificates issued via 'vcluster connect --user'.
+
+The role bindings created via --namespace-scope persist
+after a certificate expires or is revoked, which is why
+these certificates are still listed with their namespaces
+after they expired. Delete the role bindings within the
+virtual cluster to remove the access.
+
+Example:
+vcluster credentials list test --namespace test
+#######################################################
+	`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.NewValidVClusterNameFunc(globalFlags),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+	}
+
+	cobraCmd.Flags().StringVar(&cmd.Output, "output", "table", "Choose the format of the output. [table|json]")
+	return cobraCmd
+}
+
+// Run executes the functionality
+func (cmd *listCmd) Run(ctx context.Context, args []string) error {
+	err := cmd.validateDriver()
+	if err != nil {
+		return err
+	}
+
+	return cli.CredentialsListHelm(ctx, &cmd.CredentialsOptions, cmd.GlobalFlags, args[0], cmd.log)
+}
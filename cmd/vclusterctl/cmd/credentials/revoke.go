@@ -0,0 +1,63 @@
+package credentials
+
+import (
+	"context"
+
+	"github.com/loft-sh/log"
+	"github.com/loft-sh/vcluster/pkg/cli"
+	"github.com/loft-sh/vcluster/pkg/cli/completion"
+	"github.com/loft-sh/vcluster/pkg/cli/flags"
+	"github.com/spf13/cobra"
+)
+
+type revokeCmd struct {
+	credentialsCmd
+}
+
+func revoke(globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &revokeCmd{
+		credentialsCmd: credentialsCmd{
+			GlobalFlags: globalFlags,
+			log:         log.GetInstance(),
+		},
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:   "revoke VCLUSTER_NAME SERIAL_OR_SUBJECT",
+		Short: "Revokes issued client certificates",
+		Long: `#######################################################
+############# vcluster credentials revoke #############
+#######################################################
+Revokes the client certificates with the given serial or
+subject. Requests with a revoked certificate are denied
+by the vCluster syncer until the certificate expires.
+Role bindings created via --namespace-scope are not
+removed and need to be deleted within the virtual cluster.
+
+Revoking the admin certificate also breaks the default
+kube config of 'vcluster connect', use 'vcluster certs
+rotate' to issue a new one.
+
+Example:
+vcluster credentials revoke test alice --namespace test
+#######################################################
+	`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completion.NewValidVClusterNameFunc(globalFlags),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+	}
+
+	return cobraCmd
+}
+
+// Run executes the functionality
+func (cmd *revokeCmd) Run(ctx context.Context, args []string) error {
+	err := cmd.validateDriver()
+	if err != nil {
+		return err
+	}
+
+	return cli.CredentialsRevokeHelm(ctx, cmd.GlobalFlags, args[0], args[1], cmd.log)
+}
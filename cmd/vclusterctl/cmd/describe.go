@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+
+	"github.com/loft-sh/log"
+	"github.com/loft-sh/vcluster/pkg/cli"
+	"github.com/loft-sh/vcluster/pkg/cli/completion"
+	"github.com/loft-sh/vcluster/pkg/cli/config"
+	"github.com/loft-sh/vcluster/pkg/cli/flags"
+	"github.com/spf13/cobra"
+)
+
+// DescribeCmd holds the describe cmd flags
+type DescribeCmd struct {
+	*flags.GlobalFlags
+	cli.DescribeOptions
+
+	Driver string
+
+	log log.Logger
+}
+
+// NewDescribeCmd creates a new command
+func NewDescribeCmd(globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &DescribeCmd{
+		GlobalFlags: globalFlags,
+		log:         log.GetInstance(),
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:   "describe VCLUSTER_NAME TYPE/NAME",
+		Short: "Describes how an object is synced between the virtual cluster and the host cluster",
+		Long: `#######################################################
+################## vcluster describe ##################
+#######################################################
+Describe resolves the host object of an object within
+the virtual cluster (or the virtual object of a host object
+with --host) and shows both objects, the fields that were
+translated by vCluster and the related host events. With
+--collisions, describe lists all objects of TYPE that are
+translated to the same host name.
+
+Example:
+vcluster describe test pod/nginx --object-namespace default
+vcluster describe test svc/kube-dns --object-namespace kube-system
+vcluster describe test pod/nginx-x-default-x-test --host --namespace test
+vcluster describe test configmaps --collisions
+#######################################################
+	`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completion.NewValidVClusterNameFunc(globalFlags),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+	}
+
+	cobraCmd.Flags().StringVar(&cmd.Driver, "driver", "", "The driver for the virtual cluster, can be either helm or platform.")
+	cobraCmd.Flags().StringVar(&cmd.ObjectNamespace, "object-namespace", "", "The namespace of the object. Defaults to the default namespace for virtual objects and the vCluster namespace for host objects")
+	cobraCmd.Flags().BoolVar(&cmd.Host, "host", false, "If enabled, TYPE/NAME refers to an object in the host cluster")
+	cobraCmd.Flags().BoolVar(&cmd.Collisions, "collisions", false, "If enabled, lists all virtual objects of TYPE that are translated to the same host name")
+	cobraCmd.Flags().StringVar(&cmd.Output, "output", "table", "Choose the format of the output. [table|json]")
+
+	return cobraCmd
+}
+
+// Run executes the functionality
+func (cmd *DescribeCmd) Run(ctx context.Context, args []string) error {
+	cfg := cmd.LoadedConfig(cmd.log)
+
+	// If driver has been passed as flag use it, otherwise read it from the config file
+	driverType, err := config.ParseDriverType(cmp.Or(cmd.Driver, string(cfg.Driver.Type)))
+	if err != nil {
+		return fmt.Errorf("parse driver type: %w", err)
+	}
+	if driverType == config.PlatformDriver {
+		return fmt.Errorf("describe is currently only supported with the helm driver")
+	}
+
+	return cli.DescribeHelm(ctx, &cmd.DescribeOptions, cmd.GlobalFlags, args[0], args[1], cmd.log)
+}
@@ -11,10 +11,13 @@ import (
 	"github.com/mitchellh/go-homedir"
 
 	"github.com/loft-sh/log"
+	"github.com/loft-sh/vcluster/cmd/vclusterctl/cmd/certs"
 	"github.com/loft-sh/vcluster/cmd/vclusterctl/cmd/convert"
+	"github.com/loft-sh/vcluster/cmd/vclusterctl/cmd/credentials"
 	"github.com/loft-sh/vcluster/cmd/vclusterctl/cmd/credits"
 	cmdplatform "github.com/loft-sh/vcluster/cmd/vclusterctl/cmd/platform"
 	"github.com/loft-sh/vcluster/cmd/vclusterctl/cmd/platform/set"
+	"github.com/loft-sh/vcluster/cmd/vclusterctl/cmd/snapshot"
 	cmdtelemetry "github.com/loft-sh/vcluster/cmd/vclusterctl/cmd/telemetry"
 	"github.com/loft-sh/vcluster/cmd/vclusterctl/cmd/use"
 	"github.com/loft-sh/vcluster/pkg/cli/completion"
@@ -126,6 +129,10 @@ func BuildRoot(log log.Logger) (*cobra.Command, *flags.GlobalFlags, error) {
 	rootCmd.AddCommand(cmdtelemetry.NewTelemetryCmd(globalFlags))
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(NewInfoCmd(globalFlags))
+	rootCmd.AddCommand(NewDescribeCmd(globalFlags))
+	rootCmd.AddCommand(snapshot.NewSnapshotCmd(globalFlags))
+	rootCmd.AddCommand(certs.NewCertsCmd(globalFlags))
+	rootCmd.AddCommand(credentials.NewCredentialsCmd(globalFlags))
 	rootCmd.AddCommand(set.NewSetCmd(globalFlags, defaults))
 
 	// add platform commands
@@ -0,0 +1,60 @@
+package snapshot
+
+import (
+	"context"
+
+	"github.com/loft-sh/log"
+	"github.com/loft-sh/vcluster/pkg/cli"
+	"github.com/loft-sh/vcluster/pkg/cli/completion"
+	"github.com/loft-sh/vcluster/pkg/cli/flags"
+	"github.com/spf13/cobra"
+)
+
+type createCmd struct {
+	snapshotCmd
+}
+
+func create(globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &createCmd{
+		snapshotCmd: snapshotCmd{
+			GlobalFlags: globalFlags,
+			log:         log.GetInstance(),
+		},
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:   "create VCLUSTER_NAME LOCATION",
+		Short: "Creates a snapshot of a virtual cluster",
+		Long: `#######################################################
+############## vcluster snapshot create ###############
+#######################################################
+Creates a snapshot of the backing store and certificates
+of the given virtual cluster. The location can either be
+a local file path or an S3 location in the form of
+s3://bucket/key.
+
+Example:
+vcluster snapshot create test ./test.tar.gz --namespace test
+vcluster snapshot create test s3://my-bucket/test.tar.gz --s3-endpoint minio.example.com:9000
+#######################################################
+	`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completion.NewValidVClusterNameFunc(globalFlags),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+	}
+
+	cmd.addFlags(cobraCmd)
+	return cobraCmd
+}
+
+// Run executes the functionality
+func (cmd *createCmd) Run(ctx context.Context, args []string) error {
+	err := cmd.validateDriver()
+	if err != nil {
+		return err
+	}
+
+	return cli.SnapshotCreateHelm(ctx, &cmd.SnapshotOptions, cmd.GlobalFlags, args[0], args[1], cmd.log)
+}
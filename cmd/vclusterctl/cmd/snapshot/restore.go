@@ -0,0 +1,80 @@
+package snapshot
+
+import (
+	"context"
+
+	"github.com/loft-sh/log"
+	vclusterconfig "github.com/loft-sh/vcluster/config"
+	"github.com/loft-sh/vcluster/pkg/cli"
+	"github.com/loft-sh/vcluster/pkg/cli/completion"
+	"github.com/loft-sh/vcluster/pkg/cli/flags"
+	"github.com/loft-sh/vcluster/pkg/constants"
+	"github.com/loft-sh/vcluster/pkg/upgrade"
+	"github.com/spf13/cobra"
+)
+
+type restoreCmd struct {
+	snapshotCmd
+}
+
+func restore(globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &restoreCmd{
+		snapshotCmd: snapshotCmd{
+			GlobalFlags: globalFlags,
+			SnapshotOptions: cli.SnapshotOptions{
+				CreateOptions: cli.CreateOptions{
+					ChartName:       "vcluster",
+					ChartRepo:       constants.LoftChartRepo,
+					Distro:          vclusterconfig.K8SDistro,
+					CreateNamespace: true,
+					UpdateCurrent:   true,
+				},
+			},
+			log: log.GetInstance(),
+		},
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:   "restore VCLUSTER_NAME LOCATION",
+		Short: "Restores a virtual cluster from a snapshot",
+		Long: `#######################################################
+############## vcluster snapshot restore ##############
+#######################################################
+Restores the backing store and certificates of the given
+virtual cluster from a snapshot. The virtual cluster is
+stopped during the restore and started again afterwards.
+Objects that were created after the snapshot was taken
+are removed. With --create, a new virtual cluster is
+created to restore the snapshot into, which keeps its
+own certificates.
+
+Example:
+vcluster snapshot restore test ./test.tar.gz --namespace test
+vcluster snapshot restore test s3://my-bucket/test.tar.gz --s3-endpoint minio.example.com:9000
+vcluster snapshot restore test-copy ./test.tar.gz --namespace test-copy --create -f values.yaml
+#######################################################
+	`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completion.NewValidVClusterNameFunc(globalFlags),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+	}
+
+	cmd.addFlags(cobraCmd)
+	cobraCmd.Flags().BoolVar(&cmd.Create, "create", false, "If enabled, creates a new virtual cluster to restore the snapshot into")
+	cobraCmd.Flags().StringVar(&cmd.CreateOptions.ChartVersion, "chart-version", upgrade.GetVersion(), "The virtual cluster chart version to use for --create")
+	cobraCmd.Flags().StringArrayVarP(&cmd.CreateOptions.Values, "values", "f", []string{}, "Path where to load extra helm values from for --create")
+	cobraCmd.Flags().StringArrayVar(&cmd.CreateOptions.SetValues, "set", []string{}, "Set values for helm for --create. E.g. --set 'persistence.enabled=true'")
+	return cobraCmd
+}
+
+// Run executes the functionality
+func (cmd *restoreCmd) Run(ctx context.Context, args []string) error {
+	err := cmd.validateDriver()
+	if err != nil {
+		return err
+	}
+
+	return cli.SnapshotRestoreHelm(ctx, &cmd.SnapshotOptions, cmd.GlobalFlags, args[0], args[1], cmd.log)
+}
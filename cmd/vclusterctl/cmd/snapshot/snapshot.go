@@ -0,0 +1,65 @@
+package snapshot
+
+import (
+	"cmp"
+	"fmt"
+
+	"github.com/loft-sh/log"
+	"github.com/loft-sh/vcluster/pkg/cli"
+	"github.com/loft-sh/vcluster/pkg/cli/config"
+	"github.com/loft-sh/vcluster/pkg/cli/flags"
+	"github.com/loft-sh/vcluster/pkg/snapshot"
+	"github.com/spf13/cobra"
+)
+
+type snapshotCmd struct {
+	*flags.GlobalFlags
+	cli.SnapshotOptions
+
+	Driver string
+
+	log log.Logger
+}
+
+func NewSnapshotCmd(globalFlags *flags.GlobalFlags) *cobra.Command {
+	snapshotCmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Snapshot and restore virtual clusters",
+		Long: `#######################################################
+################# vcluster snapshot ###################
+#######################################################
+Snapshot and restore the backing store (etcd, kine or
+external database) together with the certificates of a
+virtual cluster.
+	`,
+		Args: cobra.NoArgs,
+	}
+
+	snapshotCmd.AddCommand(create(globalFlags))
+	snapshotCmd.AddCommand(restore(globalFlags))
+	return snapshotCmd
+}
+
+func (cmd *snapshotCmd) addFlags(cobraCmd *cobra.Command) {
+	cobraCmd.Flags().StringVar(&cmd.Driver, "driver", "", "The driver for the virtual cluster, can be either helm or platform.")
+	cobraCmd.Flags().StringVar(&cmd.Storage.S3.Endpoint, "s3-endpoint", snapshot.DefaultS3Endpoint, "The S3 compatible endpoint to use for s3:// locations")
+	cobraCmd.Flags().StringVar(&cmd.Storage.S3.Region, "s3-region", "", "The region of the S3 bucket")
+	cobraCmd.Flags().StringVar(&cmd.Storage.S3.AccessKeyID, "s3-access-key-id", "", "The S3 access key id. If empty, credentials are read from the environment or the AWS credentials file")
+	cobraCmd.Flags().StringVar(&cmd.Storage.S3.SecretAccessKey, "s3-secret-access-key", "", "The S3 secret access key")
+	cobraCmd.Flags().BoolVar(&cmd.Storage.S3.Insecure, "s3-insecure", false, "If enabled, connects to the S3 endpoint via plain http")
+}
+
+func (cmd *snapshotCmd) validateDriver() error {
+	cfg := cmd.LoadedConfig(cmd.log)
+
+	// If driver has been passed as flag use it, otherwise read it from the config file
+	driverType, err := config.ParseDriverType(cmp.Or(cmd.Driver, string(cfg.Driver.Type)))
+	if err != nil {
+		return fmt.Errorf("parse driver type: %w", err)
+	}
+	if driverType == config.PlatformDriver {
+		return fmt.Errorf("snapshot is currently only supported with the helm driver")
+	}
+
+	return nil
+}
@@ -233,11 +233,22 @@ func (c *Config) IsConfiguredForSleepMode() bool {
 	return c.External["platform"]["autoSleep"] != nil || c.External["platform"]["autoDelete"] != nil
 }
 
+// MultiNamespaceMode returns true if virtual namespaces are synced to their own host namespaces
+func (c *Config) MultiNamespaceMode() bool {
+	return c.Sync.ToHost.Namespaces.Enabled || c.Experimental.MultiNamespaceMode.Enabled
+}
+
 // ValidateChanges checks for disallowed config changes.
 // Currently only certain backingstore changes are allowed but no distro change.
 func ValidateChanges(oldCfg, newCfg *Config) error {
 	oldDistro, newDistro := oldCfg.Distro(), newCfg.Distro()
 	oldBackingStore, newBackingStore := oldCfg.BackingStoreType(), newCfg.BackingStoreType()
+	if oldCfg.MultiNamespaceMode() && !newCfg.MultiNamespaceMode() {
+		return fmt.Errorf("seems like you were using multi namespace mode before and now have disabled it, please make sure to not switch from multi namespace mode back to single namespace mode")
+	}
+	if oldCfg.ControlPlane.Encryption.Enabled && !newCfg.ControlPlane.Encryption.Enabled {
+		return fmt.Errorf("seems like you were using encryption at rest before and now have disabled it, please make sure to not disable encryption as existing resources cannot be read anymore")
+	}
 
 	return ValidateStoreAndDistroChanges(newBackingStore, oldBackingStore, newDistro, oldDistro)
 }
@@ -279,10 +290,6 @@ func (c *Config) IsProFeatureEnabled() bool {
 		return true
 	}
 
-	if len(c.Policies.CentralAdmission.ValidatingWebhooks) > 0 || len(c.Policies.CentralAdmission.MutatingWebhooks) > 0 {
-		return true
-	}
-
 	if c.ControlPlane.HostPathMapper.Central {
 		return true
 	}
@@ -299,10 +306,6 @@ func (c *Config) IsProFeatureEnabled() bool {
 		return true
 	}
 
-	if len(c.Experimental.DenyProxyRequests) > 0 {
-		return true
-	}
-
 	if len(c.External["platform"]) > 0 {
 		return true
 	}
@@ -356,7 +359,7 @@ type SyncToHost struct {
 	Pods SyncPods `json:"pods,omitempty"`
 
 	// Secrets defines if secrets created within the virtual cluster should get synced to the host cluster.
-	Secrets SyncAllResource `json:"secrets,omitempty"`
+	Secrets SyncSecrets `json:"secrets,omitempty"`
 
 	// ConfigMaps defines if config maps created within the virtual cluster should get synced to the host cluster.
 	ConfigMaps SyncAllResource `json:"configMaps,omitempty"`
@@ -389,10 +392,39 @@ type SyncToHost struct {
 	ServiceAccounts EnableSwitch `json:"serviceAccounts,omitempty"`
 
 	// PodDisruptionBudgets defines if pod disruption budgets created within the virtual cluster should get synced to the host cluster.
+	// The status of the pod disruption budgets is synced back from the host cluster and pod evictions within the virtual cluster
+	// are executed through the host cluster eviction api, so that host pod disruption budgets are respected.
 	PodDisruptionBudgets EnableSwitch `json:"podDisruptionBudgets,omitempty"`
 
 	// PriorityClasses defines if priority classes created within the virtual cluster should get synced to the host cluster.
 	PriorityClasses EnableSwitch `json:"priorityClasses,omitempty"`
+
+	// Gateway defines if Gateway API routes created within the virtual cluster should get synced to the host cluster.
+	Gateway SyncToHostGateway `json:"gateway,omitempty"`
+
+	// Jobs defines if jobs created within the virtual cluster should get synced to the host cluster as jobs instead of
+	// being run as pods by the virtual job controller. The job controller within the virtual cluster will be disabled
+	// and the job status is synced back from the host cluster. Cron jobs are not synced to the host cluster: they are still
+	// scheduled by the cron job controller within the virtual cluster and only the jobs they create are synced as host jobs.
+	Jobs EnableSwitch `json:"jobs,omitempty"`
+
+	// Namespaces defines if namespaces created within the virtual cluster should get synced to the host cluster. Each virtual
+	// namespace is mapped to its own host namespace instead of syncing all namespaced resources into a single host namespace.
+	Namespaces SyncToHostNamespaces `json:"namespaces,omitempty"`
+
+	// Naming defines how vCluster names the host objects of namespaced virtual objects in single namespace mode.
+	Naming SyncToHostNaming `json:"naming,omitempty"`
+
+	// CustomResources defines what custom resources should get synced from the virtual cluster to the host cluster. The key
+	// of the map is the name of the custom resource definition in the form resource.group, e.g. certificates.cert-manager.io.
+	// vCluster will copy the custom resource definition from the host cluster into the virtual cluster and sync the status
+	// of the resources back from the host cluster. Only namespaced resources are supported.
+	CustomResources map[string]SyncToHostCustomResource `json:"customResources,omitempty"`
+}
+
+type SyncToHostCustomResource struct {
+	// Enabled defines if this option should be enabled.
+	Enabled bool `json:"enabled,omitempty"`
 }
 
 type SyncFromHost struct {
@@ -416,6 +448,51 @@ type SyncFromHost struct {
 
 	// CSIStorageCapacities defines if csi storage capacities should get synced from the host cluster to the virtual cluster, but not back. If auto, is automatically enabled when the virtual scheduler is enabled.
 	CSIStorageCapacities EnableAutoSwitch `json:"csiStorageCapacities,omitempty"`
+
+	// VolumeAttachments defines if volume attachments of persistent volumes that belong to the virtual cluster should get synced from the host cluster to the virtual cluster, but not back.
+	// This allows tenants to see why a volume is stuck attaching.
+	VolumeAttachments EnableSwitch `json:"volumeAttachments,omitempty"`
+
+	// Gateways defines if gateway classes and gateways should get synced from the host cluster to the virtual cluster, but not back. This allows
+	// routes within the virtual cluster to attach to shared gateways of the host cluster.
+	Gateways SyncFromHostGateways `json:"gateways,omitempty"`
+
+	// ResourceQuotas defines if the resource quotas and limit ranges of the host namespace should be readable within the virtual cluster.
+	// This allows tenants to see how much of the quota enforced by the host cluster they have already consumed. Cannot be used together
+	// with sync.toHost.namespaces.
+	ResourceQuotas SyncFromHostResourceQuotas `json:"resourceQuotas,omitempty"`
+
+	// CustomResources defines what custom resources should get synced read-only from the host cluster to the virtual cluster. The key
+	// of the map is the name of the custom resource definition in the form resource.group, e.g. clusterissuers.cert-manager.io.
+	// vCluster will copy the custom resource definition from the host cluster into the virtual cluster. Only cluster scoped resources are supported.
+	CustomResources map[string]SyncFromHostCustomResource `json:"customResources,omitempty"`
+}
+
+type SyncFromHostGateways struct {
+	// Enabled defines if this option should be enabled.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Namespaces are the host namespaces gateways are synced from. Gateways in other host namespaces are not visible
+	// within the virtual cluster. Required if enabled.
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Selector are the labels a host gateway needs to have to get synced. If empty, all gateways in the namespaces are synced.
+	Selector map[string]string `json:"selector,omitempty"`
+}
+
+type SyncFromHostResourceQuotas struct {
+	// Enabled defines if this option should be enabled.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Namespace is the virtual namespace the host resource quotas and limit ranges are served in. Requests for resource quotas
+	// and limit ranges in this namespace are answered read-only from the host namespace, so the objects are never stored
+	// in the virtual cluster and are not enforced by it. Writes to them are rejected.
+	Namespace string `json:"namespace,omitempty"`
+}
+
+type SyncFromHostCustomResource struct {
+	// Enabled defines if this option should be enabled.
+	Enabled bool `json:"enabled,omitempty"`
 }
 
 type EnableAutoSwitch struct {
@@ -436,6 +513,54 @@ type SyncAllResource struct {
 	All bool `json:"all,omitempty"`
 }
 
+type SyncSecrets struct {
+	// Enabled defines if this option should be enabled.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// All defines if all resources of that type should get synced or only the necessary ones that are needed.
+	All bool `json:"all,omitempty"`
+
+	// Backend defines how secrets that are referenced by pods are made available to the pods on the host cluster.
+	Backend SecretBackend `json:"backend,omitempty"`
+}
+
+const (
+	SecretBackendTypeHost = "host"
+	SecretBackendTypeCSI  = "csi"
+	SecretBackendTypeKMS  = "kms"
+)
+
+type SecretBackend struct {
+	// Type of the secret backend. Can be either host, csi or kms. If host, secrets referenced by pods are copied into the host namespace.
+	// If csi, secret volumes are replaced with csi volumes and the secret data is never written to the host cluster. The csi provider
+	// reads the secret from the virtual cluster. If kms, secrets are copied into the host namespace, but their values are encrypted with
+	// a per-vCluster data key that is encrypted by the kms plugin. Secret volumes are replaced with csi volumes and the csi provider
+	// decrypts the host secret through the same kms plugin. Image pull secrets are not encrypted, because the kubelet needs to read them.
+	// Pods that reference secrets in environment variables or projected volumes are rejected with csi and kms.
+	// The volume attributes passed to the csi provider are defined in the github.com/loft-sh/vcluster/pkg/secretbackend package.
+	Type string `json:"type,omitempty"`
+
+	// CSI defines the csi volume that replaces secret volumes if type is csi or kms.
+	CSI SecretBackendCSI `json:"csi,omitempty"`
+
+	// KMS defines the kms v2 plugin that encrypts the data key if type is kms. The socket needs to be mounted into the
+	// vCluster control plane container and the csi provider.
+	KMS ControlPlaneEncryptionKMS `json:"kms,omitempty"`
+}
+
+type SecretBackendCSI struct {
+	// Driver is the name of the csi driver that is used for the secret volumes.
+	Driver string `json:"driver,omitempty"`
+
+	// SecretProviderClass is the name of the secret provider class in the host namespace that is passed to the csi driver.
+	SecretProviderClass string `json:"secretProviderClass,omitempty"`
+
+	// VolumeAttributes are additional attributes that are passed to the csi driver. vCluster adds the name and namespace
+	// of the virtual secret, the host namespace, the virtual cluster endpoint, the items, default mode and optional flag
+	// of the secret volume as well as the name of the virtual cluster.
+	VolumeAttributes map[string]string `json:"volumeAttributes,omitempty"`
+}
+
 type SyncPods struct {
 	// Enabled defines if pod syncing should be enabled.
 	Enabled bool `json:"enabled,omitempty"`
@@ -444,9 +569,18 @@ type SyncPods struct {
 	// a certain image that is used within the virtual cluster to be another image on the host cluster
 	TranslateImage map[string]string `json:"translateImage,omitempty"`
 
+	// TranslateImageRules are pattern based rules to rewrite images of containers, init containers and ephemeral containers
+	// on the host cluster. Exact matches in translateImage take precedence, otherwise the first matching rule is used. The
+	// original image is recorded in the vcluster.loft.sh/original-images annotation of the host pod.
+	TranslateImageRules []TranslateImageRule `json:"translateImageRules,omitempty"`
+
 	// EnforceTolerations will add the specified tolerations to all pods synced by the virtual cluster.
 	EnforceTolerations []string `json:"enforceTolerations,omitempty"`
 
+	// SchedulingPolicy enforces or defaults scheduling related fields of all pods synced to the host cluster, for example
+	// to keep the workloads of the virtual cluster on dedicated host node pools.
+	SchedulingPolicy SyncPodsSchedulingPolicy `json:"schedulingPolicy,omitempty"`
+
 	// UseSecretsForSATokens will use secrets to save the generated service account tokens by virtual cluster instead of using a
 	// pod annotation.
 	UseSecretsForSATokens bool `json:"useSecretsForSATokens,omitempty"`
@@ -457,6 +591,47 @@ type SyncPods struct {
 	RewriteHosts SyncRewriteHosts `json:"rewriteHosts,omitempty"`
 }
 
+type SyncToHostNamespaces struct {
+	// Enabled defines if namespaces created within the virtual cluster should get synced to the host cluster. Switching an existing
+	// vCluster from single namespace mode to this mode deletes the previously synced workloads from the old host namespace, so that they
+	// are recreated in their new host namespaces. Persistent volume claims are not migrated. Switching back is not supported.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// HostNameTemplate is a go template that defines the name of the host namespace for a virtual namespace. Available variables are
+	// .vcluster (the vCluster name), .vclusterNamespace (the namespace of the vCluster), .namespace (the virtual namespace name) and
+	// .hash (a short hash of the virtual namespace name), e.g. {{.vcluster}}-{{.namespace}}. The template needs to reference either
+	// .namespace or .hash. If empty, vcluster-<hash>-<vcluster hash> is used.
+	HostNameTemplate string `json:"hostNameTemplate,omitempty"`
+
+	// Labels are extra labels that will be added by vCluster to each created host namespace.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations are extra annotations that will be added by vCluster to each created host namespace.
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type SyncToHostNaming struct {
+	// Strategy is the naming strategy for host objects. Can be either "default" (name-x-namespace-x-vcluster, hashed if too long),
+	// "hash" (a hash of name, namespace and vCluster name) or "template" (rendered from template). Existing host objects keep their
+	// name when the strategy is changed, as vCluster looks them up by the host name recorded in the sync status of the virtual object,
+	// which means the strategy cannot be changed if experimental.syncSettings.disableSyncStatus is set.
+	Strategy string `json:"strategy,omitempty"`
+
+	// Template is a go template that defines the host object name if strategy is "template". Available variables are .name, .namespace,
+	// .vcluster and .hash (a short hash of name, namespace and vCluster name). The template needs to reference .hash, so that host names
+	// are unique, e.g. {{.namespace}}-{{.name}}-{{.hash}}. Names longer than 63 characters are shortened with a hash.
+	Template string `json:"template,omitempty"`
+}
+
+type SyncToHostGateway struct {
+	// Enabled defines if HTTPRoutes, GRPCRoutes and ReferenceGrants created within the virtual cluster should get synced to the host cluster.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// TLSRoutes defines if TLSRoutes created within the virtual cluster should get synced to the host cluster as well. This requires the
+	// experimental Gateway API CRDs to be installed in the host cluster.
+	TLSRoutes EnableSwitch `json:"tlsRoutes,omitempty"`
+}
+
 type SyncRewriteHosts struct {
 	// Enabled specifies if rewriting stateful set pods should be enabled.
 	Enabled bool `json:"enabled,omitempty"`
@@ -473,6 +648,55 @@ type SyncRewriteHostsInitContainer struct {
 	Resources Resources `json:"resources,omitempty"`
 }
 
+type SyncPodsSchedulingPolicy struct {
+	// Enforce applies the given fields to all pods regardless of what the virtual pod specifies. Node selectors are merged,
+	// required node affinity terms are combined with the ones of the pod, so that both need to match, other affinity terms are
+	// appended and all remaining fields are overwritten.
+	Enforce PodSchedulingPolicy `json:"enforce,omitempty"`
+
+	// Default applies the given fields to pods that do not specify them.
+	Default PodSchedulingPolicy `json:"default,omitempty"`
+}
+
+type PodSchedulingPolicy struct {
+	// NodeSelector is the node selector to apply to the pod.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Affinity is the affinity to apply to the pod.
+	Affinity map[string]interface{} `json:"affinity,omitempty"`
+
+	// TopologySpreadConstraints are the topology spread constraints to apply to the pod.
+	TopologySpreadConstraints []map[string]interface{} `json:"topologySpreadConstraints,omitempty"`
+
+	// RuntimeClassName is the host runtime class name to apply to the pod.
+	RuntimeClassName string `json:"runtimeClassName,omitempty"`
+
+	// PriorityClassName is the host priority class name to apply to the pod.
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// Resources are the resource requests and limits to apply to each container of the pod. Only the given
+	// resource names are applied, e.g. defaulting the memory limit keeps the cpu limit of a container. Requests that are
+	// greater than the resulting limit are lowered to the limit.
+	Resources Resources `json:"resources,omitempty"`
+}
+
+type TranslateImageRule struct {
+	// Pattern matches an image either exactly or, if it contains a "*", with the "*" matching any characters, e.g. docker.io/*.
+	// Images without a registry are also matched in their normalized form, e.g. nginx as docker.io/library/nginx.
+	Pattern string `json:"pattern,omitempty"`
+
+	// Regex matches an image with a regular expression that needs to match the complete image. Replacement can reference
+	// capture groups, e.g. $1.
+	Regex string `json:"regex,omitempty"`
+
+	// Replacement is the image to use on the host cluster. If pattern contains a "*", the "*" in replacement is substituted
+	// by the matched characters, e.g. mirror.corp/dockerhub/*. A digest can be used to pin an image, e.g. nginx@sha256:...
+	Replacement string `json:"replacement,omitempty"`
+
+	// Namespaces limits the rule to pods within the given virtual cluster namespaces. If empty, the rule applies to all namespaces.
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
 type SyncNodes struct {
 	// Enabled specifies if syncing real nodes should be enabled. If this is disabled, vCluster will create fake nodes instead.
 	Enabled bool `json:"enabled,omitempty"`
@@ -680,6 +904,15 @@ type ControlPlane struct {
 	// BackingStore defines which backing store to use for virtual cluster. If not defined will use embedded database as a default backing store.
 	BackingStore BackingStore `json:"backingStore,omitempty"`
 
+	// Encryption defines if and how resources should be encrypted at rest in the backing store of the virtual cluster.
+	Encryption ControlPlaneEncryption `json:"encryption,omitempty"`
+
+	// Auth defines additional ways for users to authenticate against the virtual api server.
+	Auth ControlPlaneAuth `json:"auth,omitempty"`
+
+	// Certificates defines options for the certificates vCluster generates for the virtual control plane.
+	Certificates ControlPlaneCertificates `json:"certificates,omitempty"`
+
 	// CoreDNS defines everything related to the coredns that is deployed and used within the vCluster.
 	CoreDNS CoreDNS `json:"coredns,omitempty"`
 
@@ -709,6 +942,138 @@ func (c ControlPlane) JSONSchemaExtend(base *jsonschema.Schema) {
 	addProToJSONSchema(base, reflect.TypeOf(c))
 }
 
+const (
+	EncryptionProviderAESCBC    = "aescbc"
+	EncryptionProviderSecretbox = "secretbox"
+	EncryptionProviderKMS       = "kms"
+)
+
+type ControlPlaneEncryption struct {
+	// Enabled defines if encryption at rest should be enabled. vCluster generates an encryption configuration,
+	// stores it alongside the certificates and passes it to the virtual api server.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Provider is the encryption provider used to encrypt new data. Can be either aescbc, secretbox or kms.
+	// Keys of previously used providers are kept to be able to read existing data.
+	Provider string `json:"provider,omitempty"`
+
+	// Resources are the resources that should be encrypted.
+	Resources []string `json:"resources,omitempty"`
+
+	// KeyRotationPeriod defines after which duration a new key is generated for the aescbc and secretbox provider, e.g. 720h.
+	// The key is rotated on the next start of vCluster after the period has passed and all resources are rewritten with the new key.
+	// If empty, keys are never rotated.
+	KeyRotationPeriod string `json:"keyRotationPeriod,omitempty"`
+
+	// KMS defines the kms v2 plugin that is used if provider is kms.
+	KMS ControlPlaneEncryptionKMS `json:"kms,omitempty"`
+}
+
+type ControlPlaneEncryptionKMS struct {
+	// Name is the name of the kms plugin.
+	Name string `json:"name,omitempty"`
+
+	// Endpoint is the unix socket of the kms plugin, e.g. unix:///var/run/kms-plugin/socket.sock. The socket
+	// needs to be mounted into the vCluster control plane container.
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Timeout for calls to the kms plugin, e.g. 3s.
+	Timeout string `json:"timeout,omitempty"`
+}
+
+type ControlPlaneAuth struct {
+	// OIDC configures the virtual api server to authenticate users via OpenID Connect id tokens.
+	OIDC ControlPlaneAuthOIDC `json:"oidc,omitempty"`
+}
+
+type ControlPlaneAuthOIDC struct {
+	// Enabled defines if OIDC authentication should be enabled.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// IssuerURL is the https URL of the OpenID provider.
+	IssuerURL string `json:"issuerURL,omitempty"`
+
+	// ClientID is the client ID all tokens must be issued for.
+	ClientID string `json:"clientID,omitempty"`
+
+	// CA is the PEM encoded certificate authority that signed the serving certificate of the OpenID provider. If empty,
+	// the system trust store is used.
+	CA string `json:"ca,omitempty"`
+
+	// UsernameClaim is the JWT claim to use as the user name.
+	UsernameClaim string `json:"usernameClaim,omitempty"`
+
+	// UsernamePrefix is prepended to user names to prevent clashes with existing names. Use "-" to disable prefixing.
+	UsernamePrefix string `json:"usernamePrefix,omitempty"`
+
+	// GroupsClaim is the JWT claim to use as the user's groups.
+	GroupsClaim string `json:"groupsClaim,omitempty"`
+
+	// GroupsPrefix is prepended to group names to prevent clashes with existing names.
+	GroupsPrefix string `json:"groupsPrefix,omitempty"`
+
+	// RequiredClaims are claims that must be present in the id token with the given values.
+	RequiredClaims map[string]string `json:"requiredClaims,omitempty"`
+
+	// ExtraScopes are additional scopes that 'vcluster connect --oidc' requests from the OpenID provider, e.g. groups.
+	ExtraScopes []string `json:"extraScopes,omitempty"`
+}
+
+type ControlPlaneCertificates struct {
+	// Renewal defines if and when vCluster renews its certificates automatically.
+	Renewal ControlPlaneCertificatesRenewal `json:"renewal,omitempty"`
+
+	// CA defines an external certificate authority that issues the certificates of the virtual control plane instead of a
+	// self-generated one. Components still authenticate via an internal client CA.
+	CA ControlPlaneCertificatesCA `json:"ca,omitempty"`
+
+	// CertManager defines if the serving certificate of the virtual api server should be requested from cert-manager on the host cluster.
+	CertManager ControlPlaneCertificatesCertManager `json:"certManager,omitempty"`
+}
+
+type ControlPlaneCertificatesCA struct {
+	// SecretName is the name of a secret within the vCluster namespace that holds the CA certificate (tls.crt) and key (tls.key).
+	// The CA has to be an intermediate CA dedicated to this vCluster that is restricted via name constraints, as its key is
+	// copied into the certs secret of the vCluster. Certificate signing requests are signed by the internal client CA instead.
+	SecretName string `json:"secretName,omitempty"`
+}
+
+type ControlPlaneCertificatesCertManager struct {
+	// Enabled defines if vCluster should create a cert-manager Certificate on the host cluster and serve its certificate.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// IssuerRef references the cert-manager issuer that should sign the serving certificate.
+	IssuerRef CertManagerIssuerRef `json:"issuerRef,omitempty"`
+}
+
+type CertManagerIssuerRef struct {
+	// Name of the issuer.
+	Name string `json:"name,omitempty"`
+
+	// Kind of the issuer, e.g. Issuer or ClusterIssuer.
+	Kind string `json:"kind,omitempty"`
+
+	// Group of the issuer, defaults to cert-manager.io.
+	Group string `json:"group,omitempty"`
+}
+
+type ControlPlaneCertificatesRenewal struct {
+	// Enabled defines if vCluster should renew certificates automatically before they expire. After certificates were
+	// renewed, the vCluster pods are restarted one by one to pick up the new certificates.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// RenewBefore defines how long before their expiry certificates are renewed, e.g. 720h.
+	RenewBefore string `json:"renewBefore,omitempty"`
+
+	// RotateCA defines if the cluster CA should be rotated as well once it expires within renewBefore.
+	RotateCA bool `json:"rotateCA,omitempty"`
+
+	// CATrustPeriod defines how long the new CA is only trusted after the CA was rotated, while the previous CA still issues and
+	// serves all certificates, so that existing kube configs keep working, e.g. 24h. Afterward, all certificates are re-issued
+	// by the new CA. If empty, the new CA is used right away and existing kube configs stop working.
+	CATrustPeriod string `json:"caTrustPeriod,omitempty"`
+}
+
 type ControlPlaneStatefulSet struct {
 	// HighAvailability holds options related to high availability.
 	HighAvailability ControlPlaneHighAvailability `json:"highAvailability,omitempty"`
@@ -1110,6 +1475,67 @@ type ControlPlaneProxy struct {
 
 	// ExtraSANs are extra hostnames to sign the vCluster proxy certificate for.
 	ExtraSANs []string `json:"extraSANs,omitempty"`
+
+	// Audit configures audit logging for requests served by the vCluster proxy, including exec, attach,
+	// port-forward and log requests that are redirected to the host cluster.
+	Audit ControlPlaneProxyAudit `json:"audit,omitempty"`
+
+	// RequestRules allows or denies requests served by the vCluster proxy before they reach the virtual api server.
+	RequestRules ControlPlaneProxyRequestRules `json:"requestRules,omitempty"`
+}
+
+type ControlPlaneProxyRequestRules struct {
+	// DryRun only logs and counts requests that would be denied by the rules instead of denying them.
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// Deny denies all requests that match any of the rules.
+	Deny []DenyRule `json:"deny,omitempty"`
+
+	// Allow denies all requests that don't match any of the rules. Health and discovery requests are always allowed. If empty, all requests that are not denied are allowed.
+	Allow []DenyRule `json:"allow,omitempty"`
+}
+
+type ControlPlaneProxyAudit struct {
+	// Enabled defines if audit logging is enabled for the vCluster proxy.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Policy defines which requests are recorded and at which level. If no rules are specified, exec, attach,
+	// port-forward, log and node proxy requests are recorded at the Metadata level.
+	Policy ControlPlaneProxyAuditPolicy `json:"policy,omitempty"`
+
+	// Log configures the log backend that writes audit events as JSON lines to a file.
+	Log ControlPlaneProxyAuditLog `json:"log,omitempty"`
+
+	// Webhook configures the webhook backend that sends audit events to a remote api.
+	Webhook ControlPlaneProxyAuditWebhook `json:"webhook,omitempty"`
+}
+
+type ControlPlaneProxyAuditPolicy struct {
+	// Rules are audit policy rules in the audit.k8s.io/v1 format. The first matching rule sets the audit level of a request.
+	Rules []map[string]interface{} `json:"rules,omitempty"`
+
+	// OmitStages is a list of request stages for which no events are created.
+	OmitStages []string `json:"omitStages,omitempty"`
+}
+
+type ControlPlaneProxyAuditLog struct {
+	// Path is the file audit events are written to. Use "-" to write them to standard out. If empty, the log backend is disabled.
+	Path string `json:"path,omitempty"`
+
+	// MaxAge is the maximum number of days to retain old audit log files.
+	MaxAge int `json:"maxAge,omitempty"`
+
+	// MaxBackups is the maximum number of old audit log files to retain.
+	MaxBackups int `json:"maxBackups,omitempty"`
+
+	// MaxSize is the maximum size in megabytes of the audit log file before it gets rotated.
+	MaxSize int `json:"maxSize,omitempty"`
+}
+
+type ControlPlaneProxyAuditWebhook struct {
+	// Config is a kubeconfig formatted file content that defines the remote api audit events are sent to.
+	// If empty, the webhook backend is disabled.
+	Config string `json:"config,omitempty"`
 }
 
 type ControlPlaneService struct {
@@ -1385,11 +1811,8 @@ type Policies struct {
 	LimitRange LimitRange `json:"limitRange,omitempty"`
 
 	// CentralAdmission defines what validating or mutating webhooks should be enforced within the virtual cluster.
-	CentralAdmission CentralAdmission `json:"centralAdmission,omitempty" product:"pro"`
-}
-
-func (p Policies) JSONSchemaExtend(base *jsonschema.Schema) {
-	addProToJSONSchema(base, reflect.TypeOf(p))
+	// The webhooks are registered within the virtual cluster and webhook services refer to services within the host cluster.
+	CentralAdmission CentralAdmission `json:"centralAdmission,omitempty"`
 }
 
 type ResourceQuota struct {
@@ -1668,9 +2091,12 @@ type Experimental struct {
 	SyncSettings ExperimentalSyncSettings `json:"syncSettings,omitempty"`
 
 	// GenericSync holds options to generically sync resources from virtual cluster to host.
+	// Deprecated: use sync.toHost.customResources and sync.fromHost.customResources instead.
+	// Exports without patches, selectors or hooks are migrated to sync.toHost.customResources automatically.
 	GenericSync ExperimentalGenericSync `json:"genericSync,omitempty"`
 
 	// MultiNamespaceMode tells virtual cluster to sync to multiple namespaces instead of a single one. This will map each virtual cluster namespace to a single namespace in the host cluster.
+	// Deprecated: use sync.toHost.namespaces instead.
 	MultiNamespaceMode ExperimentalMultiNamespaceMode `json:"multiNamespaceMode,omitempty"`
 
 	// IsolatedControlPlane is a feature to run the vCluster control plane in a different Kubernetes cluster than the workloads themselves.
@@ -1680,7 +2106,8 @@ type Experimental struct {
 	VirtualClusterKubeConfig VirtualClusterKubeConfig `json:"virtualClusterKubeConfig,omitempty"`
 
 	// DenyProxyRequests denies certain requests in the vCluster proxy.
-	DenyProxyRequests []DenyRule `json:"denyProxyRequests,omitempty" product:"pro"`
+	// Deprecated: use controlPlane.proxy.requestRules.deny instead.
+	DenyProxyRequests []DenyRule `json:"denyProxyRequests,omitempty"`
 }
 
 func (e Experimental) JSONSchemaExtend(base *jsonschema.Schema) {
@@ -1728,6 +2155,13 @@ type ExperimentalSyncSettings struct {
 	// SyncLabels are labels that should get not rewritten when syncing from the virtual cluster.
 	SyncLabels []string `json:"syncLabels,omitempty"`
 
+	// DisableSyncStatus will not write the vcluster.loft.sh/sync-status annotation to synced virtual objects. The annotation
+	// contains the sync phase, the host object name, the last synced generation and the last sync error.
+	DisableSyncStatus bool `json:"disableSyncStatus,omitempty"`
+
+	// Workqueue configures how events are queued and processed by the syncers.
+	Workqueue SyncWorkqueue `json:"workqueue,omitempty"`
+
 	// HostMetricsBindAddress is the bind address for the local manager
 	HostMetricsBindAddress string `json:"hostMetricsBindAddress,omitempty"`
 
@@ -1739,6 +2173,29 @@ func (e ExperimentalSyncSettings) JSONSchemaExtend(base *jsonschema.Schema) {
 	addProToJSONSchema(base, reflect.TypeOf(e))
 }
 
+type SyncWorkqueue struct {
+	SyncWorkqueueSyncer `json:",inline"`
+
+	// PrioritySyncers are the syncers whose events are processed ahead of all other syncers. While a priority
+	// syncer has queued or in-flight events, workers of all other syncers wait up to a second before taking their next event.
+	// Each entry needs to be the name of a registered syncer, e.g. pod or service.
+	PrioritySyncers []string `json:"prioritySyncers,omitempty"`
+
+	// Syncers overrides the workers, qps and burst for a specific syncer, e.g. secret or configmap.
+	Syncers map[string]SyncWorkqueueSyncer `json:"syncers,omitempty"`
+}
+
+type SyncWorkqueueSyncer struct {
+	// Workers is the number of events a syncer processes concurrently.
+	Workers int `json:"workers,omitempty"`
+
+	// QPS is the maximum number of events per second a syncer processes. 0 means unlimited.
+	QPS int `json:"qps,omitempty"`
+
+	// Burst is the maximum number of events a syncer processes at once if qps is set. Defaults to qps.
+	Burst int `json:"burst,omitempty"`
+}
+
 type ExperimentalDeploy struct {
 	// Host defines what manifests to deploy into the host cluster
 	Host ExperimentalDeployHost `json:"host,omitempty"`
@@ -1924,6 +2381,11 @@ type Patch struct {
 	// Value is the new value to be set to the path
 	Value interface{} `json:"value,omitempty" yaml:"value,omitempty"`
 
+	// Expression is a CEL expression that computes the new value to be set to the path and can be used instead of value
+	// for the add and replace operations. The object that is patched is available as object and the current value
+	// at the path as value, e.g. 'prefix-' + object.metadata.name
+	Expression string `json:"expression,omitempty" yaml:"expression,omitempty"`
+
 	// Regex - is regular expresion used to identify the Name,
 	// and optionally Namespace, parts of the field value that
 	// will be replaced with the rewritten Name and/or Namespace
@@ -1971,6 +2433,10 @@ type PatchCondition struct {
 
 	// Empty means that the path value should be empty or unset
 	Empty *bool `json:"empty,omitempty" yaml:"empty,omitempty"`
+
+	// Expression is a CEL expression that needs to evaluate to true. The object that is patched is available as object
+	// and the current value at the patch path as value, e.g. object.spec.replicas > 3. If set, all other fields are ignored.
+	Expression string `json:"expression,omitempty" yaml:"expression,omitempty"`
 }
 
 type PatchSync struct {
@@ -1979,21 +2445,27 @@ type PatchSync struct {
 }
 
 type DenyRule struct {
-	// The name of the check.
+	// Name of the rule, which is used in the denial message and the metrics.
 	Name string `json:"name,omitempty"`
 
-	// Namespace describe a list of namespaces that will be affected by the check.
+	// Users is a list of users the rule applies to. An empty list means that all users will be affected.
+	Users []string `json:"users,omitempty"`
+
+	// Groups is a list of groups the rule applies to. An empty list means that all groups will be affected.
+	Groups []string `json:"groups,omitempty"`
+
+	// Namespace describe a list of namespaces that will be affected by the rule.
 	// An empty list means that all namespaces will be affected.
 	// In case of ClusterScoped rules, only the Namespace resource is affected.
 	Namespaces []string `json:"namespaces,omitempty"`
 
-	// Rules describes on which verbs and on what resources/subresources the webhook is enforced.
-	// The webhook is enforced if it matches any Rule.
+	// Rules describes on which verbs and on what resources/subresources the rule applies, e.g. pods/exec.
+	// The rule applies if it matches any Rule. If empty, the rule applies to all requests.
 	// The version of the request must match the rule version exactly. Equivalent matching is not supported.
 	Rules []RuleWithVerbs `json:"rules,omitempty"`
 
-	// ExcludedUsers describe a list of users for which the checks will be skipped.
-	// Impersonation attempts on these users will still be subjected to the checks.
+	// ExcludedUsers describe a list of users for which the rule will be skipped.
+	// Impersonation attempts on these users will still be subjected to the rule.
 	ExcludedUsers []string `json:"excludedUsers,omitempty"`
 }
 
@@ -188,7 +188,7 @@ func TestConfig_IsProFeatureEnabled(t *testing.T) {
 					},
 				},
 			},
-			expected: true,
+			expected: false,
 		},
 		{
 			name: "Central Admission Control mutating webhooks used",
@@ -203,7 +203,7 @@ func TestConfig_IsProFeatureEnabled(t *testing.T) {
 					},
 				},
 			},
-			expected: true,
+			expected: false,
 		},
 		{
 			name: "Embedded etcd not used",
@@ -355,7 +355,7 @@ func TestConfig_IsProFeatureEnabled(t *testing.T) {
 					},
 				},
 			},
-			expected: true,
+			expected: false,
 		},
 		{
 			name: "External Platform configuration used",
@@ -0,0 +1,73 @@
+package oidc
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	vclusterconfig "github.com/loft-sh/vcluster/config"
+	"golang.org/x/exp/maps"
+)
+
+// CAFile is the path the certificate authority of the OpenID provider is written to
+const CAFile = "/data/oidc/ca.crt"
+
+// APIServerArgs returns the kube-apiserver flags without leading dashes that configure OIDC authentication. The
+// k0s config template in pkg/k0s sets the same flags.
+func APIServerArgs(oidc vclusterconfig.ControlPlaneAuthOIDC) []string {
+	if !oidc.Enabled {
+		return nil
+	}
+
+	args := []string{
+		"oidc-issuer-url=" + oidc.IssuerURL,
+		"oidc-client-id=" + oidc.ClientID,
+	}
+	if oidc.CA != "" {
+		args = append(args, "oidc-ca-file="+CAFile)
+	}
+	if oidc.UsernameClaim != "" {
+		args = append(args, "oidc-username-claim="+oidc.UsernameClaim)
+	}
+	if oidc.UsernamePrefix != "" {
+		args = append(args, "oidc-username-prefix="+oidc.UsernamePrefix)
+	}
+	if oidc.GroupsClaim != "" {
+		args = append(args, "oidc-groups-claim="+oidc.GroupsClaim)
+	}
+	if oidc.GroupsPrefix != "" {
+		args = append(args, "oidc-groups-prefix="+oidc.GroupsPrefix)
+	}
+	if len(oidc.RequiredClaims) > 0 {
+		claims := maps.Keys(oidc.RequiredClaims)
+		slices.Sort(claims)
+		for idx, claim := range claims {
+			claims[idx] = claim + "=" + oidc.RequiredClaims[claim]
+		}
+
+		args = append(args, "oidc-required-claim="+strings.Join(claims, ","))
+	}
+
+	return args
+}
+
+// WriteCA writes the certificate authority of the OpenID provider to CAFile, so that the virtual api server can verify it
+func WriteCA(oidc vclusterconfig.ControlPlaneAuthOIDC) error {
+	if !oidc.Enabled || oidc.CA == "" {
+		return nil
+	}
+
+	err := os.MkdirAll(filepath.Dir(CAFile), 0755)
+	if err != nil {
+		return fmt.Errorf("create directory %s: %w", filepath.Dir(CAFile), err)
+	}
+
+	err = os.WriteFile(CAFile, []byte(oidc.CA), 0644)
+	if err != nil {
+		return fmt.Errorf("write oidc ca: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package revocation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/loft-sh/vcluster/pkg/credentials"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apiserver/pkg/authentication/authenticator"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// New returns an authenticator that fails requests with a client certificate that was revoked via
+// 'vcluster credentials revoke'. Requests with other client certificates are passed on to the next authenticator.
+func New(client client.Client, vClusterName, namespace string) authenticator.Request {
+	return &revocationAuthenticator{
+		client:    client,
+		name:      credentials.SecretName(vClusterName),
+		namespace: namespace,
+	}
+}
+
+type revocationAuthenticator struct {
+	client    client.Client
+	name      string
+	namespace string
+
+	m       sync.Mutex
+	revoked map[string]bool
+	exp     time.Time
+}
+
+func (r *revocationAuthenticator) AuthenticateRequest(req *http.Request) (*authenticator.Response, bool, error) {
+	if req.TLS == nil || len(req.TLS.PeerCertificates) == 0 {
+		return nil, false, nil
+	}
+
+	revoked, err := r.revokedSerials(req.Context())
+	if err != nil {
+		return nil, false, err
+	}
+
+	serial := req.TLS.PeerCertificates[0].SerialNumber.String()
+	if revoked[serial] {
+		return nil, false, fmt.Errorf("client certificate %s has been revoked", serial)
+	}
+
+	return nil, false, nil
+}
+
+func (r *revocationAuthenticator) revokedSerials(ctx context.Context) (map[string]bool, error) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	now := time.Now()
+	if r.revoked != nil && r.exp.After(now) {
+		return r.revoked, nil
+	}
+
+	secret := &corev1.Secret{}
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: r.namespace, Name: r.name}, secret)
+	if err != nil && !kerrors.IsNotFound(err) {
+		// keep denying the known revoked certificates if the secret cannot be retrieved
+		if r.revoked != nil {
+			klog.Errorf("Error retrieving credentials secret %s/%s: %v", r.namespace, r.name, err)
+			return r.revoked, nil
+		}
+
+		return nil, fmt.Errorf("get credentials secret: %w", err)
+	}
+
+	revoked, err := credentials.RevokedSerials(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	r.revoked = revoked
+	r.exp = now.Add(time.Second * 5)
+	return revoked, nil
+}
@@ -0,0 +1,55 @@
+package revocation
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/loft-sh/vcluster/pkg/credentials"
+	"github.com/loft-sh/vcluster/pkg/scheme"
+	testingutil "github.com/loft-sh/vcluster/pkg/util/testing"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRevocationAuthenticator(t *testing.T) {
+	revoked, err := json.Marshal(credentials.Credential{Serial: "1", Subject: "alice", NotAfter: time.Now().Add(time.Hour), Revoked: &metav1.Time{Time: time.Now()}})
+	assert.NilError(t, err)
+	valid, err := json.Marshal(credentials.Credential{Serial: "2", Subject: "bob", NotAfter: time.Now().Add(time.Hour)})
+	assert.NilError(t, err)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      credentials.SecretName("vcluster"),
+			Namespace: "test",
+		},
+		Data: map[string][]byte{
+			"1": revoked,
+			"2": valid,
+		},
+	}
+
+	auth := New(testingutil.NewFakeClient(scheme.Scheme, secret), "vcluster", "test")
+	newRequest := func(serial int64) *http.Request {
+		req, err := http.NewRequest(http.MethodGet, "https://localhost:8443/api", nil)
+		assert.NilError(t, err)
+		if serial > 0 {
+			req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{{SerialNumber: big.NewInt(serial)}}}
+		}
+		return req
+	}
+
+	_, ok, err := auth.AuthenticateRequest(newRequest(1))
+	assert.ErrorContains(t, err, "client certificate 1 has been revoked")
+	assert.Assert(t, !ok)
+	_, ok, err = auth.AuthenticateRequest(newRequest(2))
+	assert.NilError(t, err)
+	assert.Assert(t, !ok)
+	_, ok, err = auth.AuthenticateRequest(newRequest(0))
+	assert.NilError(t, err)
+	assert.Assert(t, !ok)
+}
@@ -0,0 +1,176 @@
+package certs
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	vclusterconfig "github.com/loft-sh/vcluster/config"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var certManagerCertificateGVK = schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}
+
+// CertManagerCertificateName returns the name of the cert-manager Certificate for the virtual api server
+func CertManagerCertificateName(vClusterName string) string {
+	return vClusterName + "-apiserver"
+}
+
+// CertManagerSecretName returns the name of the secret cert-manager stores the serving certificate in
+func CertManagerSecretName(vClusterName string) string {
+	return vClusterName + "-apiserver-tls"
+}
+
+// EnsureCertManagerCertificate creates or updates the cert-manager Certificate that requests the serving certificate
+// of the virtual api server for the given SANs.
+func EnsureCertManagerCertificate(ctx context.Context, c client.Client, vClusterName, namespace string, certManager vclusterconfig.ControlPlaneCertificatesCertManager, sans []string) error {
+	dnsNames, ipAddresses := []interface{}{}, []interface{}{}
+	for _, san := range sans {
+		if net.ParseIP(san) != nil {
+			ipAddresses = append(ipAddresses, san)
+		} else {
+			dnsNames = append(dnsNames, san)
+		}
+	}
+
+	issuerRef := map[string]interface{}{
+		"name": certManager.IssuerRef.Name,
+	}
+	if certManager.IssuerRef.Kind != "" {
+		issuerRef["kind"] = certManager.IssuerRef.Kind
+	}
+	if certManager.IssuerRef.Group != "" {
+		issuerRef["group"] = certManager.IssuerRef.Group
+	}
+	spec := map[string]interface{}{
+		"secretName":  CertManagerSecretName(vClusterName),
+		"commonName":  APIServerCertCommonName,
+		"dnsNames":    dnsNames,
+		"ipAddresses": ipAddresses,
+		"usages":      []interface{}{"server auth", "digital signature", "key encipherment"},
+		"issuerRef":   issuerRef,
+	}
+
+	certificate := &unstructured.Unstructured{}
+	certificate.SetGroupVersionKind(certManagerCertificateGVK)
+	err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: CertManagerCertificateName(vClusterName)}, certificate)
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			return fmt.Errorf("get certificate: %w", err)
+		}
+
+		certificate.SetName(CertManagerCertificateName(vClusterName))
+		certificate.SetNamespace(namespace)
+		certificate.Object["spec"] = spec
+		klog.Infof("Create cert-manager certificate %s/%s", namespace, certificate.GetName())
+		err = c.Create(ctx, certificate)
+		if err != nil {
+			return fmt.Errorf("create certificate: %w", err)
+		}
+
+		return nil
+	} else if equality.Semantic.DeepEqual(certificate.Object["spec"], spec) {
+		return nil
+	}
+
+	certificate.Object["spec"] = spec
+	klog.Infof("Update cert-manager certificate %s/%s", namespace, certificate.GetName())
+	err = c.Update(ctx, certificate)
+	if err != nil {
+		return fmt.Errorf("update certificate: %w", err)
+	}
+
+	return nil
+}
+
+// GetCertManagerCertificate returns the serving certificate, key and issuing CA issued by cert-manager. If the
+// certificate was not issued yet, nil is returned.
+func GetCertManagerCertificate(ctx context.Context, c client.Client, vClusterName, namespace string) ([]byte, []byte, []byte, error) {
+	secret := &corev1.Secret{}
+	err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: CertManagerSecretName(vClusterName)}, secret)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil, nil, nil
+		}
+
+		return nil, nil, nil, fmt.Errorf("get certificate secret: %w", err)
+	}
+
+	cert, key := secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey]
+	if len(cert) == 0 || len(key) == 0 {
+		return nil, nil, nil, nil
+	}
+
+	_, err = tls.X509KeyPair(cert, key)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("parse certificate secret %s/%s: %w", namespace, secret.Name, err)
+	}
+
+	return cert, key, secret.Data["ca.crt"], nil
+}
+
+// EnsureCertManagerRootCA requests the serving certificate from cert-manager and writes a CA bundle of the cluster CA
+// and the issuing CA into the certificate dir, which is trusted by pods within the virtual cluster.
+func EnsureCertManagerRootCA(ctx context.Context, c client.Client, vClusterName, namespace, certificateDir string, certManager vclusterconfig.ControlPlaneCertificatesCertManager, sans []string) error {
+	err := EnsureCertManagerCertificate(ctx, c, vClusterName, namespace, certManager, sans)
+	if err != nil {
+		return err
+	}
+
+	var issuerCA []byte
+	klog.Infof("Waiting for cert-manager to issue certificate %s/%s", namespace, CertManagerCertificateName(vClusterName))
+	err = wait.PollUntilContextTimeout(ctx, time.Second*2, time.Minute*5, true, func(ctx context.Context) (bool, error) {
+		cert, _, ca, err := GetCertManagerCertificate(ctx, c, vClusterName, namespace)
+		if err != nil {
+			return false, err
+		} else if cert == nil {
+			return false, nil
+		}
+
+		issuerCA = ca
+		return true, nil
+	})
+	if err != nil {
+		return fmt.Errorf("wait for certificate %s/%s: %w", namespace, CertManagerCertificateName(vClusterName), err)
+	} else if len(issuerCA) == 0 {
+		return fmt.Errorf("certificate secret %s/%s is missing ca.crt, please use an issuer that exposes its CA", namespace, CertManagerSecretName(vClusterName))
+	}
+
+	_, err = WriteRootCA(certificateDir, issuerCA)
+	return err
+}
+
+// WriteRootCA writes a CA bundle of the cluster CA and the given issuing CA into the certificate dir and returns
+// if the bundle has changed.
+func WriteRootCA(certificateDir string, issuerCA []byte) (bool, error) {
+	clusterCA, err := os.ReadFile(filepath.Join(certificateDir, CACertName))
+	if err != nil {
+		return false, fmt.Errorf("read %s: %w", CACertName, err)
+	}
+
+	rootCA := append(append(clusterCA, '\n'), issuerCA...)
+	current, err := os.ReadFile(filepath.Join(certificateDir, RootCACertName))
+	if err == nil && bytes.Equal(current, rootCA) {
+		return false, nil
+	}
+
+	err = os.WriteFile(filepath.Join(certificateDir, RootCACertName), rootCA, 0644)
+	if err != nil {
+		return false, fmt.Errorf("write %s: %w", RootCACertName, err)
+	}
+
+	return true, nil
+}
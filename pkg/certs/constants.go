@@ -28,6 +28,19 @@ const (
 	CACertName = "ca.crt"
 	// CAKeyName defines certificate name
 	CAKeyName = "ca.key"
+	// NextCAKeyName defines the key of the next cluster CA, which is already trusted but only signs certificates once
+	// the CA trust period of a CA rotation has passed
+	NextCAKeyName = "ca-next.key"
+
+	// ClientCACertAndKeyBaseName defines the base name of the internal client CA that is used together with an external CA
+	ClientCACertAndKeyBaseName = "client-ca"
+	// ClientCACertName defines client CA certificate name
+	ClientCACertName = "client-ca.crt"
+	// ClientCAKeyName defines client CA key name
+	ClientCAKeyName = "client-ca.key"
+
+	// RootCACertName defines the name of the CA bundle that is trusted by pods within the virtual cluster
+	RootCACertName = "root-ca.crt"
 
 	// APIServerCertAndKeyBaseName defines API's server certificate and key base name
 	APIServerCertAndKeyBaseName = "apiserver"
@@ -0,0 +1,327 @@
+package certs
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	vclusterconfig "github.com/loft-sh/vcluster/config"
+	"github.com/loft-sh/vcluster/pkg/config"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiserverv1 "k8s.io/apiserver/pkg/apis/apiserver/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// EncryptionConfigName is the name of the encryption configuration within the certs secret and the certificate directory
+	EncryptionConfigName = "encryption-config.yaml"
+
+	// EncryptionMigratedAnnotation holds the hash of the encryption configuration all resources were rewritten with
+	EncryptionMigratedAnnotation = "vcluster.loft.sh/encryption-migrated"
+
+	encryptionKeyPrefix = "key-"
+)
+
+// EnsureEncryptionConfig generates the encryption configuration for the virtual api server, stores it within the certs secret
+// and writes it into the certificate directory. Keys are rotated after the configured key rotation period and keys of previously
+// used providers are kept until all resources were rewritten with the current provider.
+func EnsureEncryptionConfig(
+	ctx context.Context,
+	currentNamespace string,
+	currentNamespaceClient kubernetes.Interface,
+	vClusterName string,
+	certificateDir string,
+	options *config.VirtualClusterConfig,
+) error {
+	if currentNamespaceClient == nil {
+		return errors.New("nil currentNamespaceClient")
+	}
+
+	var encryptionConfig []byte
+	secretName := vClusterName + "-certs"
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		secret, err := currentNamespaceClient.CoreV1().Secrets(currentNamespace).Get(ctx, secretName, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("get certs secret: %w", err)
+		}
+
+		existing, err := ParseEncryptionConfig(secret.Data[EncryptionConfigName])
+		if err != nil {
+			return err
+		}
+
+		encryption := options.ControlPlane.Encryption
+		if !encryption.Enabled {
+			if existing != nil {
+				return fmt.Errorf("seems like you were using encryption at rest before and now have disabled it, please make sure to not disable encryption as existing resources cannot be read anymore")
+			}
+
+			return nil
+		}
+
+		migrated := existing != nil && secret.Annotations[EncryptionMigratedAnnotation] == EncryptionConfigHash(existing)
+		newConfig, err := buildEncryptionConfig(existing, migrated, encryption, time.Now())
+		if err != nil {
+			return err
+		}
+
+		encryptionConfig, err = yaml.Marshal(newConfig)
+		if err != nil {
+			return fmt.Errorf("marshal encryption config: %w", err)
+		} else if bytes.Equal(secret.Data[EncryptionConfigName], encryptionConfig) {
+			return nil
+		}
+
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[EncryptionConfigName] = encryptionConfig
+		_, err = currentNamespaceClient.CoreV1().Secrets(currentNamespace).Update(ctx, secret, metav1.UpdateOptions{})
+		if err != nil {
+			return err
+		}
+
+		klog.Infof("Successfully updated encryption config in certs secret %s/%s", currentNamespace, secretName)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("ensure encryption config: %w", err)
+	} else if encryptionConfig == nil {
+		return nil
+	}
+
+	err = os.MkdirAll(certificateDir, 0777)
+	if err != nil {
+		return fmt.Errorf("create directory %s: %w", certificateDir, err)
+	}
+
+	return os.WriteFile(filepath.Join(certificateDir, EncryptionConfigName), encryptionConfig, 0600)
+}
+
+// ParseEncryptionConfig parses the encryption configuration stored in the certs secret, it returns nil if there is none.
+func ParseEncryptionConfig(data []byte) (*apiserverv1.EncryptionConfiguration, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	encryptionConfig := &apiserverv1.EncryptionConfiguration{}
+	err := yaml.Unmarshal(data, encryptionConfig)
+	if err != nil {
+		return nil, fmt.Errorf("parse encryption config: %w", err)
+	}
+
+	return encryptionConfig, nil
+}
+
+// EncryptionConfigHash returns a hash over the resources and the provider that is used to write them. If the hash changes,
+// the resources need to be rewritten.
+func EncryptionConfigHash(encryptionConfig *apiserverv1.EncryptionConfiguration) string {
+	hashed := []apiserverv1.ResourceConfiguration{}
+	for _, resourceConfig := range encryptionConfig.Resources {
+		if len(resourceConfig.Providers) == 0 {
+			continue
+		}
+
+		// only the primary key is used for writing
+		writeProvider := *resourceConfig.Providers[0].DeepCopy()
+		if writeProvider.AESCBC != nil && len(writeProvider.AESCBC.Keys) > 1 {
+			writeProvider.AESCBC.Keys = writeProvider.AESCBC.Keys[:1]
+		}
+		if writeProvider.Secretbox != nil && len(writeProvider.Secretbox.Keys) > 1 {
+			writeProvider.Secretbox.Keys = writeProvider.Secretbox.Keys[:1]
+		}
+
+		hashed = append(hashed, apiserverv1.ResourceConfiguration{
+			Resources: resourceConfig.Resources,
+			Providers: []apiserverv1.ProviderConfiguration{writeProvider},
+		})
+	}
+
+	out, _ := json.Marshal(hashed)
+	hash := sha256.Sum256(out)
+	return hex.EncodeToString(hash[:])
+}
+
+func buildEncryptionConfig(existing *apiserverv1.EncryptionConfiguration, migrated bool, encryption vclusterconfig.ControlPlaneEncryption, now time.Time) (*apiserverv1.EncryptionConfiguration, error) {
+	resources := encryption.Resources
+	if len(resources) == 0 {
+		resources = []string{"secrets"}
+	}
+
+	// if all resources were rewritten, only the previous write provider is still needed to read resources
+	// that were written in between, otherwise we keep all previous providers
+	previousProviders := []apiserverv1.ProviderConfiguration{}
+	removedResources := []string{}
+	if existing != nil {
+		for idx, resourceConfig := range existing.Resources {
+			if idx == 0 {
+				previousProviders = resourceConfig.Providers
+				if migrated && len(previousProviders) > 1 {
+					previousProviders = previousProviders[:1]
+				}
+			} else if migrated {
+				// resources that are not encrypted anymore were already rewritten in plain text
+				break
+			}
+
+			for _, resource := range resourceConfig.Resources {
+				if !slices.Contains(resources, resource) && !slices.Contains(removedResources, resource) {
+					removedResources = append(removedResources, resource)
+				}
+			}
+		}
+	}
+
+	writeProvider, err := encryptionWriteProvider(previousProviders, migrated, encryption, now)
+	if err != nil {
+		return nil, err
+	}
+
+	providers := []apiserverv1.ProviderConfiguration{writeProvider}
+	for _, provider := range previousProviders {
+		if provider.Identity != nil || replacesProvider(writeProvider, provider) {
+			continue
+		}
+
+		providers = append(providers, provider)
+	}
+
+	resourceConfigs := []apiserverv1.ResourceConfiguration{
+		{
+			Resources: resources,
+			Providers: append(providers, apiserverv1.ProviderConfiguration{Identity: &apiserverv1.IdentityConfiguration{}}),
+		},
+	}
+	if len(removedResources) > 0 {
+		// resources that should not be encrypted anymore are written in plain text, but still need to be readable
+		resourceConfigs = append(resourceConfigs, apiserverv1.ResourceConfiguration{
+			Resources: removedResources,
+			Providers: append([]apiserverv1.ProviderConfiguration{{Identity: &apiserverv1.IdentityConfiguration{}}}, providers...),
+		})
+	}
+
+	return &apiserverv1.EncryptionConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: apiserverv1.SchemeGroupVersion.String(),
+			Kind:       "EncryptionConfiguration",
+		},
+		Resources: resourceConfigs,
+	}, nil
+}
+
+func encryptionWriteProvider(previousProviders []apiserverv1.ProviderConfiguration, migrated bool, encryption vclusterconfig.ControlPlaneEncryption, now time.Time) (apiserverv1.ProviderConfiguration, error) {
+	if encryption.Provider == vclusterconfig.EncryptionProviderKMS {
+		kms := &apiserverv1.KMSConfiguration{
+			APIVersion: "v2",
+			Name:       encryption.KMS.Name,
+			Endpoint:   encryption.KMS.Endpoint,
+		}
+		if encryption.KMS.Timeout != "" {
+			timeout, err := time.ParseDuration(encryption.KMS.Timeout)
+			if err != nil {
+				return apiserverv1.ProviderConfiguration{}, fmt.Errorf("parse kms timeout: %w", err)
+			}
+
+			kms.Timeout = &metav1.Duration{Duration: timeout}
+		}
+
+		return apiserverv1.ProviderConfiguration{KMS: kms}, nil
+	}
+
+	// reuse the keys of a previous provider of the same type
+	keys := []apiserverv1.Key{}
+	for _, provider := range previousProviders {
+		if encryption.Provider == vclusterconfig.EncryptionProviderSecretbox && provider.Secretbox != nil {
+			keys = slices.Clone(provider.Secretbox.Keys)
+			break
+		} else if encryption.Provider != vclusterconfig.EncryptionProviderSecretbox && provider.AESCBC != nil {
+			keys = slices.Clone(provider.AESCBC.Keys)
+			break
+		}
+	}
+	if migrated && len(keys) > 1 {
+		// all resources were rewritten with the primary key, so older keys can be dropped
+		keys = keys[:1]
+	}
+
+	rotate, err := shouldRotateEncryptionKey(keys, encryption.KeyRotationPeriod, now)
+	if err != nil {
+		return apiserverv1.ProviderConfiguration{}, err
+	} else if rotate {
+		key, err := newEncryptionKey(now)
+		if err != nil {
+			return apiserverv1.ProviderConfiguration{}, err
+		}
+
+		keys = append([]apiserverv1.Key{key}, keys...)
+	}
+
+	if encryption.Provider == vclusterconfig.EncryptionProviderSecretbox {
+		return apiserverv1.ProviderConfiguration{Secretbox: &apiserverv1.SecretboxConfiguration{Keys: keys}}, nil
+	}
+
+	return apiserverv1.ProviderConfiguration{AESCBC: &apiserverv1.AESConfiguration{Keys: keys}}, nil
+}
+
+// replacesProvider checks if the previous provider is superseded by the write provider, which already holds its keys
+func replacesProvider(writeProvider, provider apiserverv1.ProviderConfiguration) bool {
+	switch {
+	case writeProvider.AESCBC != nil:
+		return provider.AESCBC != nil
+	case writeProvider.Secretbox != nil:
+		return provider.Secretbox != nil
+	case writeProvider.KMS != nil:
+		return provider.KMS != nil && provider.KMS.Name == writeProvider.KMS.Name
+	}
+
+	return false
+}
+
+func shouldRotateEncryptionKey(keys []apiserverv1.Key, keyRotationPeriod string, now time.Time) (bool, error) {
+	if len(keys) == 0 {
+		return true, nil
+	} else if keyRotationPeriod == "" {
+		return false, nil
+	}
+
+	period, err := time.ParseDuration(keyRotationPeriod)
+	if err != nil {
+		return false, fmt.Errorf("parse key rotation period: %w", err)
+	}
+
+	created, err := strconv.ParseInt(strings.TrimPrefix(keys[0].Name, encryptionKeyPrefix), 10, 64)
+	if err != nil {
+		// we don't know when keys that were not generated by vCluster were created
+		return false, nil
+	}
+
+	return now.Sub(time.Unix(created, 0)) >= period, nil
+}
+
+func newEncryptionKey(now time.Time) (apiserverv1.Key, error) {
+	secret := make([]byte, 32)
+	_, err := rand.Read(secret)
+	if err != nil {
+		return apiserverv1.Key{}, fmt.Errorf("generate encryption key: %w", err)
+	}
+
+	return apiserverv1.Key{
+		Name:   encryptionKeyPrefix + strconv.FormatInt(now.Unix(), 10),
+		Secret: base64.StdEncoding.EncodeToString(secret),
+	}, nil
+}
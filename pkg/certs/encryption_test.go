@@ -0,0 +1,70 @@
+package certs
+
+import (
+	"testing"
+	"time"
+
+	vclusterconfig "github.com/loft-sh/vcluster/config"
+	"gotest.tools/v3/assert"
+	apiserverv1 "k8s.io/apiserver/pkg/apis/apiserver/v1"
+)
+
+func TestBuildEncryptionConfig(t *testing.T) {
+	now := time.Unix(1000000, 0)
+	encryption := vclusterconfig.ControlPlaneEncryption{
+		Enabled:           true,
+		Provider:          vclusterconfig.EncryptionProviderAESCBC,
+		KeyRotationPeriod: "24h",
+	}
+
+	// initial config
+	initial, err := buildEncryptionConfig(nil, false, encryption, now)
+	assert.NilError(t, err)
+	assert.Equal(t, len(initial.Resources), 1)
+	assert.DeepEqual(t, initial.Resources[0].Resources, []string{"secrets"})
+	assert.Equal(t, len(initial.Resources[0].Providers), 2)
+	assert.Equal(t, len(initial.Resources[0].Providers[0].AESCBC.Keys), 1)
+	assert.Equal(t, initial.Resources[0].Providers[0].AESCBC.Keys[0].Name, "key-1000000")
+	assert.Assert(t, initial.Resources[0].Providers[1].Identity != nil)
+
+	// nothing changes before the rotation period has passed
+	unchanged, err := buildEncryptionConfig(initial, true, encryption, now.Add(time.Hour))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, unchanged, initial)
+	assert.Equal(t, EncryptionConfigHash(unchanged), EncryptionConfigHash(initial))
+
+	// a new key is prepended after the rotation period
+	rotated, err := buildEncryptionConfig(initial, true, encryption, now.Add(25*time.Hour))
+	assert.NilError(t, err)
+	keys := rotated.Resources[0].Providers[0].AESCBC.Keys
+	assert.Equal(t, len(keys), 2)
+	assert.Equal(t, keys[1], initial.Resources[0].Providers[0].AESCBC.Keys[0])
+	assert.Assert(t, EncryptionConfigHash(rotated) != EncryptionConfigHash(initial))
+
+	// old keys are kept until all resources were rewritten
+	notMigrated, err := buildEncryptionConfig(rotated, false, encryption, now.Add(50*time.Hour))
+	assert.NilError(t, err)
+	assert.Equal(t, len(notMigrated.Resources[0].Providers[0].AESCBC.Keys), 3)
+	migrated, err := buildEncryptionConfig(rotated, true, encryption, now.Add(26*time.Hour))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, migrated.Resources[0].Providers[0].AESCBC.Keys, keys[:1])
+
+	// switching the provider keeps the previous provider to read existing data
+	encryption.Provider = vclusterconfig.EncryptionProviderKMS
+	encryption.KMS = vclusterconfig.ControlPlaneEncryptionKMS{Name: "vault", Endpoint: "unix:///kms/socket.sock", Timeout: "3s"}
+	encryption.Resources = []string{"configmaps"}
+	switched, err := buildEncryptionConfig(migrated, true, encryption, now.Add(27*time.Hour))
+	assert.NilError(t, err)
+	assert.Equal(t, len(switched.Resources), 2)
+	providers := switched.Resources[0].Providers
+	assert.DeepEqual(t, switched.Resources[0].Resources, []string{"configmaps"})
+	assert.Equal(t, len(providers), 3)
+	assert.Equal(t, providers[0].KMS.APIVersion, "v2")
+	assert.Equal(t, providers[0].KMS.Timeout.Duration, 3*time.Second)
+	assert.DeepEqual(t, providers[1], migrated.Resources[0].Providers[0])
+	assert.Assert(t, providers[2].Identity != nil)
+
+	// resources that are not encrypted anymore are written in plain text
+	assert.DeepEqual(t, switched.Resources[1].Resources, []string{"secrets"})
+	assert.DeepEqual(t, switched.Resources[1].Providers, append([]apiserverv1.ProviderConfiguration{{Identity: &apiserverv1.IdentityConfiguration{}}}, providers[:2]...))
+}
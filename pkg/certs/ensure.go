@@ -36,12 +36,34 @@ func EnsureCerts(
 		return errors.New("nil currentNamespaceClient")
 	}
 
+	var err error
 	// we create a certificate for up to 20 etcd replicas, this should be sufficient for most use cases. Eventually we probably
 	// want to update this to the actual etcd number, but for now this is the easiest way to allow up and downscaling without
 	// regenerating certificates.
+	var externalCACert, externalCAKey []byte
+	if options.ControlPlane.Certificates.CA.SecretName != "" {
+		externalCACert, externalCAKey, err = LoadExternalCA(ctx, currentNamespaceClient, currentNamespace, options.ControlPlane.Certificates.CA.SecretName)
+		if err != nil {
+			return err
+		}
+	}
+
 	secretName := vClusterName + "-certs"
 	secret, err := currentNamespaceClient.CoreV1().Secrets(currentNamespace).Get(ctx, secretName, metav1.GetOptions{})
 	if err == nil {
+		// replace the cluster ca if the external ca has changed
+		if externalCACert != nil {
+			changed, err := ensureExternalCA(secret, externalCACert, externalCAKey)
+			if err != nil {
+				return err
+			} else if changed {
+				secret, err = currentNamespaceClient.CoreV1().Secrets(currentNamespace).Update(ctx, secret, metav1.UpdateOptions{})
+				if err != nil {
+					return fmt.Errorf("update certs secret: %w", err)
+				}
+			}
+		}
+
 		// download certs from secret
 		err = downloadCertsFromSecret(secret, certificateDir)
 		if err != nil {
@@ -98,6 +120,14 @@ func EnsureCerts(
 	// we check if the files are already there
 	_, err = os.Stat(filepath.Join(certificateDir, CAKeyName))
 	if errors.Is(err, fs.ErrNotExist) {
+		// write the external ca, so that it signs the generated certificates
+		if externalCACert != nil {
+			err = writeExternalCA(certificateDir, externalCACert, externalCAKey)
+			if err != nil {
+				return err
+			}
+		}
+
 		// try to generate the certificates
 		err = generateCertificates(serviceCIDR, vClusterName, certificateDir, options.Networking.Advanced.ClusterDomain, etcdSans)
 		if err != nil {
@@ -224,6 +254,16 @@ func generateCertificates(
 // downloadCertsFromSecret writes to the filesystem the content of each field in the secret
 // if the field has an equivalent inside the certmap, we write with the corresponding name
 // otherwise the file has the same name than the field
+// DownloadCerts writes the certificates stored in the certs secret of the virtual cluster into the certificate dir
+func DownloadCerts(ctx context.Context, currentNamespaceClient kubernetes.Interface, currentNamespace, vClusterName, certificateDir string) error {
+	secret, err := currentNamespaceClient.CoreV1().Secrets(currentNamespace).Get(ctx, vClusterName+"-certs", metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("get certs secret: %w", err)
+	}
+
+	return downloadCertsFromSecret(secret, certificateDir)
+}
+
 func downloadCertsFromSecret(
 	secret *corev1.Secret,
 	certificateDir string,
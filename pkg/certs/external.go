@@ -0,0 +1,123 @@
+package certs
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/klog/v2"
+)
+
+// LoadExternalCA retrieves the external CA certificate and key from the given secret and validates them
+func LoadExternalCA(ctx context.Context, client kubernetes.Interface, namespace, secretName string) ([]byte, []byte, error) {
+	secret, err := client.CoreV1().Secrets(namespace).Get(ctx, secretName, metav1.GetOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("get external ca secret %s/%s: %w", namespace, secretName, err)
+	}
+
+	caCert, caKey := secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey]
+	if len(caCert) == 0 || len(caKey) == 0 {
+		return nil, nil, fmt.Errorf("external ca secret %s/%s is missing %s or %s", namespace, secretName, corev1.TLSCertKey, corev1.TLSPrivateKeyKey)
+	}
+
+	_, err = tls.X509KeyPair(caCert, caKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse external ca of secret %s/%s: %w", namespace, secretName, err)
+	}
+
+	certs, err := certutil.ParseCertsPEM(caCert)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse external ca of secret %s/%s: %w", namespace, secretName, err)
+	}
+
+	err = validateExternalCA(certs[0])
+	if err != nil {
+		return nil, nil, fmt.Errorf("certificate in secret %s/%s %w", namespace, secretName, err)
+	}
+
+	return caCert, caKey, nil
+}
+
+// validateExternalCA makes sure the external CA is an intermediate CA that is restricted via name constraints, as
+// its key is stored within the certs secret of the vCluster
+func validateExternalCA(cert *x509.Certificate) error {
+	if !cert.IsCA {
+		return fmt.Errorf("is not a CA")
+	} else if bytes.Equal(cert.RawSubject, cert.RawIssuer) && cert.CheckSignatureFrom(cert) == nil {
+		return fmt.Errorf("is a self-signed root CA, please use an intermediate CA that is dedicated to this vCluster instead")
+	} else if len(cert.PermittedDNSDomains) == 0 && len(cert.PermittedIPRanges) == 0 {
+		return fmt.Errorf("has no name constraints, please restrict the intermediate CA to the names of the vCluster via permitted DNS domains or IP ranges")
+	}
+
+	return nil
+}
+
+// writeExternalCA writes the external CA into the certificate dir, so that it is used to sign the control plane
+// certificates, and generates the internal client CA that signs the client certificates of the control plane components.
+func writeExternalCA(certificateDir string, caCert, caKey []byte) error {
+	err := os.MkdirAll(certificateDir, 0777)
+	if err != nil {
+		return fmt.Errorf("create directory %s: %w", certificateDir, err)
+	}
+
+	err = os.WriteFile(filepath.Join(certificateDir, CACertName), caCert, 0644)
+	if err != nil {
+		return fmt.Errorf("write %s: %w", CACertName, err)
+	}
+	err = os.WriteFile(filepath.Join(certificateDir, CAKeyName), caKey, 0600)
+	if err != nil {
+		return fmt.Errorf("write %s: %w", CAKeyName, err)
+	}
+
+	if CertOrKeyExist(certificateDir, ClientCACertAndKeyBaseName) {
+		return nil
+	}
+
+	klog.Infof("Generating %q certificate and key", ClientCACertAndKeyBaseName)
+	clientCACert, clientCAKey, err := NewCertificateAuthority(&CertConfig{
+		Config: certutil.Config{
+			CommonName: "kubernetes-client-ca",
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("generate client ca: %w", err)
+	}
+
+	return WriteCertAndKey(certificateDir, ClientCACertAndKeyBaseName, clientCACert, clientCAKey)
+}
+
+// ensureExternalCA replaces the cluster CA within the certs secret if the external CA has changed and re-issues
+// all certificates that were signed by the previous one. It returns true if the secret was changed.
+func ensureExternalCA(secret *corev1.Secret, caCert, caKey []byte) (bool, error) {
+	if len(secret.Data[ClientCACertName]) == 0 {
+		return false, fmt.Errorf("vCluster was created with a self-generated CA, an external CA can only be configured when the vCluster is created")
+	}
+
+	current, err := certutil.ParseCertsPEM(secret.Data[CACertName])
+	if err != nil {
+		return false, fmt.Errorf("parse certificate %s: %w", CACertName, err)
+	}
+	external, err := certutil.ParseCertsPEM(caCert)
+	if err != nil {
+		return false, fmt.Errorf("parse external ca: %w", err)
+	}
+	if current[0].Equal(external[0]) {
+		return false, nil
+	}
+
+	klog.Info("External CA has changed, re-issuing certificates")
+	_, err = RenewCertsSecret(secret, RenewOptions{RotateCA: true, CACert: caCert, CAKey: caKey}, 0)
+	if err != nil {
+		return false, fmt.Errorf("replace external ca: %w", err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,123 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/client-go/util/keyutil"
+)
+
+func TestExternalCA(t *testing.T) {
+	newExternalCA := func() ([]byte, []byte) {
+		cert, key, err := NewCertificateAuthority(&CertConfig{Config: certutil.Config{CommonName: "enterprise-ca"}})
+		assert.NilError(t, err)
+		keyData, err := keyutil.MarshalPrivateKeyToPEM(key)
+		assert.NilError(t, err)
+		return EncodeCertPEM(cert), keyData
+	}
+
+	// certificates are signed by the external ca and client certificates by the internal client ca
+	caCert, caKey := newExternalCA()
+	certificateDir := t.TempDir()
+	assert.NilError(t, writeExternalCA(certificateDir, caCert, caKey))
+	assert.NilError(t, generateCertificates("10.96.0.0/12", "vcluster", certificateDir, "cluster.local", []string{"localhost"}))
+	external, err := certutil.ParseCertsPEM(caCert)
+	assert.NilError(t, err)
+	apiServerCert, err := TryLoadCertFromDisk(certificateDir, APIServerCertAndKeyBaseName)
+	assert.NilError(t, err)
+	assert.NilError(t, apiServerCert.CheckSignatureFrom(external[0]))
+	clientCACert, err := TryLoadCertFromDisk(certificateDir, ClientCACertAndKeyBaseName)
+	assert.NilError(t, err)
+	adminConf, err := clientcmd.LoadFromFile(filepath.Join(certificateDir, AdminKubeConfigFileName))
+	assert.NilError(t, err)
+	for _, cluster := range adminConf.Clusters {
+		assert.DeepEqual(t, cluster.CertificateAuthorityData, caCert)
+	}
+	for _, authInfo := range adminConf.AuthInfos {
+		clientCert, err := certutil.ParseCertsPEM(authInfo.ClientCertificateData)
+		assert.NilError(t, err)
+		assert.NilError(t, clientCert[0].CheckSignatureFrom(clientCACert))
+	}
+
+	// build the certs secret
+	secret := &corev1.Secret{Data: map[string][]byte{}}
+	entries, err := os.ReadDir(certificateDir)
+	assert.NilError(t, err)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(certificateDir, entry.Name()))
+		assert.NilError(t, err)
+		secret.Data[entry.Name()] = data
+	}
+
+	// the external ca cannot be rotated by vCluster
+	_, err = RenewCertsSecret(secret.DeepCopy(), RenewOptions{RotateCA: true}, 0)
+	assert.ErrorContains(t, err, "the cluster CA is managed externally")
+
+	// nothing changes as long as the external ca stays the same
+	changed, err := ensureExternalCA(secret, caCert, caKey)
+	assert.NilError(t, err)
+	assert.Assert(t, !changed)
+
+	// a new external ca re-issues the certificates signed by the previous one
+	newCACert, newCAKey := newExternalCA()
+	changed, err = ensureExternalCA(secret, newCACert, newCAKey)
+	assert.NilError(t, err)
+	assert.Assert(t, changed)
+	assert.DeepEqual(t, secret.Data[CACertName], newCACert)
+	assert.DeepEqual(t, secret.Data[CAKeyName], newCAKey)
+	newExternal, err := certutil.ParseCertsPEM(newCACert)
+	assert.NilError(t, err)
+	renewed, err := certutil.ParseCertsPEM(secret.Data[APIServerCertName])
+	assert.NilError(t, err)
+	assert.NilError(t, renewed[0].CheckSignatureFrom(newExternal[0]))
+	assert.DeepEqual(t, secret.Data[ClientCACertName], EncodeCertPEM(clientCACert))
+	adminConf, err = clientcmd.Load(secret.Data[AdminKubeConfigFileName])
+	assert.NilError(t, err)
+	for _, cluster := range adminConf.Clusters {
+		assert.Assert(t, strings.Contains(string(cluster.CertificateAuthorityData), string(newCACert)))
+	}
+}
+
+func TestValidateExternalCA(t *testing.T) {
+	rootCert, rootKey, err := NewCertificateAuthority(&CertConfig{Config: certutil.Config{CommonName: "enterprise-root-ca"}})
+	assert.NilError(t, err)
+	newIntermediateCA := func(permittedDNSDomains []string) *x509.Certificate {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		assert.NilError(t, err)
+		template := &x509.Certificate{
+			SerialNumber:          big.NewInt(2),
+			Subject:               pkix.Name{CommonName: "vcluster-ca"},
+			NotBefore:             rootCert.NotBefore,
+			NotAfter:              rootCert.NotAfter,
+			KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+			BasicConstraintsValid: true,
+			IsCA:                  true,
+			PermittedDNSDomains:   permittedDNSDomains,
+		}
+		certDER, err := x509.CreateCertificate(rand.Reader, template, rootCert, key.Public(), rootKey)
+		assert.NilError(t, err)
+		cert, err := x509.ParseCertificate(certDER)
+		assert.NilError(t, err)
+		return cert
+	}
+
+	assert.ErrorContains(t, validateExternalCA(rootCert), "self-signed root CA")
+	assert.ErrorContains(t, validateExternalCA(newIntermediateCA(nil)), "no name constraints")
+	assert.NilError(t, validateExternalCA(newIntermediateCA([]string{"vcluster.svc", "vcluster.example.com"})))
+}
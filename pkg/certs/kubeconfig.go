@@ -32,6 +32,7 @@ import (
 
 // clientCertAuth struct holds info required to build a client certificate to provide authentication info in a kubeconfig object
 type clientCertAuth struct {
+	CACert        *x509.Certificate
 	CAKey         crypto.Signer
 	Organizations []string
 }
@@ -125,9 +126,19 @@ func getKubeConfigSpecs(cfg *InitConfiguration) (map[string]*kubeConfigSpec, err
 	if err != nil {
 		return nil, err
 	}
+	// client certificates are signed by the internal client CA if the cluster CA is managed externally
+	clientCACert, clientCAKey := caCert, caKey
+	if CertOrKeyExist(cfg.CertificatesDir, ClientCACertAndKeyBaseName) {
+		clientCACert, clientCAKey, err = TryLoadCertAndKeyFromDisk(cfg.CertificatesDir, ClientCACertAndKeyBaseName)
+		if err != nil {
+			return nil, errors.Wrap(err, "couldn't create a kubeconfig; the client CA files couldn't be loaded")
+		}
+	}
+
 	for _, spec := range configs {
 		spec.CACert = caCert
-		spec.ClientCertAuth.CAKey = caKey
+		spec.ClientCertAuth.CACert = clientCACert
+		spec.ClientCertAuth.CAKey = clientCAKey
 	}
 	return configs, nil
 }
@@ -149,7 +160,12 @@ func buildKubeConfigFromSpec(spec *kubeConfigSpec, clustername string, notAfter
 	// otherwise, create a client certs
 	clientCertConfig := newClientCertConfigFromKubeConfigSpec(spec, notAfter)
 
-	clientCert, clientKey, err := NewCertAndKey(spec.CACert, spec.ClientCertAuth.CAKey, &clientCertConfig)
+	clientCACert := spec.CACert
+	if spec.ClientCertAuth.CACert != nil {
+		clientCACert = spec.ClientCertAuth.CACert
+	}
+
+	clientCert, clientKey, err := NewCertAndKey(clientCACert, spec.ClientCertAuth.CAKey, &clientCertConfig)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failure while creating %s client certificate", spec.ClientName)
 	}
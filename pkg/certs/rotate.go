@@ -0,0 +1,523 @@
+package certs
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/maps"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/client-go/util/keyutil"
+)
+
+const (
+	// CATrustUntilAnnotation holds the time until certificates issued by the previous CA are still trusted after a CA rotation
+	CATrustUntilAnnotation = "vcluster.loft.sh/ca-trust-until"
+)
+
+// CertificateInfo describes a certificate stored within the certs secret
+type CertificateInfo struct {
+	// Name is the name of the entry in the certs secret
+	Name string
+
+	// CommonName is the subject common name of the certificate
+	CommonName string
+
+	// CA defines if the certificate is a certificate authority
+	CA bool
+
+	// Previous defines if this is a previous CA that is only kept for trust
+	Previous bool
+
+	// Next defines if this is the next CA that is already trusted but not used for signing yet
+	Next bool
+
+	NotAfter time.Time
+}
+
+// RenewOptions defines which certificates are renewed by RenewCertificates
+type RenewOptions struct {
+	// RenewBefore renews only certificates that expire within the given duration. If zero, all certificates are renewed.
+	RenewBefore time.Duration
+
+	// RotateCA generates a new cluster CA that signs all renewed certificates. The previous CA is still trusted
+	// until it is removed via PrunePreviousCA.
+	RotateCA bool
+
+	// CACert and CAKey are used as the new cluster CA on RotateCA instead of generating one, e.g. if the CA is managed externally.
+	CACert []byte
+	CAKey  []byte
+}
+
+type certificateAuthority struct {
+	certs []*x509.Certificate
+	key   crypto.Signer
+}
+
+// ListCertificates returns all certificates found in the certs secret data sorted by name.
+func ListCertificates(data map[string][]byte) ([]CertificateInfo, error) {
+	nextCA := len(data[NextCAKeyName]) > 0
+	infos := []CertificateInfo{}
+	names := maps.Keys(data)
+	slices.Sort(names)
+	for _, name := range names {
+		switch {
+		case strings.HasSuffix(name, ".crt"):
+			certs, err := certutil.ParseCertsPEM(data[name])
+			if err != nil {
+				return nil, fmt.Errorf("parse certificate %s: %w", name, err)
+			}
+
+			for idx, cert := range certs {
+				infos = append(infos, CertificateInfo{
+					Name:       name,
+					CommonName: cert.Subject.CommonName,
+					CA:         cert.IsCA,
+					Previous:   idx > 0 && !(nextCA && name == CACertName),
+					Next:       idx > 0 && nextCA && name == CACertName,
+					NotAfter:   cert.NotAfter,
+				})
+			}
+		case strings.HasSuffix(name, ".conf"):
+			kubeConfig, err := clientcmd.Load(data[name])
+			if err != nil {
+				return nil, fmt.Errorf("parse kube config %s: %w", name, err)
+			}
+
+			for _, authInfo := range kubeConfig.AuthInfos {
+				if authInfo == nil || len(authInfo.ClientCertificateData) == 0 {
+					continue
+				}
+
+				certs, err := certutil.ParseCertsPEM(authInfo.ClientCertificateData)
+				if err != nil {
+					return nil, fmt.Errorf("parse client certificate in %s: %w", name, err)
+				}
+
+				infos = append(infos, CertificateInfo{
+					Name:       name,
+					CommonName: certs[0].Subject.CommonName,
+					NotAfter:   certs[0].NotAfter,
+				})
+			}
+		}
+	}
+
+	return infos, nil
+}
+
+// RenewCertificates re-issues the leaf certificates and kube config client certificates within the certs secret data with
+// their current subject, SANs and key. It returns the names of the changed entries.
+func RenewCertificates(data map[string][]byte, options RenewOptions) ([]string, error) {
+	now := time.Now()
+	changed := []string{}
+
+	// find all certificate authorities
+	cas := map[string]*certificateAuthority{}
+	for name, certData := range data {
+		keyName := strings.TrimSuffix(name, ".crt") + ".key"
+		if !strings.HasSuffix(name, ".crt") || len(data[keyName]) == 0 {
+			continue
+		}
+
+		certs, err := certutil.ParseCertsPEM(certData)
+		if err != nil {
+			return nil, fmt.Errorf("parse certificate %s: %w", name, err)
+		} else if !certs[0].IsCA {
+			continue
+		}
+
+		key, err := parseSigner(data[keyName])
+		if err != nil {
+			return nil, fmt.Errorf("parse key %s: %w", keyName, err)
+		}
+
+		cas[name] = &certificateAuthority{certs: certs, key: key}
+	}
+
+	// rotate the cluster ca and keep trusting the previous one
+	var previousCA []byte
+	if options.RotateCA {
+		ca, ok := cas[CACertName]
+		if !ok {
+			return nil, fmt.Errorf("couldn't find %s in certs secret", CACertName)
+		}
+
+		cert, key, keyData, err := newClusterCA(ca, options)
+		if err != nil {
+			return nil, err
+		}
+
+		previousCA = EncodeCertPEM(ca.certs[0])
+		cas[CACertName] = &certificateAuthority{certs: []*x509.Certificate{cert, ca.certs[0]}, key: key}
+		data[CACertName] = append(EncodeCertPEM(cert), previousCA...)
+		data[CAKeyName] = keyData
+		changed = append(changed, CACertName, CAKeyName)
+	}
+
+	shouldRenew := func(cert *x509.Certificate, caName string) bool {
+		return options.RenewBefore == 0 || cert.NotAfter.Sub(now) < options.RenewBefore || (options.RotateCA && caName == CACertName)
+	}
+
+	// renew leaf certificates
+	names := maps.Keys(data)
+	slices.Sort(names)
+	for _, name := range names {
+		if !strings.HasSuffix(name, ".crt") {
+			continue
+		}
+
+		keyName := strings.TrimSuffix(name, ".crt") + ".key"
+		certs, err := certutil.ParseCertsPEM(data[name])
+		if err != nil {
+			return nil, fmt.Errorf("parse certificate %s: %w", name, err)
+		} else if certs[0].IsCA || len(data[keyName]) == 0 {
+			continue
+		}
+
+		caName, ca := findCertificateAuthority(cas, certs[0])
+		if ca == nil || !shouldRenew(certs[0], caName) {
+			continue
+		}
+
+		key, err := parseSigner(data[keyName])
+		if err != nil {
+			return nil, fmt.Errorf("parse key %s: %w", keyName, err)
+		}
+
+		certData, err := renewCertificate(certs[0], key, ca)
+		if err != nil {
+			return nil, fmt.Errorf("renew certificate %s: %w", name, err)
+		}
+
+		data[name] = certData
+		changed = append(changed, name)
+	}
+
+	// renew kube config client certificates
+	for _, name := range names {
+		if !strings.HasSuffix(name, ".conf") {
+			continue
+		}
+
+		kubeConfig, err := clientcmd.Load(data[name])
+		if err != nil {
+			return nil, fmt.Errorf("parse kube config %s: %w", name, err)
+		}
+
+		updated := false
+		for _, authInfo := range kubeConfig.AuthInfos {
+			if authInfo == nil || len(authInfo.ClientCertificateData) == 0 || len(authInfo.ClientKeyData) == 0 {
+				continue
+			}
+
+			certs, err := certutil.ParseCertsPEM(authInfo.ClientCertificateData)
+			if err != nil {
+				return nil, fmt.Errorf("parse client certificate in %s: %w", name, err)
+			}
+
+			caName, ca := findCertificateAuthority(cas, certs[0])
+			if ca == nil || !shouldRenew(certs[0], caName) {
+				continue
+			}
+
+			key, err := parseSigner(authInfo.ClientKeyData)
+			if err != nil {
+				return nil, fmt.Errorf("parse client key in %s: %w", name, err)
+			}
+
+			authInfo.ClientCertificateData, err = renewCertificate(certs[0], key, ca)
+			if err != nil {
+				return nil, fmt.Errorf("renew client certificate in %s: %w", name, err)
+			}
+			updated = true
+		}
+		if previousCA != nil {
+			for _, cluster := range kubeConfig.Clusters {
+				if cluster != nil && bytes.Contains(cluster.CertificateAuthorityData, previousCA) {
+					cluster.CertificateAuthorityData = data[CACertName]
+					updated = true
+				}
+			}
+		}
+		if !updated {
+			continue
+		}
+
+		data[name], err = clientcmd.Write(*kubeConfig)
+		if err != nil {
+			return nil, fmt.Errorf("write kube config %s: %w", name, err)
+		}
+		changed = append(changed, name)
+	}
+
+	return changed, nil
+}
+
+// PrunePreviousCA removes the previous cluster CA that was kept after a CA rotation from the certs secret data.
+// It returns the names of the changed entries.
+func PrunePreviousCA(data map[string][]byte) ([]string, error) {
+	certs, err := certutil.ParseCertsPEM(data[CACertName])
+	if err != nil {
+		return nil, fmt.Errorf("parse certificate %s: %w", CACertName, err)
+	} else if len(certs) < 2 {
+		return nil, nil
+	}
+
+	bundle := data[CACertName]
+	data[CACertName] = EncodeCertPEM(certs[0])
+	changed, err := replaceKubeConfigCA(data, bundle, data[CACertName])
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]string{CACertName}, changed...), nil
+}
+
+// stageNextCA generates the next cluster CA and adds it to the trusted CAs. The current CA keeps signing all
+// certificates until the next CA is promoted via promoteNextCA. It returns the names of the changed entries.
+func stageNextCA(data map[string][]byte) ([]string, error) {
+	certs, err := certutil.ParseCertsPEM(data[CACertName])
+	if err != nil {
+		return nil, fmt.Errorf("parse certificate %s: %w", CACertName, err)
+	}
+
+	cert, _, keyData, err := newClusterCA(&certificateAuthority{certs: certs}, RenewOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := data[CACertName]
+	data[CACertName] = append(EncodeCertPEM(certs[0]), EncodeCertPEM(cert)...)
+	data[NextCAKeyName] = keyData
+	changed, err := replaceKubeConfigCA(data, bundle, data[CACertName])
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]string{CACertName, NextCAKeyName}, changed...), nil
+}
+
+// promoteNextCA re-issues all certificates with the next cluster CA and removes the current one. It returns the
+// names of the changed entries.
+func promoteNextCA(data map[string][]byte) ([]string, error) {
+	certs, err := certutil.ParseCertsPEM(data[CACertName])
+	if err != nil {
+		return nil, fmt.Errorf("parse certificate %s: %w", CACertName, err)
+	} else if len(certs) < 2 {
+		return nil, fmt.Errorf("couldn't find the next CA in %s", CACertName)
+	}
+
+	changed, err := RenewCertificates(data, RenewOptions{RotateCA: true, CACert: EncodeCertPEM(certs[1]), CAKey: data[NextCAKeyName]})
+	if err != nil {
+		return nil, err
+	}
+	delete(data, NextCAKeyName)
+
+	pruned, err := PrunePreviousCA(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(append(changed, NextCAKeyName), pruned...), nil
+}
+
+// replaceKubeConfigCA replaces the certificate authority of the kube configs that trust exactly the given CA bundle
+func replaceKubeConfigCA(data map[string][]byte, oldBundle, newBundle []byte) ([]string, error) {
+	changed := []string{}
+	names := maps.Keys(data)
+	slices.Sort(names)
+	for _, name := range names {
+		if !strings.HasSuffix(name, ".conf") {
+			continue
+		}
+
+		kubeConfig, err := clientcmd.Load(data[name])
+		if err != nil {
+			return nil, fmt.Errorf("parse kube config %s: %w", name, err)
+		}
+
+		updated := false
+		for _, cluster := range kubeConfig.Clusters {
+			if cluster != nil && bytes.Equal(cluster.CertificateAuthorityData, oldBundle) {
+				cluster.CertificateAuthorityData = newBundle
+				updated = true
+			}
+		}
+		if !updated {
+			continue
+		}
+
+		data[name], err = clientcmd.Write(*kubeConfig)
+		if err != nil {
+			return nil, fmt.Errorf("write kube config %s: %w", name, err)
+		}
+		changed = append(changed, name)
+	}
+
+	return changed, nil
+}
+
+// RenewCertsSecret renews the certificates within the certs secret. If the CA is rotated with a trust period, the new
+// CA is only trusted at first, while certificates are still signed by the current CA, so that existing kube configs
+// keep working. Once the trust period has passed, all certificates are re-issued by the new CA and the previous one is
+// removed. It returns the names of the changed entries.
+func RenewCertsSecret(secret *corev1.Secret, options RenewOptions, caTrustPeriod time.Duration) ([]string, error) {
+	if options.RotateCA && len(options.CACert) == 0 && len(secret.Data[ClientCACertName]) > 0 {
+		return nil, fmt.Errorf("the cluster CA is managed externally, please update the referenced CA secret instead")
+	} else if options.RotateCA && len(secret.Data[NextCAKeyName]) > 0 {
+		return nil, fmt.Errorf("a CA rotation is already in progress until %s", secret.Annotations[CATrustUntilAnnotation])
+	}
+
+	changed := []string{}
+	if trustUntil, ok := secret.Annotations[CATrustUntilAnnotation]; ok && !options.RotateCA {
+		until, err := time.Parse(time.RFC3339, trustUntil)
+		if err != nil || time.Now().After(until) {
+			var rotated []string
+			if len(secret.Data[NextCAKeyName]) > 0 {
+				rotated, err = promoteNextCA(secret.Data)
+			} else {
+				rotated, err = PrunePreviousCA(secret.Data)
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			delete(secret.Annotations, CATrustUntilAnnotation)
+			changed = append(changed, rotated...)
+		}
+	}
+
+	// stage the new CA and keep signing with the current one during the trust period
+	if options.RotateCA && len(options.CACert) == 0 && caTrustPeriod > 0 {
+		staged, err := stageNextCA(secret.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		if secret.Annotations == nil {
+			secret.Annotations = map[string]string{}
+		}
+		secret.Annotations[CATrustUntilAnnotation] = time.Now().Add(caTrustPeriod).UTC().Format(time.RFC3339)
+		changed = append(changed, staged...)
+		options.RotateCA = false
+	}
+
+	renewed, err := RenewCertificates(secret.Data, options)
+	if err != nil {
+		return nil, err
+	}
+	changed = append(changed, renewed...)
+
+	if options.RotateCA {
+		if caTrustPeriod == 0 {
+			_, err = PrunePreviousCA(secret.Data)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			if secret.Annotations == nil {
+				secret.Annotations = map[string]string{}
+			}
+			secret.Annotations[CATrustUntilAnnotation] = time.Now().Add(caTrustPeriod).UTC().Format(time.RFC3339)
+		}
+	}
+
+	return uniqueNames(changed), nil
+}
+
+func uniqueNames(names []string) []string {
+	unique := []string{}
+	for _, name := range names {
+		if !slices.Contains(unique, name) {
+			unique = append(unique, name)
+		}
+	}
+
+	return unique
+}
+
+func newClusterCA(ca *certificateAuthority, options RenewOptions) (*x509.Certificate, crypto.Signer, []byte, error) {
+	if len(options.CACert) > 0 {
+		certs, err := certutil.ParseCertsPEM(options.CACert)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("parse ca certificate: %w", err)
+		}
+
+		key, err := parseSigner(options.CAKey)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("parse ca key: %w", err)
+		}
+
+		return certs[0], key, options.CAKey, nil
+	}
+
+	cert, key, err := NewCertificateAuthority(&CertConfig{
+		Config: certutil.Config{
+			CommonName:   ca.certs[0].Subject.CommonName,
+			Organization: ca.certs[0].Subject.Organization,
+		},
+		PublicKeyAlgorithm: ca.certs[0].PublicKeyAlgorithm,
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	keyData, err := keyutil.MarshalPrivateKeyToPEM(key)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("marshal ca key: %w", err)
+	}
+
+	return cert, key, keyData, nil
+}
+
+func findCertificateAuthority(cas map[string]*certificateAuthority, cert *x509.Certificate) (string, *certificateAuthority) {
+	for name, ca := range cas {
+		for _, caCert := range ca.certs {
+			if cert.CheckSignatureFrom(caCert) == nil {
+				return name, ca
+			}
+		}
+	}
+
+	return "", nil
+}
+
+func renewCertificate(cert *x509.Certificate, key crypto.Signer, ca *certificateAuthority) ([]byte, error) {
+	newCert, err := NewSignedCert(&CertConfig{
+		Config: certutil.Config{
+			CommonName:   cert.Subject.CommonName,
+			Organization: cert.Subject.Organization,
+			AltNames: certutil.AltNames{
+				DNSNames: cert.DNSNames,
+				IPs:      cert.IPAddresses,
+			},
+			Usages: cert.ExtKeyUsage,
+		},
+	}, key, ca.certs[0], ca.key, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return EncodeCertPEM(newCert), nil
+}
+
+func parseSigner(data []byte) (crypto.Signer, error) {
+	key, err := keyutil.ParsePrivateKeyPEM(data)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("key is not a signer")
+	}
+
+	return signer, nil
+}
@@ -0,0 +1,126 @@
+package certs
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/client-go/util/keyutil"
+)
+
+func TestRenewCertsSecret(t *testing.T) {
+	caCert, caKey, err := NewCertificateAuthority(&CertConfig{Config: certutil.Config{CommonName: "kubernetes"}})
+	assert.NilError(t, err)
+	notAfter := time.Now().Add(time.Hour)
+	serverCert, serverKey, err := NewCertAndKey(caCert, caKey, &CertConfig{
+		Config: certutil.Config{
+			CommonName: APIServerCertCommonName,
+			AltNames:   certutil.AltNames{DNSNames: []string{"kubernetes.default"}},
+			Usages:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		},
+		NotAfter: &notAfter,
+	})
+	assert.NilError(t, err)
+	adminCert, adminKey, err := NewCertAndKey(caCert, caKey, &CertConfig{
+		Config: certutil.Config{
+			CommonName:   "kubernetes-admin",
+			Organization: []string{"system:masters"},
+			Usages:       []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		},
+	})
+	assert.NilError(t, err)
+
+	encodeKey := func(key any) []byte {
+		out, err := keyutil.MarshalPrivateKeyToPEM(key)
+		assert.NilError(t, err)
+		return out
+	}
+	adminConf, err := clientcmd.Write(*CreateWithCerts("https://127.0.0.1:6443", "kubernetes", "kubernetes-admin", EncodeCertPEM(caCert), encodeKey(adminKey), EncodeCertPEM(adminCert)))
+	assert.NilError(t, err)
+	secret := &corev1.Secret{
+		Data: map[string][]byte{
+			CACertName:              EncodeCertPEM(caCert),
+			CAKeyName:               encodeKey(caKey),
+			APIServerCertName:       EncodeCertPEM(serverCert),
+			APIServerKeyName:        encodeKey(serverKey),
+			AdminKubeConfigFileName: adminConf,
+		},
+	}
+
+	// only the expiring server certificate is renewed
+	changed, err := RenewCertsSecret(secret, RenewOptions{RenewBefore: 24 * time.Hour}, 0)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, changed, []string{APIServerCertName})
+	renewed, err := certutil.ParseCertsPEM(secret.Data[APIServerCertName])
+	assert.NilError(t, err)
+	assert.Assert(t, renewed[0].NotAfter.After(notAfter.Add(24*time.Hour)))
+	assert.DeepEqual(t, renewed[0].DNSNames, []string{"kubernetes.default"})
+	assert.NilError(t, renewed[0].CheckSignatureFrom(caCert))
+
+	// rotating the ca trusts the new ca, but keeps serving and signing with the current one
+	changed, err = RenewCertsSecret(secret, RenewOptions{RotateCA: true}, time.Hour)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, changed, []string{CACertName, NextCAKeyName, AdminKubeConfigFileName, APIServerCertName})
+	bundle, err := certutil.ParseCertsPEM(secret.Data[CACertName])
+	assert.NilError(t, err)
+	assert.Equal(t, len(bundle), 2)
+	assert.Assert(t, bundle[0].Equal(caCert))
+	renewed, err = certutil.ParseCertsPEM(secret.Data[APIServerCertName])
+	assert.NilError(t, err)
+	assert.NilError(t, renewed[0].CheckSignatureFrom(caCert))
+	kubeConfig, err := clientcmd.Load(secret.Data[AdminKubeConfigFileName])
+	assert.NilError(t, err)
+	assert.DeepEqual(t, kubeConfig.Clusters["kubernetes"].CertificateAuthorityData, secret.Data[CACertName])
+	_, ok := secret.Annotations[CATrustUntilAnnotation]
+	assert.Assert(t, ok)
+	certificates, err := ListCertificates(secret.Data)
+	assert.NilError(t, err)
+	next := 0
+	for _, certificate := range certificates {
+		if certificate.Next {
+			assert.Equal(t, certificate.Name, CACertName)
+			next++
+		}
+	}
+	assert.Equal(t, next, 1)
+
+	// a second rotation is rejected during the trust period
+	_, err = RenewCertsSecret(secret, RenewOptions{RotateCA: true}, time.Hour)
+	assert.ErrorContains(t, err, "already in progress")
+
+	// after the trust period all certificates are issued by the new ca and the previous one is removed
+	nextCA := bundle[1]
+	secret.Annotations[CATrustUntilAnnotation] = time.Now().Add(-time.Minute).Format(time.RFC3339)
+	changed, err = RenewCertsSecret(secret, RenewOptions{RenewBefore: 24 * time.Hour}, time.Hour)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, changed, []string{CACertName, CAKeyName, APIServerCertName, AdminKubeConfigFileName, NextCAKeyName})
+	bundle, err = certutil.ParseCertsPEM(secret.Data[CACertName])
+	assert.NilError(t, err)
+	assert.Equal(t, len(bundle), 1)
+	assert.Assert(t, bundle[0].Equal(nextCA))
+	renewed, err = certutil.ParseCertsPEM(secret.Data[APIServerCertName])
+	assert.NilError(t, err)
+	assert.NilError(t, renewed[0].CheckSignatureFrom(nextCA))
+	kubeConfig, err = clientcmd.Load(secret.Data[AdminKubeConfigFileName])
+	assert.NilError(t, err)
+	assert.DeepEqual(t, kubeConfig.Clusters["kubernetes"].CertificateAuthorityData, secret.Data[CACertName])
+	_, ok = secret.Data[NextCAKeyName]
+	assert.Assert(t, !ok)
+	_, ok = secret.Annotations[CATrustUntilAnnotation]
+	assert.Assert(t, !ok)
+
+	// rotating without a trust period switches to the new ca right away
+	changed, err = RenewCertsSecret(secret, RenewOptions{RotateCA: true}, 0)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, changed, []string{CACertName, CAKeyName, APIServerCertName, AdminKubeConfigFileName})
+	bundle, err = certutil.ParseCertsPEM(secret.Data[CACertName])
+	assert.NilError(t, err)
+	assert.Equal(t, len(bundle), 1)
+	renewed, err = certutil.ParseCertsPEM(secret.Data[APIServerCertName])
+	assert.NilError(t, err)
+	assert.NilError(t, renewed[0].CheckSignatureFrom(bundle[0]))
+}
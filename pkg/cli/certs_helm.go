@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/loft-sh/log"
+	"github.com/loft-sh/log/table"
+	"github.com/loft-sh/vcluster/pkg/certs"
+	"github.com/loft-sh/vcluster/pkg/cli/find"
+	"github.com/loft-sh/vcluster/pkg/cli/flags"
+	"github.com/loft-sh/vcluster/pkg/lifecycle"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/client-go/kubernetes"
+)
+
+type CertsOptions struct {
+	// Output is the output format of the check command, can be either table or json
+	Output string
+
+	// RotateCA rotates the cluster CA as well
+	RotateCA bool
+
+	// CATrustPeriod defines how long the previous CA keeps issuing and serving certificates after a CA rotation
+	CATrustPeriod time.Duration
+
+	// SkipRestart skips restarting the vCluster pods after the rotation
+	SkipRestart bool
+}
+
+type CertificateStatus struct {
+	Name       string    `json:"name"`
+	CommonName string    `json:"commonName"`
+	CA         bool      `json:"ca"`
+	Previous   bool      `json:"previous,omitempty"`
+	Next       bool      `json:"next,omitempty"`
+	NotAfter   time.Time `json:"notAfter"`
+}
+
+func CertsCheckHelm(ctx context.Context, options *CertsOptions, globalFlags *flags.GlobalFlags, vClusterName string, log log.Logger) error {
+	_, _, secret, err := getCertsSecret(ctx, globalFlags, vClusterName, log)
+	if err != nil {
+		return err
+	}
+
+	certificates, err := certs.ListCertificates(secret.Data)
+	if err != nil {
+		return err
+	}
+
+	if options.Output == "json" {
+		output := []CertificateStatus{}
+		for _, certificate := range certificates {
+			output = append(output, CertificateStatus(certificate))
+		}
+
+		out, err := json.MarshalIndent(output, "", "    ")
+		if err != nil {
+			return fmt.Errorf("json marshal certificates: %w", err)
+		}
+
+		log.WriteString(logrus.InfoLevel, string(out)+"\n")
+		return nil
+	}
+
+	values := [][]string{}
+	for _, certificate := range certificates {
+		name := certificate.Name
+		if certificate.Previous {
+			name += " (previous)"
+		} else if certificate.Next {
+			name += " (next)"
+		}
+
+		residual := "expired"
+		if remaining := time.Until(certificate.NotAfter); remaining > 0 {
+			residual = duration.HumanDuration(remaining)
+		}
+
+		values = append(values, []string{
+			name,
+			certificate.CommonName,
+			fmt.Sprintf("%t", certificate.CA),
+			certificate.NotAfter.Format(time.RFC3339),
+			residual,
+		})
+	}
+
+	table.PrintTable(log, []string{"NAME", "COMMON NAME", "CA", "EXPIRES", "RESIDUAL TIME"}, values)
+	return nil
+}
+
+func CertsRotateHelm(ctx context.Context, options *CertsOptions, globalFlags *flags.GlobalFlags, vClusterName string, log log.Logger) error {
+	vCluster, kubeClient, secret, err := getCertsSecret(ctx, globalFlags, vClusterName, log)
+	if err != nil {
+		return err
+	}
+
+	changed, err := certs.RenewCertsSecret(secret, certs.RenewOptions{RotateCA: options.RotateCA}, options.CATrustPeriod)
+	if err != nil {
+		return err
+	}
+
+	_, err = kubeClient.CoreV1().Secrets(secret.Namespace).Update(ctx, secret, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("update certs secret: %w", err)
+	}
+	log.Infof("Renewed %s", strings.Join(changed, ", "))
+
+	// restart the vCluster pods one by one, so that the control plane picks up the new certificates and updates the kube
+	// config secret
+	if !options.SkipRestart {
+		log.Infof("Restart vCluster pods")
+		err = lifecycle.RolloutRestart(ctx, kubeClient, vCluster.Name, vCluster.Namespace)
+		if err != nil {
+			return err
+		}
+	}
+
+	if options.RotateCA && options.CATrustPeriod > 0 {
+		log.Infof("The new CA is trusted now and replaces the current CA in %s, please make sure to retrieve a new kube config via 'vcluster connect %s' in the meantime", options.CATrustPeriod, vCluster.Name)
+	}
+	log.Donef("Successfully rotated certificates of vCluster %s/%s", vCluster.Namespace, vCluster.Name)
+	return nil
+}
+
+func getCertsSecret(ctx context.Context, globalFlags *flags.GlobalFlags, vClusterName string, log log.Logger) (*find.VCluster, *kubernetes.Clientset, *corev1.Secret, error) {
+	vCluster, err := find.GetVCluster(ctx, globalFlags.Context, vClusterName, globalFlags.Namespace, log)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	kubeConfig, err := vCluster.ClientFactory.ClientConfig()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("there is an error loading your current kube config (%w), please make sure you have access to a kubernetes cluster and the command `kubectl get namespaces` is working", err)
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	secret, err := kubeClient.CoreV1().Secrets(vCluster.Namespace).Get(ctx, vCluster.Name+"-certs", metav1.GetOptions{})
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("get certs secret: %w", err)
+	}
+
+	return vCluster, kubeClient, secret, nil
+}
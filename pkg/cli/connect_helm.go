@@ -2,6 +2,7 @@ package cli
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"io"
@@ -15,9 +16,11 @@ import (
 	"time"
 
 	"github.com/loft-sh/log"
+	vclusterconfig "github.com/loft-sh/vcluster/config"
 	"github.com/loft-sh/vcluster/pkg/cli/find"
 	"github.com/loft-sh/vcluster/pkg/cli/flags"
 	"github.com/loft-sh/vcluster/pkg/cli/localkubernetes"
+	"github.com/loft-sh/vcluster/pkg/credentials"
 	"github.com/loft-sh/vcluster/pkg/lifecycle"
 	"github.com/loft-sh/vcluster/pkg/util/clihelper"
 	"github.com/loft-sh/vcluster/pkg/util/portforward"
@@ -35,6 +38,7 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	certutil "k8s.io/client-go/util/cert"
 )
 
 type ConnectOptions struct {
@@ -49,6 +53,12 @@ type ConnectOptions struct {
 	ServiceAccount            string
 	LocalPort                 int
 	ServiceAccountExpiration  int
+	OIDC                      bool
+	User                      string
+	Groups                    []string
+	NamespaceScope            []string
+	TTL                       time.Duration
+	AllowLongTTL              bool
 	Print                     bool
 	UpdateCurrent             bool
 	BackgroundProxy           bool
@@ -381,7 +391,7 @@ func (cmd *connectHelm) getVClusterKubeConfig(ctx context.Context, vclusterName
 	}
 
 	// start port forwarding
-	if cmd.ServiceAccount != "" || cmd.Server == "" || len(command) > 0 {
+	if cmd.ServiceAccount != "" || cmd.User != "" || cmd.Server == "" || len(command) > 0 {
 		cmd.portForwarding = true
 		cmd.interruptChan = make(chan struct{})
 		cmd.errorChan = make(chan error)
@@ -416,6 +426,56 @@ func (cmd *connectHelm) getVClusterKubeConfig(ctx context.Context, vclusterName
 		}
 	}
 
+	// we want to use a client certificate for a specific user in the kube config
+	if cmd.User != "" {
+		certificate, key, err := createUserClientCertificate(ctx, *kubeConfig, cmd.ConnectOptions, cmd.Log)
+		if err != nil {
+			return nil, err
+		}
+
+		// track the certificate, so that it can be revoked via vcluster credentials revoke
+		parsed, err := certutil.ParseCertsPEM(certificate)
+		if err != nil {
+			return nil, fmt.Errorf("parse client certificate: %w", err)
+		}
+		err = credentials.Record(ctx, cmd.kubeClient, vclusterName, cmd.Namespace, parsed[0], cmd.NamespaceScope)
+		if err != nil {
+			return nil, fmt.Errorf("record client certificate: %w", err)
+		}
+
+		// set client certificate
+		for k := range kubeConfig.AuthInfos {
+			kubeConfig.AuthInfos[k] = &clientcmdapi.AuthInfo{
+				ClientCertificateData: certificate,
+				ClientKeyData:         key,
+				Extensions:            make(map[string]runtime.Object),
+				ImpersonateUserExtra:  make(map[string][]string),
+			}
+		}
+	}
+
+	// we want to log in via the OpenID provider of the vCluster
+	if cmd.OIDC {
+		vConfig, err := getVClusterConfig(ctx, cmd.kubeClient, vclusterName, cmd.Namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		execConfig, err := createOIDCExecConfig(vConfig.ControlPlane.Auth.OIDC)
+		if err != nil {
+			return nil, err
+		}
+
+		// set exec credential plugin
+		for k := range kubeConfig.AuthInfos {
+			kubeConfig.AuthInfos[k] = &clientcmdapi.AuthInfo{
+				Exec:                 execConfig,
+				Extensions:           make(map[string]runtime.Object),
+				ImpersonateUserExtra: make(map[string][]string),
+			}
+		}
+	}
+
 	return kubeConfig, nil
 }
 
@@ -809,3 +869,32 @@ func createServiceAccountToken(ctx context.Context, vKubeConfig clientcmdapi.Con
 
 	return token, nil
 }
+
+// createOIDCExecConfig returns an exec credential plugin config that retrieves id tokens from the OpenID provider
+// via kubelogin (https://github.com/int128/kubelogin)
+func createOIDCExecConfig(oidc vclusterconfig.ControlPlaneAuthOIDC) (*clientcmdapi.ExecConfig, error) {
+	if !oidc.Enabled {
+		return nil, fmt.Errorf("OIDC authentication is not enabled for this vCluster, please configure controlPlane.auth.oidc")
+	}
+
+	args := []string{
+		"oidc-login",
+		"get-token",
+		"--oidc-issuer-url=" + oidc.IssuerURL,
+		"--oidc-client-id=" + oidc.ClientID,
+	}
+	for _, scope := range oidc.ExtraScopes {
+		args = append(args, "--oidc-extra-scope="+scope)
+	}
+	if oidc.CA != "" {
+		args = append(args, "--certificate-authority-data="+base64.StdEncoding.EncodeToString([]byte(oidc.CA)))
+	}
+
+	return &clientcmdapi.ExecConfig{
+		APIVersion:      "client.authentication.k8s.io/v1beta1",
+		Command:         "kubectl",
+		Args:            args,
+		InstallHint:     "Please install the kubelogin kubectl plugin via 'kubectl krew install oidc-login', see https://github.com/int128/kubelogin for more information",
+		InteractiveMode: clientcmdapi.IfAvailableExecInteractiveMode,
+	}, nil
+}
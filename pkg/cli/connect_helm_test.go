@@ -3,6 +3,7 @@ package cli
 import (
 	"testing"
 
+	vclusterconfig "github.com/loft-sh/vcluster/config"
 	"github.com/loft-sh/vcluster/pkg/cli/flags"
 	"gotest.tools/v3/assert"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
@@ -99,3 +100,26 @@ func TestExchangeContextName(t *testing.T) {
 		assert.DeepEqual(t, newConfig, testCase.expectedConfig)
 	}
 }
+
+func TestCreateOIDCExecConfig(t *testing.T) {
+	_, err := createOIDCExecConfig(vclusterconfig.ControlPlaneAuthOIDC{})
+	assert.ErrorContains(t, err, "OIDC authentication is not enabled")
+
+	execConfig, err := createOIDCExecConfig(vclusterconfig.ControlPlaneAuthOIDC{
+		Enabled:     true,
+		IssuerURL:   "https://dex.example.com",
+		ClientID:    "vcluster",
+		CA:          "ca",
+		ExtraScopes: []string{"groups"},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, execConfig.Command, "kubectl")
+	assert.DeepEqual(t, execConfig.Args, []string{
+		"oidc-login",
+		"get-token",
+		"--oidc-issuer-url=https://dex.example.com",
+		"--oidc-client-id=vcluster",
+		"--oidc-extra-scope=groups",
+		"--certificate-authority-data=Y2E=",
+	})
+}
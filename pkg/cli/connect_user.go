@@ -0,0 +1,278 @@
+package cli
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/loft-sh/log"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/client-go/util/keyutil"
+	"k8s.io/utils/ptr"
+)
+
+// ConnectUserAnnotation is set on all objects vcluster connect creates for a user
+const ConnectUserAnnotation = "vcluster.loft.sh/connect-user"
+
+const (
+	// DefaultUserTTL is the validity of client certificates issued via --user if --ttl is not given
+	DefaultUserTTL = 8 * time.Hour
+
+	// MaxUserTTL is the maximum validity of client certificates issued via --user without --allow-long-ttl
+	MaxUserTTL = 24 * time.Hour
+)
+
+// ValidateUserOptions validates the options of client certificates issued via --user
+func ValidateUserOptions(options *ConnectOptions) error {
+	if options.TTL < 10*time.Minute {
+		return fmt.Errorf("--ttl needs to be at least 10m")
+	} else if options.TTL > MaxUserTTL && !options.AllowLongTTL {
+		return fmt.Errorf("--ttl %s is longer than %s, please also specify --allow-long-ttl", options.TTL, MaxUserTTL)
+	}
+
+	// system groups such as system:masters would bypass the namespace scope
+	if len(options.NamespaceScope) > 0 {
+		for _, group := range options.Groups {
+			if strings.HasPrefix(group, "system:") {
+				return fmt.Errorf("group %s cannot be used together with --namespace-scope", group)
+			}
+		}
+	}
+
+	return nil
+}
+
+// createUserClientCertificate issues a client certificate for the user with the given groups via the certificate signing
+// request api of the virtual cluster, which signs it with the vCluster client CA. If namespaces are given, the user is
+// granted access to these namespaces only.
+func createUserClientCertificate(ctx context.Context, vKubeConfig clientcmdapi.Config, options *ConnectOptions, log log.Logger) ([]byte, []byte, error) {
+	err := ValidateUserOptions(options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	vKubeClient, err := getLocalVClusterClient(vKubeConfig, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("generate private key: %w", err)
+	}
+	request, err := newClientCertificateRequest(options.User, options.Groups, key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	csr := &certificatesv1.CertificateSigningRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "vcluster-connect-",
+			Annotations: map[string]string{
+				ConnectUserAnnotation: options.User,
+			},
+		},
+		Spec: certificatesv1.CertificateSigningRequestSpec{
+			Request:    request,
+			SignerName: certificatesv1.KubeAPIServerClientSignerName,
+			Usages: []certificatesv1.KeyUsage{
+				certificatesv1.UsageDigitalSignature,
+				certificatesv1.UsageKeyEncipherment,
+				certificatesv1.UsageClientAuth,
+			},
+		},
+	}
+	csr.Spec.ExpirationSeconds = ptr.To(int32(options.TTL.Seconds()))
+
+	log.Infof("Create client certificate for user %s", options.User)
+	var certificate []byte
+	err = wait.PollUntilContextTimeout(ctx, time.Second, time.Minute*3, false, func(ctx context.Context) (bool, error) {
+		// create the certificate signing request
+		if csr.Name == "" {
+			created, err := vKubeClient.CertificatesV1().CertificateSigningRequests().Create(ctx, csr, metav1.CreateOptions{})
+			if err != nil {
+				if kerrors.IsForbidden(err) || kerrors.IsInvalid(err) {
+					return false, err
+				}
+
+				return false, nil
+			}
+
+			csr = created
+		}
+
+		// approve the certificate signing request
+		if !isCertificateSigningRequestApproved(csr) {
+			csr.Status.Conditions = append(csr.Status.Conditions, certificatesv1.CertificateSigningRequestCondition{
+				Type:    certificatesv1.CertificateApproved,
+				Status:  corev1.ConditionTrue,
+				Reason:  "VClusterConnect",
+				Message: "Approved by vcluster connect",
+			})
+			updated, err := vKubeClient.CertificatesV1().CertificateSigningRequests().UpdateApproval(ctx, csr.Name, csr, metav1.UpdateOptions{})
+			if err != nil {
+				if kerrors.IsForbidden(err) {
+					return false, err
+				}
+
+				return false, nil
+			}
+
+			csr = updated
+		}
+
+		// wait until the certificate was issued
+		current, err := vKubeClient.CertificatesV1().CertificateSigningRequests().Get(ctx, csr.Name, metav1.GetOptions{})
+		if err != nil {
+			if kerrors.IsForbidden(err) {
+				return false, err
+			}
+
+			return false, nil
+		}
+		for _, condition := range current.Status.Conditions {
+			if condition.Type == certificatesv1.CertificateFailed || condition.Type == certificatesv1.CertificateDenied {
+				return false, fmt.Errorf("certificate signing request %s was not signed: %s", current.Name, condition.Message)
+			}
+		}
+
+		certificate = current.Status.Certificate
+		return len(certificate) > 0, nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("create client certificate: %w", err)
+	}
+
+	// grant access to the namespaces
+	for _, namespace := range options.NamespaceScope {
+		err = ensureNamespaceScope(ctx, vKubeClient, options.User, namespace)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		log.Donef("Granted user %s access to namespace %s", options.User, namespace)
+	}
+
+	keyData, err := keyutil.MarshalPrivateKeyToPEM(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal private key: %w", err)
+	}
+
+	return certificate, keyData, nil
+}
+
+func newClientCertificateRequest(user string, groups []string, key *ecdsa.PrivateKey) ([]byte, error) {
+	request, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{
+			CommonName:   user,
+			Organization: groups,
+		},
+	}, key)
+	if err != nil {
+		return nil, fmt.Errorf("create certificate request: %w", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: request}), nil
+}
+
+func isCertificateSigningRequestApproved(csr *certificatesv1.CertificateSigningRequest) bool {
+	for _, condition := range csr.Status.Conditions {
+		if condition.Type == certificatesv1.CertificateApproved && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ensureNamespaceScope creates a role with full access to the namespace and binds it to the user. The role binding is
+// not tied to the certificate and persists after it expires or is revoked, which is why it is tracked alongside the
+// issued credential and shown by vcluster credentials list.
+func ensureNamespaceScope(ctx context.Context, vKubeClient kubernetes.Interface, user, namespace string) error {
+	role, roleBinding := newNamespaceScope(user, namespace)
+	_, err := vKubeClient.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		_, err = vKubeClient.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}, metav1.CreateOptions{})
+	}
+	if err != nil && !kerrors.IsAlreadyExists(err) {
+		return fmt.Errorf("create namespace %s: %w", namespace, err)
+	}
+
+	_, err = vKubeClient.RbacV1().Roles(namespace).Update(ctx, role, metav1.UpdateOptions{})
+	if kerrors.IsNotFound(err) {
+		_, err = vKubeClient.RbacV1().Roles(namespace).Create(ctx, role, metav1.CreateOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("create role %s/%s: %w", namespace, role.Name, err)
+	}
+
+	_, err = vKubeClient.RbacV1().RoleBindings(namespace).Update(ctx, roleBinding, metav1.UpdateOptions{})
+	if kerrors.IsNotFound(err) {
+		_, err = vKubeClient.RbacV1().RoleBindings(namespace).Create(ctx, roleBinding, metav1.CreateOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("create role binding %s/%s: %w", namespace, roleBinding.Name, err)
+	}
+
+	return nil
+}
+
+// NamespaceScopeName returns the name of the role and role binding that grant the user access to a namespace
+func NamespaceScopeName(user string) string {
+	return translate.SafeConcatName("vcluster", "user", user)
+}
+
+func newNamespaceScope(user, namespace string) (*rbacv1.Role, *rbacv1.RoleBinding) {
+	name := NamespaceScopeName(user)
+	annotations := map[string]string{
+		ConnectUserAnnotation: user,
+	}
+
+	return &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			Annotations: annotations,
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{"*"},
+				Resources: []string{"*"},
+				Verbs:     []string{"*"},
+			},
+		},
+	}, &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			Annotations: annotations,
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.SchemeGroupVersion.Group,
+			Kind:     "Role",
+			Name:     name,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				APIGroup: rbacv1.SchemeGroupVersion.Group,
+				Kind:     rbacv1.UserKind,
+				Name:     user,
+			},
+		},
+	}
+}
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+func TestNewClientCertificateRequest(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+
+	request, err := newClientCertificateRequest("alice", []string{"dev", "ops"}, key)
+	assert.NilError(t, err)
+	block, _ := pem.Decode(request)
+	assert.Assert(t, block != nil)
+	assert.Equal(t, block.Type, "CERTIFICATE REQUEST")
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	assert.NilError(t, err)
+	assert.NilError(t, csr.CheckSignature())
+	assert.Equal(t, csr.Subject.CommonName, "alice")
+	assert.DeepEqual(t, csr.Subject.Organization, []string{"dev", "ops"})
+}
+
+func TestNewNamespaceScope(t *testing.T) {
+	role, roleBinding := newNamespaceScope("alice@example.com", "ns1")
+	assert.Equal(t, role.Namespace, "ns1")
+	assert.Equal(t, roleBinding.Namespace, "ns1")
+	assert.Equal(t, roleBinding.RoleRef.Kind, "Role")
+	assert.Equal(t, roleBinding.RoleRef.Name, role.Name)
+	assert.DeepEqual(t, roleBinding.Subjects, []rbacv1.Subject{{
+		APIGroup: rbacv1.GroupName,
+		Kind:     rbacv1.UserKind,
+		Name:     "alice@example.com",
+	}})
+	assert.Equal(t, role.Annotations[ConnectUserAnnotation], "alice@example.com")
+}
+
+func TestValidateUserOptions(t *testing.T) {
+	testCases := []struct {
+		name    string
+		options ConnectOptions
+		err     string
+	}{
+		{
+			name:    "default ttl",
+			options: ConnectOptions{TTL: DefaultUserTTL, Groups: []string{"dev"}, NamespaceScope: []string{"ns1"}},
+		},
+		{
+			name:    "short ttl",
+			options: ConnectOptions{TTL: time.Minute},
+			err:     "--ttl needs to be at least 10m",
+		},
+		{
+			name:    "long ttl",
+			options: ConnectOptions{TTL: 30 * 24 * time.Hour},
+			err:     "please also specify --allow-long-ttl",
+		},
+		{
+			name:    "allowed long ttl",
+			options: ConnectOptions{TTL: 30 * 24 * time.Hour, AllowLongTTL: true},
+		},
+		{
+			name:    "system group with namespace scope",
+			options: ConnectOptions{TTL: DefaultUserTTL, Groups: []string{"dev", "system:masters"}, NamespaceScope: []string{"ns1"}},
+			err:     "group system:masters cannot be used together with --namespace-scope",
+		},
+		{
+			name:    "system group without namespace scope",
+			options: ConnectOptions{TTL: DefaultUserTTL, Groups: []string{"system:masters"}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := ValidateUserOptions(&testCase.options)
+			if testCase.err == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.ErrorContains(t, err, testCase.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/loft-sh/log"
+	"github.com/loft-sh/log/table"
+	"github.com/loft-sh/vcluster/pkg/cli/find"
+	"github.com/loft-sh/vcluster/pkg/cli/flags"
+	"github.com/loft-sh/vcluster/pkg/credentials"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/client-go/kubernetes"
+)
+
+type CredentialsOptions struct {
+	// Output is the output format of the list command, can be either table or json
+	Output string
+}
+
+func CredentialsListHelm(ctx context.Context, options *CredentialsOptions, globalFlags *flags.GlobalFlags, vClusterName string, log log.Logger) error {
+	vCluster, kubeClient, err := getCredentialsClient(ctx, globalFlags, vClusterName, log)
+	if err != nil {
+		return err
+	}
+
+	issued, err := credentials.List(ctx, kubeClient, vCluster.Name, vCluster.Namespace)
+	if err != nil {
+		return err
+	}
+
+	if options.Output == "json" {
+		out, err := json.MarshalIndent(issued, "", "    ")
+		if err != nil {
+			return fmt.Errorf("json marshal credentials: %w", err)
+		}
+
+		log.WriteString(logrus.InfoLevel, string(out)+"\n")
+		return nil
+	}
+
+	values := [][]string{}
+	for _, credential := range issued {
+		residual := "expired"
+		if remaining := time.Until(credential.NotAfter); remaining > 0 {
+			residual = duration.HumanDuration(remaining)
+		}
+
+		revoked := ""
+		if credential.Revoked != nil {
+			revoked = credential.Revoked.Format(time.RFC3339)
+		}
+
+		values = append(values, []string{
+			credential.Serial,
+			credential.Subject,
+			strings.Join(credential.Groups, ","),
+			strings.Join(credential.Namespaces, ","),
+			credential.NotAfter.Format(time.RFC3339),
+			residual,
+			revoked,
+		})
+	}
+
+	table.PrintTable(log, []string{"SERIAL", "SUBJECT", "GROUPS", "NAMESPACES", "EXPIRES", "RESIDUAL TIME", "REVOKED"}, values)
+	return nil
+}
+
+func CredentialsRevokeHelm(ctx context.Context, globalFlags *flags.GlobalFlags, vClusterName, serialOrSubject string, log log.Logger) error {
+	vCluster, kubeClient, err := getCredentialsClient(ctx, globalFlags, vClusterName, log)
+	if err != nil {
+		return err
+	}
+
+	revoked, err := credentials.Revoke(ctx, kubeClient, vCluster.Name, vCluster.Namespace, serialOrSubject)
+	if err != nil {
+		return err
+	}
+
+	for _, credential := range revoked {
+		log.Donef("Revoked client certificate %s of %s, which expires at %s", credential.Serial, credential.Subject, credential.NotAfter.Format(time.RFC3339))
+		for _, namespace := range credential.Namespaces {
+			log.Warnf("Role binding %s/%s still grants %s access to namespace %s, delete it within the virtual cluster to remove the access", namespace, NamespaceScopeName(credential.Subject), credential.Subject, namespace)
+		}
+	}
+
+	return nil
+}
+
+func getCredentialsClient(ctx context.Context, globalFlags *flags.GlobalFlags, vClusterName string, log log.Logger) (*find.VCluster, *kubernetes.Clientset, error) {
+	vCluster, err := find.GetVCluster(ctx, globalFlags.Context, vClusterName, globalFlags.Namespace, log)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	kubeConfig, err := vCluster.ClientFactory.ClientConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("there is an error loading your current kube config (%w), please make sure you have access to a kubernetes cluster and the command `kubectl get namespaces` is working", err)
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return vCluster, kubeClient, nil
+}
@@ -0,0 +1,56 @@
+package describe
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Collision is a host name that multiple virtual objects are translated to
+type Collision struct {
+	HostName string   `json:"hostName"`
+	Virtual  []string `json:"virtual"`
+}
+
+// Collisions lists all virtual objects of the given resource that are translated to the same host name
+func (d *Describer) Collisions(ctx context.Context, resource string) (string, []Collision, error) {
+	info, err := resolveResource(d.Virtual.Mapper, resource)
+	if err != nil {
+		return "", nil, fmt.Errorf("resolve resource %s in virtual cluster: %w", resource, err)
+	}
+
+	list, err := resourceInterface(d.Virtual, info, metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return "", nil, fmt.Errorf("list virtual objects: %w", err)
+	}
+
+	byHostName := map[string][]string{}
+	for _, vObj := range list.Items {
+		hostName := d.Translator.HostNameCluster(vObj.GetName())
+		if info.namespaced {
+			hostName = formatName(d.Translator.HostNamespace(vObj.GetNamespace()), d.Translator.HostName(vObj.GetName(), vObj.GetNamespace()))
+		}
+
+		byHostName[hostName] = append(byHostName[hostName], formatName(vObj.GetNamespace(), vObj.GetName()))
+	}
+
+	collisions := []Collision{}
+	for hostName, virtual := range byHostName {
+		if len(virtual) < 2 {
+			continue
+		}
+
+		sort.Strings(virtual)
+		collisions = append(collisions, Collision{
+			HostName: hostName,
+			Virtual:  virtual,
+		})
+	}
+	sort.Slice(collisions, func(i, j int) bool {
+		return collisions[i].HostName < collisions[j].HostName
+	})
+
+	return info.gvk.Kind, collisions, nil
+}
@@ -0,0 +1,338 @@
+package describe
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	syncertypes "github.com/loft-sh/vcluster/pkg/syncer/types"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Cluster holds the clients to access one side of the virtual cluster
+type Cluster struct {
+	Client     dynamic.Interface
+	KubeClient kubernetes.Interface
+	Mapper     meta.RESTMapper
+}
+
+// Describer resolves the mapping between a virtual object and its host object
+type Describer struct {
+	Virtual Cluster
+	Host    Cluster
+
+	// Translator is the translator the virtual cluster uses to translate names
+	Translator translate.Translator
+}
+
+// Result is the outcome of a describe
+type Result struct {
+	Kind string `json:"kind"`
+
+	Virtual *unstructured.Unstructured `json:"virtual,omitempty"`
+	Host    *unstructured.Unstructured `json:"host,omitempty"`
+
+	SyncStatus *syncertypes.SyncStatus `json:"syncStatus,omitempty"`
+
+	Differences []FieldDiff    `json:"differences,omitempty"`
+	HostEvents  []corev1.Event `json:"hostEvents,omitempty"`
+}
+
+type resourceInfo struct {
+	gvk        schema.GroupVersionKind
+	gvr        schema.GroupVersionResource
+	namespaced bool
+}
+
+// DescribeVirtual looks up the virtual object and tries to find the host object it was synced to
+func (d *Describer) DescribeVirtual(ctx context.Context, resource, namespace, name string) (*Result, error) {
+	info, err := resolveResource(d.Virtual.Mapper, resource)
+	if err != nil {
+		return nil, fmt.Errorf("resolve resource %s in virtual cluster: %w", resource, err)
+	}
+	if !info.namespaced {
+		namespace = ""
+	}
+
+	vObj, err := getObject(ctx, d.Virtual, info, types.NamespacedName{Namespace: namespace, Name: name})
+	if err != nil {
+		return nil, fmt.Errorf("get virtual object: %w", err)
+	} else if vObj == nil {
+		return nil, fmt.Errorf("%s %s not found in virtual cluster", info.gvk.Kind, formatName(namespace, name))
+	}
+
+	result := &Result{
+		Kind:    info.gvk.Kind,
+		Virtual: vObj,
+	}
+	result.SyncStatus, err = parseSyncStatus(vObj)
+	if err != nil {
+		return nil, err
+	}
+
+	pObj, err := d.findHostObject(ctx, info, vObj, result.SyncStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.complete(ctx, result, pObj)
+}
+
+// DescribeHost looks up the host object and tries to find the virtual object it belongs to
+func (d *Describer) DescribeHost(ctx context.Context, resource, namespace, name string) (*Result, error) {
+	info, err := resolveResource(d.Host.Mapper, resource)
+	if err != nil {
+		return nil, fmt.Errorf("resolve resource %s in host cluster: %w", resource, err)
+	}
+	if !info.namespaced {
+		namespace = ""
+	}
+
+	pObj, err := getObject(ctx, d.Host, info, types.NamespacedName{Namespace: namespace, Name: name})
+	if err != nil {
+		return nil, fmt.Errorf("get host object: %w", err)
+	} else if pObj == nil {
+		return nil, fmt.Errorf("%s %s not found in host cluster", info.gvk.Kind, formatName(namespace, name))
+	}
+
+	// objects synced from the virtual cluster have the virtual name stored in annotations, all others
+	// are mirrored by the syncer and have the same name in the virtual cluster.
+	vName := types.NamespacedName{Namespace: pObj.GetNamespace(), Name: pObj.GetName()}
+	if pObj.GetAnnotations()[translate.NameAnnotation] != "" {
+		vName = types.NamespacedName{
+			Namespace: pObj.GetAnnotations()[translate.NamespaceAnnotation],
+			Name:      pObj.GetAnnotations()[translate.NameAnnotation],
+		}
+	}
+
+	result := &Result{
+		Kind: info.gvk.Kind,
+	}
+	vInfo, err := restMappingFor(d.Virtual.Mapper, info.gvk)
+	if err == nil {
+		if !vInfo.namespaced {
+			vName.Namespace = ""
+		}
+
+		result.Virtual, err = getObject(ctx, d.Virtual, vInfo, vName)
+		if err != nil {
+			return nil, fmt.Errorf("get virtual object: %w", err)
+		}
+	} else if !meta.IsNoMatchError(err) {
+		return nil, err
+	}
+	if result.Virtual != nil {
+		result.SyncStatus, err = parseSyncStatus(result.Virtual)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return d.complete(ctx, result, pObj)
+}
+
+func (d *Describer) complete(ctx context.Context, result *Result, pObj *unstructured.Unstructured) (*Result, error) {
+	result.Host = pObj
+	if result.Virtual == nil || result.Host == nil {
+		return result, nil
+	}
+
+	result.Differences = Diff(result.Virtual, result.Host)
+
+	events, err := d.hostEvents(ctx, result.Kind, pObj)
+	if err != nil {
+		return nil, err
+	}
+	result.HostEvents = events
+	return result, nil
+}
+
+func (d *Describer) findHostObject(ctx context.Context, vInfo resourceInfo, vObj *unstructured.Unstructured, syncStatus *syncertypes.SyncStatus) (*unstructured.Unstructured, error) {
+	info, err := restMappingFor(d.Host.Mapper, vInfo.gvk)
+	if meta.IsNoMatchError(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	// the syncer records the host name in the sync status
+	if syncStatus != nil && syncStatus.HostName != "" {
+		pObj, err := getObject(ctx, d.Host, info, parseName(syncStatus.HostName))
+		if err != nil || pObj != nil {
+			return pObj, err
+		}
+	}
+
+	// try the default name translation
+	hostName := types.NamespacedName{Name: d.Translator.HostNameCluster(vObj.GetName())}
+	if vInfo.namespaced {
+		hostName = types.NamespacedName{
+			Namespace: d.Translator.HostNamespace(vObj.GetNamespace()),
+			Name:      d.Translator.HostName(vObj.GetName(), vObj.GetNamespace()),
+		}
+	}
+	pObj, err := getObject(ctx, d.Host, info, hostName)
+	if err != nil {
+		return nil, err
+	} else if pObj != nil && belongsTo(pObj, vObj) {
+		return pObj, nil
+	}
+
+	// search for an object that points to the virtual object, this covers objects with custom name translation
+	list, err := resourceInterface(d.Host, info, hostName.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil && !kerrors.IsForbidden(err) {
+		return nil, fmt.Errorf("list host objects: %w", err)
+	} else if list != nil {
+		for i := range list.Items {
+			if belongsTo(&list.Items[i], vObj) {
+				return &list.Items[i], nil
+			}
+		}
+	}
+
+	// objects synced from the host cluster are mirrored with the same name
+	return getObject(ctx, d.Host, info, types.NamespacedName{Namespace: vObj.GetNamespace(), Name: vObj.GetName()})
+}
+
+func (d *Describer) hostEvents(ctx context.Context, kind string, pObj *unstructured.Unstructured) ([]corev1.Event, error) {
+	if d.Host.KubeClient == nil {
+		return nil, nil
+	}
+
+	fieldSelector := fields.Set{
+		"involvedObject.kind": kind,
+		"involvedObject.name": pObj.GetName(),
+	}
+	if pObj.GetNamespace() != "" {
+		fieldSelector["involvedObject.namespace"] = pObj.GetNamespace()
+	}
+	eventList, err := d.Host.KubeClient.CoreV1().Events(pObj.GetNamespace()).List(ctx, metav1.ListOptions{
+		FieldSelector: fieldSelector.AsSelector().String(),
+	})
+	if err != nil {
+		if kerrors.IsForbidden(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("list host events: %w", err)
+	}
+
+	events := eventList.Items
+	sort.SliceStable(events, func(i, j int) bool {
+		return EventTime(events[i]).Before(EventTime(events[j]))
+	})
+	return events, nil
+}
+
+func resourceInterface(cluster Cluster, info resourceInfo, namespace string) dynamic.ResourceInterface {
+	if info.namespaced {
+		return cluster.Client.Resource(info.gvr).Namespace(namespace)
+	}
+
+	return cluster.Client.Resource(info.gvr)
+}
+
+func getObject(ctx context.Context, cluster Cluster, info resourceInfo, name types.NamespacedName) (*unstructured.Unstructured, error) {
+	if name.Name == "" {
+		return nil, nil
+	}
+
+	obj, err := resourceInterface(cluster, info, name.Namespace).Get(ctx, name.Name, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+func resolveResource(mapper meta.RESTMapper, resource string) (resourceInfo, error) {
+	groupResource := schema.ParseGroupResource(resource)
+	gvk, err := mapper.KindFor(groupResource.WithVersion(""))
+	if err != nil {
+		return resourceInfo{}, err
+	}
+
+	return restMappingFor(mapper, gvk)
+}
+
+func restMappingFor(mapper meta.RESTMapper, gvk schema.GroupVersionKind) (resourceInfo, error) {
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return resourceInfo{}, err
+	}
+
+	return resourceInfo{
+		gvk:        mapping.GroupVersionKind,
+		gvr:        mapping.Resource,
+		namespaced: mapping.Scope.Name() == meta.RESTScopeNameNamespace,
+	}, nil
+}
+
+// belongsTo checks if the host object was synced from the given virtual object
+func belongsTo(pObj, vObj *unstructured.Unstructured) bool {
+	annotations := pObj.GetAnnotations()
+	if annotations[translate.NameAnnotation] != vObj.GetName() {
+		return false
+	}
+
+	return vObj.GetNamespace() == "" || annotations[translate.NamespaceAnnotation] == vObj.GetNamespace()
+}
+
+func parseSyncStatus(vObj *unstructured.Unstructured) (*syncertypes.SyncStatus, error) {
+	raw := vObj.GetAnnotations()[translate.SyncStatusAnnotation]
+	if raw == "" {
+		return nil, nil
+	}
+
+	syncStatus := &syncertypes.SyncStatus{}
+	err := json.Unmarshal([]byte(raw), syncStatus)
+	if err != nil {
+		return nil, fmt.Errorf("parse sync status: %w", err)
+	}
+
+	return syncStatus, nil
+}
+
+func parseName(name string) types.NamespacedName {
+	namespace, name, found := strings.Cut(name, "/")
+	if !found {
+		return types.NamespacedName{Name: namespace}
+	}
+
+	return types.NamespacedName{Namespace: namespace, Name: name}
+}
+
+func formatName(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+
+	return namespace + "/" + name
+}
+
+func EventTime(event corev1.Event) time.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	} else if !event.EventTime.IsZero() {
+		return event.EventTime.Time
+	}
+
+	return event.CreationTimestamp.Time
+}
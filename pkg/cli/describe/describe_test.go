@@ -0,0 +1,191 @@
+package describe
+
+import (
+	"context"
+	"testing"
+
+	"github.com/loft-sh/vcluster/pkg/scheme"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func newFakeCluster(objs ...runtime.Object) Cluster {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Pod"), meta.RESTScopeNamespace)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Node"), meta.RESTScopeRoot)
+
+	return Cluster{
+		Client:     dynamicfake.NewSimpleDynamicClient(scheme.Scheme, objs...),
+		KubeClient: kubefake.NewSimpleClientset(),
+		Mapper:     mapper,
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	translator := translate.NewSingleNamespaceTranslator("vcluster")
+	hostName := translator.HostName("nginx", "default")
+
+	vPod := &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{Kind: "Pod", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx",
+			Namespace: "default",
+			Labels: map[string]string{
+				"app": "nginx",
+			},
+		},
+		Spec: corev1.PodSpec{
+			ServiceAccountName: "default",
+		},
+	}
+	pPod := &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{Kind: "Pod", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      hostName,
+			Namespace: "vcluster",
+			Labels: map[string]string{
+				"app":                    "nginx",
+				translate.MarkerLabel:    translate.VClusterName,
+				translate.NamespaceLabel: "default",
+			},
+			Annotations: map[string]string{
+				translate.NameAnnotation:      "nginx",
+				translate.NamespaceAnnotation: "default",
+			},
+		},
+		Spec: corev1.PodSpec{
+			ServiceAccountName: "vc-workload-vcluster",
+		},
+	}
+	otherPod := &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{Kind: "Pod", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "custom-name",
+			Namespace: "vcluster",
+			Annotations: map[string]string{
+				translate.NameAnnotation:      "other",
+				translate.NamespaceAnnotation: "default",
+			},
+		},
+	}
+	vOtherPod := &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{Kind: "Pod", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "other",
+			Namespace: "default",
+		},
+	}
+	node := &corev1.Node{
+		TypeMeta: metav1.TypeMeta{Kind: "Node", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-1",
+		},
+	}
+
+	describer := &Describer{
+		Virtual:    newFakeCluster(vPod, vOtherPod, node.DeepCopy()),
+		Host:       newFakeCluster(pPod, otherPod, node.DeepCopy()),
+		Translator: translator,
+	}
+
+	ctx := context.Background()
+	result, err := describer.DescribeVirtual(ctx, "pods", "default", "nginx")
+	assert.NilError(t, err)
+	assert.Equal(t, result.Kind, "Pod")
+	assert.Equal(t, result.Host.GetName(), hostName)
+	assert.DeepEqual(t, result.Differences, []FieldDiff{
+		{Path: "metadata.annotations[" + translate.NameAnnotation + "]", Virtual: NoValue, Host: "nginx"},
+		{Path: "metadata.annotations[" + translate.NamespaceAnnotation + "]", Virtual: NoValue, Host: "default"},
+		{Path: "metadata.labels[" + translate.MarkerLabel + "]", Virtual: NoValue, Host: translate.VClusterName},
+		{Path: "metadata.labels[" + translate.NamespaceLabel + "]", Virtual: NoValue, Host: "default"},
+		{Path: "metadata.name", Virtual: "nginx", Host: hostName},
+		{Path: "metadata.namespace", Virtual: "default", Host: "vcluster"},
+		{Path: "spec.serviceAccountName", Virtual: "default", Host: "vc-workload-vcluster"},
+	})
+
+	// custom host names are found through the name annotations
+	result, err = describer.DescribeVirtual(ctx, "pods", "default", "other")
+	assert.NilError(t, err)
+	assert.Equal(t, result.Host.GetName(), "custom-name")
+
+	// reverse lookup through the host object
+	result, err = describer.DescribeHost(ctx, "pods", "vcluster", hostName)
+	assert.NilError(t, err)
+	assert.Equal(t, result.Virtual.GetName(), "nginx")
+	assert.Equal(t, result.Virtual.GetNamespace(), "default")
+
+	// mirrored objects have the same name
+	result, err = describer.DescribeVirtual(ctx, "nodes", "", "node-1")
+	assert.NilError(t, err)
+	assert.Equal(t, result.Host.GetName(), "node-1")
+	assert.Equal(t, len(result.Differences), 0)
+
+	// objects that are not synced have no host object
+	_, err = describer.DescribeVirtual(ctx, "pods", "default", "missing")
+	assert.ErrorContains(t, err, "not found in virtual cluster")
+}
+
+func TestDiff(t *testing.T) {
+	vObj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":            "test",
+			"resourceVersion": "1",
+		},
+		"spec": map[string]interface{}{
+			"list":  []interface{}{"a", "b"},
+			"count": int64(1),
+		},
+	}}
+	pObj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":            "test",
+			"resourceVersion": "2",
+		},
+		"spec": map[string]interface{}{
+			"list":  []interface{}{"a"},
+			"count": int64(2),
+			"empty": map[string]interface{}{},
+		},
+	}}
+
+	assert.DeepEqual(t, Diff(vObj, pObj), []FieldDiff{
+		{Path: "spec.count", Virtual: "1", Host: "2"},
+		{Path: "spec.empty", Virtual: NoValue, Host: "{}"},
+		{Path: "spec.list[1]", Virtual: "b", Host: NoValue},
+	})
+}
+
+// concatNamingStrategy is not injective, e.g. team-a/web and team/a-web have the same host name
+type concatNamingStrategy struct{}
+
+func (concatNamingStrategy) HostName(vName, vNamespace string) string {
+	return vNamespace + "-" + vName
+}
+
+func TestCollisions(t *testing.T) {
+	newPod := func(namespace, name string) *corev1.Pod {
+		return &corev1.Pod{
+			TypeMeta:   metav1.TypeMeta{Kind: "Pod", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		}
+	}
+	describer := &Describer{
+		Virtual:    newFakeCluster(newPod("team-a", "web"), newPod("team", "a-web"), newPod("team-b", "web")),
+		Host:       newFakeCluster(),
+		Translator: translate.NewSingleNamespaceTranslatorWithNamingStrategy("vcluster", concatNamingStrategy{}),
+	}
+
+	kind, collisions, err := describer.Collisions(context.Background(), "pods")
+	assert.NilError(t, err)
+	assert.Equal(t, kind, "Pod")
+	assert.DeepEqual(t, collisions, []Collision{
+		{HostName: "vcluster/team-a-web", Virtual: []string{"team-a/web", "team/a-web"}},
+	})
+}
@@ -0,0 +1,133 @@
+package describe
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// NoValue is shown if a field is only set on one side
+const NoValue = "<none>"
+
+// ignoredFields are fields that are always different between the virtual and host object and are therefore not interesting
+var ignoredFields = []string{
+	"metadata.uid",
+	"metadata.resourceVersion",
+	"metadata.creationTimestamp",
+	"metadata.generation",
+	"metadata.managedFields",
+	"metadata.selfLink",
+}
+
+// FieldDiff is a single field that differs between the virtual and the host object
+type FieldDiff struct {
+	Path    string `json:"path"`
+	Virtual string `json:"virtual"`
+	Host    string `json:"host"`
+}
+
+// Diff returns all fields that differ between the virtual and the host object sorted by their path
+func Diff(vObj, pObj *unstructured.Unstructured) []FieldDiff {
+	vFields := map[string]string{}
+	pFields := map[string]string{}
+	if vObj != nil {
+		flatten("", vObj.Object, vFields)
+	}
+	if pObj != nil {
+		flatten("", pObj.Object, pFields)
+	}
+
+	paths := map[string]bool{}
+	for path := range vFields {
+		paths[path] = true
+	}
+	for path := range pFields {
+		paths[path] = true
+	}
+
+	diffs := []FieldDiff{}
+	for path := range paths {
+		if isIgnored(path) {
+			continue
+		}
+
+		vValue, vOk := vFields[path]
+		pValue, pOk := pFields[path]
+		if vOk && pOk && vValue == pValue {
+			continue
+		}
+		if !vOk {
+			vValue = NoValue
+		}
+		if !pOk {
+			pValue = NoValue
+		}
+
+		diffs = append(diffs, FieldDiff{
+			Path:    path,
+			Virtual: vValue,
+			Host:    pValue,
+		})
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Path < diffs[j].Path
+	})
+	return diffs
+}
+
+func isIgnored(path string) bool {
+	for _, ignored := range ignoredFields {
+		if path == ignored || strings.HasPrefix(path, ignored+".") || strings.HasPrefix(path, ignored+"[") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func flatten(prefix string, value interface{}, out map[string]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 && prefix != "" {
+			out[prefix] = "{}"
+			return
+		}
+		for key, child := range v {
+			flatten(joinPath(prefix, key), child, out)
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			out[prefix] = "[]"
+			return
+		}
+		for i, child := range v {
+			flatten(fmt.Sprintf("%s[%d]", prefix, i), child, out)
+		}
+	case string:
+		out[prefix] = v
+	case nil:
+		out[prefix] = "null"
+	default:
+		raw, err := json.Marshal(v)
+		if err != nil {
+			out[prefix] = fmt.Sprintf("%v", v)
+			return
+		}
+		out[prefix] = string(raw)
+	}
+}
+
+func joinPath(prefix, key string) string {
+	// keys such as labels and annotations often contain dots or slashes
+	if strings.ContainsAny(key, "./") {
+		return prefix + "[" + key + "]"
+	} else if prefix == "" {
+		return key
+	}
+
+	return prefix + "." + key
+}
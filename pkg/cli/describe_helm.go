@@ -0,0 +1,287 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/loft-sh/log"
+	"github.com/loft-sh/log/table"
+	vclusterconfig "github.com/loft-sh/vcluster/config"
+	"github.com/loft-sh/vcluster/pkg/cli/describe"
+	"github.com/loft-sh/vcluster/pkg/cli/find"
+	"github.com/loft-sh/vcluster/pkg/cli/flags"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/yaml"
+)
+
+// maxValueLength is the maximum length of a field value shown in the table output
+const maxValueLength = 64
+
+type DescribeOptions struct {
+	// ObjectNamespace is the namespace of the object to describe
+	ObjectNamespace string
+
+	// Host specifies that the object is a host object instead of a virtual object
+	Host bool
+
+	// Collisions lists the virtual objects of a resource that are translated to the same host name
+	Collisions bool
+
+	Output string
+}
+
+func DescribeHelm(ctx context.Context, options *DescribeOptions, globalFlags *flags.GlobalFlags, vClusterName, object string, log log.Logger) error {
+	resource, name, found := strings.Cut(object, "/")
+	if options.Collisions {
+		if found || resource == "" {
+			return fmt.Errorf("unexpected resource %s, expected TYPE, e.g. pods", object)
+		}
+	} else if !found || resource == "" || name == "" {
+		return fmt.Errorf("unexpected object %s, expected TYPE/NAME, e.g. pod/nginx", object)
+	}
+
+	vCluster, err := find.GetVCluster(ctx, globalFlags.Context, vClusterName, globalFlags.Namespace, log)
+	if err != nil {
+		return err
+	} else if vCluster.IsSleeping() || vCluster.Status == find.StatusPaused {
+		return fmt.Errorf("vCluster %s is paused, please resume it first", vCluster.Name)
+	}
+
+	// connect to the virtual cluster through a port-forwarding
+	connectCmd := &connectHelm{
+		GlobalFlags:    globalFlags,
+		ConnectOptions: &ConnectOptions{},
+		Log:            log,
+	}
+	err = connectCmd.prepare(ctx, vCluster)
+	if err != nil {
+		return err
+	}
+	kubeConfig, err := connectCmd.getVClusterKubeConfig(ctx, vCluster.Name, []string{"describe"})
+	if err != nil {
+		return err
+	}
+	defer close(connectCmd.interruptChan)
+	err = connectCmd.waitForVCluster(ctx, *kubeConfig, connectCmd.errorChan)
+	if err != nil {
+		return err
+	}
+	vRestConfig, err := clientcmd.NewDefaultClientConfig(getLocalVClusterConfig(*kubeConfig, connectCmd.ConnectOptions), &clientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		return fmt.Errorf("create virtual rest config: %w", err)
+	}
+
+	// create the describer
+	describer, err := newDescriber(ctx, vCluster, connectCmd.kubeClient, connectCmd.restConfig, vRestConfig)
+	if err != nil {
+		return err
+	}
+
+	if options.Collisions {
+		kind, collisions, err := describer.Collisions(ctx, resource)
+		if err != nil {
+			return err
+		}
+
+		return printCollisions(options, kind, collisions, log)
+	}
+
+	var result *describe.Result
+	if options.Host {
+		namespace := options.ObjectNamespace
+		if namespace == "" {
+			namespace = vCluster.Namespace
+		}
+
+		result, err = describer.DescribeHost(ctx, resource, namespace, name)
+	} else {
+		namespace := options.ObjectNamespace
+		if namespace == "" {
+			namespace = metav1.NamespaceDefault
+		}
+
+		result, err = describer.DescribeVirtual(ctx, resource, namespace, name)
+	}
+	if err != nil {
+		return err
+	}
+
+	return printDescribeResult(options, result, log)
+}
+
+func newDescriber(ctx context.Context, vCluster *find.VCluster, kubeClient kubernetes.Interface, hostRestConfig, virtualRestConfig *rest.Config) (*describe.Describer, error) {
+	hostCluster, err := newDescribeCluster(hostRestConfig)
+	if err != nil {
+		return nil, fmt.Errorf("create host clients: %w", err)
+	}
+	virtualCluster, err := newDescribeCluster(virtualRestConfig)
+	if err != nil {
+		return nil, fmt.Errorf("create virtual clients: %w", err)
+	}
+
+	// we need the vCluster config to know how names are translated
+	vConfig, err := getVClusterConfig(ctx, kubeClient, vCluster.Name, vCluster.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	translate.VClusterName = vCluster.Name
+	targetNamespace := vCluster.Namespace
+	if vConfig.Experimental.SyncSettings.TargetNamespace != "" {
+		targetNamespace = vConfig.Experimental.SyncSettings.TargetNamespace
+	}
+	namingStrategy, err := translate.NewNamingStrategy(vConfig.Sync.ToHost.Naming.Strategy, vConfig.Sync.ToHost.Naming.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	translator := translate.NewSingleNamespaceTranslatorWithNamingStrategy(targetNamespace, namingStrategy)
+	if vConfig.MultiNamespaceMode() {
+		translator, err = translate.NewMultiNamespaceTranslator(vCluster.Namespace, vConfig.Sync.ToHost.Namespaces.HostNameTemplate)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &describe.Describer{
+		Virtual:    *virtualCluster,
+		Host:       *hostCluster,
+		Translator: translator,
+	}, nil
+}
+
+func newDescribeCluster(restConfig *rest.Config) (*describe.Cluster, error) {
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	cachedDiscoveryClient := memory.NewMemCacheClient(discoveryClient)
+	return &describe.Cluster{
+		Client:     dynamicClient,
+		KubeClient: kubeClient,
+		Mapper:     restmapper.NewShortcutExpander(restmapper.NewDeferredDiscoveryRESTMapper(cachedDiscoveryClient), cachedDiscoveryClient, nil),
+	}, nil
+}
+
+func getVClusterConfig(ctx context.Context, kubeClient kubernetes.Interface, name, namespace string) (*vclusterconfig.Config, error) {
+	secret, err := kubeClient.CoreV1().Secrets(namespace).Get(ctx, "vc-config-"+name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("get vCluster config: %w", err)
+	}
+
+	vConfig := &vclusterconfig.Config{}
+	err = yaml.Unmarshal(secret.Data["config.yaml"], vConfig)
+	if err != nil {
+		return nil, fmt.Errorf("parse vCluster config: %w", err)
+	}
+
+	return vConfig, nil
+}
+
+func printDescribeResult(options *DescribeOptions, result *describe.Result, log log.Logger) error {
+	if options.Output == "json" {
+		bytes, err := json.MarshalIndent(result, "", "    ")
+		if err != nil {
+			return fmt.Errorf("json marshal result: %w", err)
+		}
+
+		log.WriteString(logrus.InfoLevel, string(bytes)+"\n")
+		return nil
+	}
+
+	virtualName, hostName := "<not found>", "<not found>"
+	if result.Virtual != nil {
+		virtualName = formatObjectName(result.Virtual.GetNamespace(), result.Virtual.GetName())
+	}
+	if result.Host != nil {
+		hostName = formatObjectName(result.Host.GetNamespace(), result.Host.GetName())
+	}
+
+	table.PrintTable(log, []string{"KIND", "VIRTUAL", "HOST"}, [][]string{{result.Kind, virtualName, hostName}})
+	if result.SyncStatus != nil {
+		log.WriteString(logrus.InfoLevel, "\n")
+		values := [][]string{{string(result.SyncStatus.Phase), result.SyncStatus.HostName, fmt.Sprintf("%d", result.SyncStatus.LastSyncedGeneration), result.SyncStatus.LastError}}
+		table.PrintTable(log, []string{"SYNC PHASE", "HOST NAME", "SYNCED GENERATION", "LAST ERROR"}, values)
+	}
+
+	if len(result.Differences) > 0 {
+		log.WriteString(logrus.InfoLevel, "\n")
+		values := [][]string{}
+		for _, diff := range result.Differences {
+			values = append(values, []string{diff.Path, truncateValue(diff.Virtual), truncateValue(diff.Host)})
+		}
+		table.PrintTable(log, []string{"TRANSLATED FIELD", "VIRTUAL", "HOST"}, values)
+	}
+
+	if len(result.HostEvents) > 0 {
+		log.WriteString(logrus.InfoLevel, "\n")
+		values := [][]string{}
+		for _, event := range result.HostEvents {
+			values = append(values, []string{event.Type, event.Reason, duration.HumanDuration(time.Since(describe.EventTime(event))), event.Message})
+		}
+		table.PrintTable(log, []string{"TYPE", "REASON", "AGE", "HOST EVENT"}, values)
+	}
+
+	return nil
+}
+
+func printCollisions(options *DescribeOptions, kind string, collisions []describe.Collision, log log.Logger) error {
+	if options.Output == "json" {
+		bytes, err := json.MarshalIndent(collisions, "", "    ")
+		if err != nil {
+			return fmt.Errorf("json marshal collisions: %w", err)
+		}
+
+		log.WriteString(logrus.InfoLevel, string(bytes)+"\n")
+		return nil
+	} else if len(collisions) == 0 {
+		log.Infof("No %s are translated to the same host name", kind)
+		return nil
+	}
+
+	values := [][]string{}
+	for _, collision := range collisions {
+		values = append(values, []string{kind, collision.HostName, strings.Join(collision.Virtual, ", ")})
+	}
+	table.PrintTable(log, []string{"KIND", "HOST", "VIRTUAL"}, values)
+	return nil
+}
+
+func formatObjectName(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+
+	return namespace + "/" + name
+}
+
+func truncateValue(value string) string {
+	value = strings.ReplaceAll(value, "\n", " ")
+	if len(value) > maxValueLength {
+		return value[:maxValueLength-3] + "..."
+	}
+
+	return value
+}
@@ -20,6 +20,12 @@ func AddCommonFlags(cmd *cobra.Command, options *cli.ConnectOptions) {
 	cmd.Flags().StringVar(&options.ServiceAccount, "service-account", "", "If specified, vCluster will create a service account token to connect to the virtual cluster instead of using the default client cert / key. Service account must exist and can be used as namespace/name.")
 	cmd.Flags().StringVar(&options.ServiceAccountClusterRole, "cluster-role", "", "If specified, vCluster will create the service account if it does not exist and also add a cluster role binding for the given cluster role to it. Requires --service-account to be set")
 	cmd.Flags().IntVar(&options.ServiceAccountExpiration, "token-expiration", 0, "If specified, vCluster will create the service account token for the given duration in seconds. Defaults to eternal")
+	cmd.Flags().BoolVar(&options.OIDC, "oidc", false, "If specified, vCluster will create a kube config that logs in via the OpenID provider configured in controlPlane.auth.oidc instead of using the default client cert / key. Requires the kubelogin kubectl plugin")
+	cmd.Flags().StringVar(&options.User, "user", "", "If specified, vCluster will issue a client certificate for the given user that is signed by the vCluster client CA instead of using the default client cert / key")
+	cmd.Flags().StringSliceVar(&options.Groups, "groups", []string{}, "The groups of the client certificate issued via --user")
+	cmd.Flags().StringSliceVar(&options.NamespaceScope, "namespace-scope", []string{}, "If specified, vCluster will create a role and role binding that grant the user of --user full access to these namespaces within the virtual cluster. The role bindings are not removed when the certificate expires or is revoked")
+	cmd.Flags().DurationVar(&options.TTL, "ttl", cli.DefaultUserTTL, "The validity of the client certificate issued via --user, e.g. 1h")
+	cmd.Flags().BoolVar(&options.AllowLongTTL, "allow-long-ttl", false, "If specified, --ttl may be longer than 24h")
 	cmd.Flags().BoolVar(&options.Insecure, "insecure", false, "If specified, vCluster will create the kube config with insecure-skip-tls-verify")
 	cmd.Flags().BoolVar(&options.BackgroundProxy, "background-proxy", true, "Try to use a background-proxy to access the vCluster. Only works if docker is installed and reachable")
 
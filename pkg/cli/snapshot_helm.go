@@ -0,0 +1,378 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/loft-sh/log"
+	"github.com/loft-sh/vcluster/pkg/cli/find"
+	"github.com/loft-sh/vcluster/pkg/cli/flags"
+	"github.com/loft-sh/vcluster/pkg/lifecycle"
+	"github.com/loft-sh/vcluster/pkg/snapshot"
+	"github.com/loft-sh/vcluster/pkg/util/podhelper"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const snapshotContainer = "syncer"
+
+var snapshotCommand = []string{"/vcluster", "snapshot"}
+
+type SnapshotOptions struct {
+	Storage snapshot.Options
+
+	// Create creates a new vCluster with the create options to restore the snapshot into
+	Create        bool
+	CreateOptions CreateOptions
+}
+
+func SnapshotCreateHelm(ctx context.Context, options *SnapshotOptions, globalFlags *flags.GlobalFlags, vClusterName, location string, log log.Logger) error {
+	storage, err := snapshot.NewStorage(location, &options.Storage)
+	if err != nil {
+		return err
+	}
+
+	vCluster, kubeConfig, pod, err := prepareSnapshot(ctx, globalFlags, vClusterName, log)
+	if err != nil {
+		return err
+	}
+
+	// stream the snapshot from the vCluster pod into the storage
+	reader, writer := io.Pipe()
+	stderr := &bytes.Buffer{}
+	go func() {
+		err := podhelper.ExecStream(ctx, kubeConfig, &podhelper.ExecStreamOptions{
+			Pod:       pod.Name,
+			Namespace: pod.Namespace,
+			Container: snapshotContainer,
+			Command:   append(snapshotCommand, "create"),
+			Stdout:    writer,
+			Stderr:    stderr,
+		})
+		if err != nil {
+			err = fmt.Errorf("create snapshot in pod %s/%s: %w: %s", pod.Namespace, pod.Name, err, strings.TrimSpace(stderr.String()))
+		}
+		_ = writer.CloseWithError(err)
+	}()
+
+	log.Infof("Creating snapshot of vCluster %s/%s to %s...", vCluster.Namespace, vCluster.Name, storage.Target())
+	err = storage.Write(ctx, reader)
+	if err != nil {
+		_ = reader.CloseWithError(err)
+		return err
+	}
+
+	log.Donef("Successfully created snapshot of vCluster %s/%s at %s", vCluster.Namespace, vCluster.Name, storage.Target())
+	return nil
+}
+
+func SnapshotRestoreHelm(ctx context.Context, options *SnapshotOptions, globalFlags *flags.GlobalFlags, vClusterName, location string, log log.Logger) error {
+	storage, err := snapshot.NewStorage(location, &options.Storage)
+	if err != nil {
+		return err
+	}
+
+	// create a new vCluster to restore the snapshot into
+	if options.Create {
+		err = createRestoreTarget(ctx, options, globalFlags, vClusterName, log)
+		if err != nil {
+			return err
+		}
+	}
+
+	vCluster, err := find.GetVCluster(ctx, globalFlags.Context, vClusterName, globalFlags.Namespace, log)
+	if err != nil {
+		return err
+	} else if vCluster.IsSleeping() {
+		return fmt.Errorf("vCluster %s is sleeping, please wake it up first", vCluster.Name)
+	}
+
+	kubeConfig, err := vCluster.ClientFactory.ClientConfig()
+	if err != nil {
+		return fmt.Errorf("there is an error loading your current kube config (%w), please make sure you have access to a kubernetes cluster and the command `kubectl get namespaces` is working", err)
+	}
+	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		return err
+	}
+
+	// the api server and syncer must not run during the restore, otherwise the syncer would act on the
+	// keys that are removed or overwritten, e.g. by deleting the corresponding host pods
+	paused := vCluster.Status == find.StatusPaused
+	if !paused {
+		log.Infof("Stopping vCluster %s/%s...", vCluster.Namespace, vCluster.Name)
+		err = lifecycle.StopControlPlane(ctx, kubeClient, vCluster.Name, vCluster.Namespace, log)
+		if err != nil {
+			return fmt.Errorf("stop vcluster: %w", err)
+		}
+	}
+	err = waitForPodsDeleted(ctx, kubeClient, vCluster.Namespace, "app=vcluster,release="+vCluster.Name)
+	if err != nil {
+		return err
+	}
+
+	reader, err := storage.Read(ctx)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	// stream the snapshot from the storage into a restore pod that only starts the backing store
+	log.Infof("Restoring snapshot %s into vCluster %s/%s...", storage.Target(), vCluster.Namespace, vCluster.Name)
+	err = runRestorePod(ctx, kubeConfig, kubeClient, vCluster, reader)
+	if err != nil {
+		return fmt.Errorf("%w\nvCluster %s/%s stays paused, use `vcluster resume %s -n %s` to start it again", err, vCluster.Namespace, vCluster.Name, vCluster.Name, vCluster.Namespace)
+	}
+
+	if !paused {
+		// a deployed etcd needs to pick up the restored certificates
+		err = lifecycle.DeletePods(ctx, kubeClient, "app=vcluster-etcd,release="+vCluster.Name, vCluster.Namespace, log)
+		if err != nil {
+			return fmt.Errorf("delete vcluster etcd pods: %w", err)
+		}
+
+		log.Infof("Starting vCluster %s/%s...", vCluster.Namespace, vCluster.Name)
+		err = lifecycle.ResumeVCluster(ctx, kubeClient, vCluster.Name, vCluster.Namespace, log)
+		if err != nil {
+			return fmt.Errorf("resume vcluster: %w", err)
+		}
+	}
+
+	log.Donef("Successfully restored snapshot %s into vCluster %s/%s", storage.Target(), vCluster.Namespace, vCluster.Name)
+	return nil
+}
+
+// createRestoreTarget creates the vCluster if it doesn't exist yet and waits until it is running, so that its
+// backing store is initialized before the snapshot is restored
+func createRestoreTarget(ctx context.Context, options *SnapshotOptions, globalFlags *flags.GlobalFlags, vClusterName string, log log.Logger) error {
+	_, err := find.GetVCluster(ctx, globalFlags.Context, vClusterName, globalFlags.Namespace, log)
+	if err == nil {
+		return fmt.Errorf("vcluster %s already exists in namespace %s, please restore without --create", vClusterName, globalFlags.Namespace)
+	} else if !errors.As(err, new(*find.VClusterNotFoundError)) {
+		return err
+	}
+
+	createOptions := options.CreateOptions
+	createOptions.Connect = false
+	createOptions.Print = false
+	err = CreateHelm(ctx, &createOptions, globalFlags, vClusterName, log)
+	if err != nil {
+		return fmt.Errorf("create vcluster: %w", err)
+	}
+
+	log.Infof("Waiting for vCluster %s/%s to come up...", globalFlags.Namespace, vClusterName)
+	return wait.PollUntilContextTimeout(ctx, time.Second*2, time.Minute*5, true, func(ctx context.Context) (bool, error) {
+		vCluster, err := find.GetVCluster(ctx, globalFlags.Context, vClusterName, globalFlags.Namespace, log)
+		if err != nil {
+			return false, nil
+		}
+
+		return vCluster.Status == find.StatusRunning, nil
+	})
+}
+
+// runRestorePod restores the snapshot in a one-off pod built from the vCluster pod template, which mounts the same
+// backing store and only starts it instead of the whole control plane
+func runRestorePod(ctx context.Context, kubeConfig *rest.Config, kubeClient kubernetes.Interface, vCluster *find.VCluster, reader io.Reader) error {
+	pod, err := newRestorePod(ctx, kubeClient, vCluster)
+	if err != nil {
+		return err
+	}
+
+	// remove leftovers of a previous restore
+	err = kubeClient.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{})
+	if err != nil && !kerrors.IsNotFound(err) {
+		return fmt.Errorf("delete restore pod %s/%s: %w", pod.Namespace, pod.Name, err)
+	}
+	err = waitForPodsDeleted(ctx, kubeClient, pod.Namespace, labels.FormatLabels(pod.Labels))
+	if err != nil {
+		return err
+	}
+
+	_, err = kubeClient.CoreV1().Pods(pod.Namespace).Create(ctx, pod, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("create restore pod %s/%s: %w", pod.Namespace, pod.Name, err)
+	}
+	defer func() {
+		_ = kubeClient.CoreV1().Pods(pod.Namespace).Delete(context.WithoutCancel(ctx), pod.Name, metav1.DeleteOptions{})
+	}()
+
+	err = wait.PollUntilContextTimeout(ctx, time.Second, time.Minute*5, true, func(ctx context.Context) (bool, error) {
+		restorePod, err := kubeClient.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+
+		return restorePod.Status.Phase != corev1.PodPending, nil
+	})
+	if err != nil {
+		return fmt.Errorf("wait for restore pod %s/%s: %w", pod.Namespace, pod.Name, err)
+	}
+
+	// the restore command reads the snapshot from stdin until it is closed by the attach
+	wrapper, upgrader, err := podhelper.GetUpgraderWrapper(kubeConfig)
+	if err != nil {
+		return err
+	}
+	attachErr := podhelper.ExecStreamWithTransport(ctx, kubeClient, &podhelper.ExecStreamWithTransportOptions{
+		ExecStreamOptions: podhelper.ExecStreamOptions{
+			Pod:       pod.Name,
+			Namespace: pod.Namespace,
+			Container: snapshotContainer,
+			Stdin:     reader,
+			Stdout:    io.Discard,
+			Stderr:    io.Discard,
+		},
+		Transport:   wrapper,
+		Upgrader:    upgrader,
+		SubResource: podhelper.SubResourceAttach,
+	})
+
+	var phase corev1.PodPhase
+	err = wait.PollUntilContextTimeout(ctx, time.Second, time.Minute*10, true, func(ctx context.Context) (bool, error) {
+		restorePod, err := kubeClient.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+
+		phase = restorePod.Status.Phase
+		return phase == corev1.PodSucceeded || phase == corev1.PodFailed, nil
+	})
+	if err != nil {
+		return fmt.Errorf("wait for restore pod %s/%s: %w", pod.Namespace, pod.Name, err)
+	} else if phase == corev1.PodFailed {
+		logs, _ := kubeClient.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{Container: snapshotContainer}).DoRaw(ctx)
+		return fmt.Errorf("restore snapshot in pod %s/%s failed: %s", pod.Namespace, pod.Name, strings.TrimSpace(string(logs)))
+	} else if attachErr != nil {
+		return fmt.Errorf("attach to restore pod %s/%s: %w", pod.Namespace, pod.Name, attachErr)
+	}
+
+	return nil
+}
+
+func newRestorePod(ctx context.Context, kubeClient kubernetes.Interface, vCluster *find.VCluster) (*corev1.Pod, error) {
+	var podSpec *corev1.PodSpec
+	statefulSet, err := kubeClient.AppsV1().StatefulSets(vCluster.Namespace).Get(ctx, vCluster.Name, metav1.GetOptions{})
+	if err == nil {
+		podSpec = statefulSet.Spec.Template.Spec.DeepCopy()
+
+		// mount the volumes of the first replica, which holds the backing store
+		for _, claim := range statefulSet.Spec.VolumeClaimTemplates {
+			podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+				Name: claim.Name,
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: claim.Name + "-" + statefulSet.Name + "-0",
+					},
+				},
+			})
+		}
+	} else if kerrors.IsNotFound(err) {
+		deployment, err := kubeClient.AppsV1().Deployments(vCluster.Namespace).Get(ctx, vCluster.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("get vcluster deployment %s/%s: %w", vCluster.Namespace, vCluster.Name, err)
+		}
+
+		podSpec = deployment.Spec.Template.Spec.DeepCopy()
+	} else {
+		return nil, fmt.Errorf("get vcluster statefulset %s/%s: %w", vCluster.Namespace, vCluster.Name, err)
+	}
+
+	var container *corev1.Container
+	for i := range podSpec.Containers {
+		if podSpec.Containers[i].Name == snapshotContainer {
+			container = &podSpec.Containers[i]
+			break
+		}
+	}
+	if container == nil {
+		return nil, fmt.Errorf("vcluster %s/%s has no %s container", vCluster.Namespace, vCluster.Name, snapshotContainer)
+	}
+
+	container.Command = append(snapshotCommand, "restore", "--start-backing-store")
+	container.Args = nil
+	container.Stdin = true
+	container.StdinOnce = true
+	container.LivenessProbe = nil
+	container.ReadinessProbe = nil
+	container.StartupProbe = nil
+	podSpec.Containers = []corev1.Container{*container}
+	podSpec.RestartPolicy = corev1.RestartPolicyNever
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      vCluster.Name + "-snapshot-restore",
+			Namespace: vCluster.Namespace,
+			Labels: map[string]string{
+				"app":     "vcluster-snapshot-restore",
+				"release": vCluster.Name,
+			},
+		},
+		Spec: *podSpec,
+	}, nil
+}
+
+func waitForPodsDeleted(ctx context.Context, kubeClient kubernetes.Interface, namespace, labelSelector string) error {
+	err := wait.PollUntilContextTimeout(ctx, time.Second, time.Minute*3, true, func(ctx context.Context) (bool, error) {
+		pods, err := kubeClient.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+		if err != nil {
+			return false, err
+		}
+
+		return len(pods.Items) == 0, nil
+	})
+	if err != nil {
+		return fmt.Errorf("wait for pods %s in namespace %s to be deleted: %w", labelSelector, namespace, err)
+	}
+
+	return nil
+}
+
+func prepareSnapshot(ctx context.Context, globalFlags *flags.GlobalFlags, vClusterName string, log log.Logger) (*find.VCluster, *rest.Config, *corev1.Pod, error) {
+	vCluster, err := find.GetVCluster(ctx, globalFlags.Context, vClusterName, globalFlags.Namespace, log)
+	if err != nil {
+		return nil, nil, nil, err
+	} else if vCluster.IsSleeping() || vCluster.Status == find.StatusPaused {
+		return nil, nil, nil, fmt.Errorf("vCluster %s is paused, please resume it first", vCluster.Name)
+	}
+
+	kubeConfig, err := vCluster.ClientFactory.ClientConfig()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("there is an error loading your current kube config (%w), please make sure you have access to a kubernetes cluster and the command `kubectl get namespaces` is working", err)
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	pods, err := kubeClient.CoreV1().Pods(vCluster.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: "app=vcluster,release=" + vCluster.Name,
+	})
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("list vcluster pods: %w", err)
+	}
+
+	// sort by newest
+	sort.Slice(pods.Items, func(i, j int) bool {
+		return pods.Items[i].CreationTimestamp.Unix() > pods.Items[j].CreationTimestamp.Unix()
+	})
+	for _, pod := range pods.Items {
+		if pod.DeletionTimestamp == nil && find.GetPodStatus(&pod) == "Running" {
+			return vCluster, kubeConfig, &pod, nil
+		}
+	}
+
+	return nil, nil, nil, fmt.Errorf("can't find a running vcluster pod in namespace %s", vCluster.Namespace)
+}
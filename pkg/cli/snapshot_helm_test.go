@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/loft-sh/vcluster/pkg/cli/find"
+	"gotest.tools/v3/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestNewRestorePod(t *testing.T) {
+	probe := &corev1.Probe{ProbeHandler: corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/readyz"}}}
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "vcluster-test"},
+		Spec: appsv1.StatefulSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					ServiceAccountName: "vc-test",
+					Containers: []corev1.Container{
+						{Name: snapshotContainer, Image: "vcluster", Args: []string{"start"}, ReadinessProbe: probe, LivenessProbe: probe},
+						{Name: "plugin", Image: "plugin"},
+					},
+				},
+			},
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{{ObjectMeta: metav1.ObjectMeta{Name: "data"}}},
+		},
+	}
+
+	pod, err := newRestorePod(context.Background(), fake.NewSimpleClientset(statefulSet), &find.VCluster{Name: "test", Namespace: "vcluster-test"})
+	assert.NilError(t, err)
+	assert.Equal(t, pod.Name, "test-snapshot-restore")
+	assert.Equal(t, pod.Labels["app"], "vcluster-snapshot-restore")
+	assert.Equal(t, pod.Spec.ServiceAccountName, "vc-test")
+	assert.Equal(t, pod.Spec.RestartPolicy, corev1.RestartPolicyNever)
+	assert.Equal(t, len(pod.Spec.Containers), 1)
+	container := pod.Spec.Containers[0]
+	assert.DeepEqual(t, container.Command, []string{"/vcluster", "snapshot", "restore", "--start-backing-store"})
+	assert.Assert(t, container.Args == nil)
+	assert.Assert(t, container.Stdin && container.StdinOnce)
+	assert.Assert(t, container.ReadinessProbe == nil && container.LivenessProbe == nil)
+	assert.Equal(t, pod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName, "data-test-0")
+}
@@ -80,6 +80,10 @@ func (v VirtualClusterConfig) VirtualClusterKubeConfig() config.VirtualClusterKu
 			ClientCACert:        "/data/pki/ca.crt",
 			RequestHeaderCACert: "/data/pki/front-proxy-ca.crt",
 		}
+		if v.ControlPlane.Certificates.CA.SecretName != "" {
+			// components authenticate via the internal client CA if the cluster CA is managed externally
+			distroConfig.ClientCACert = "/data/pki/client-ca.crt"
+		}
 	}
 
 	retConfig := v.Experimental.VirtualClusterKubeConfig
@@ -175,7 +179,7 @@ func (v VirtualClusterConfig) LegacyOptions() (*legacyconfig.LegacyVirtualCluste
 		MountPhysicalHostPaths:      false,
 		HostMetricsBindAddress:      "0",
 		VirtualMetricsBindAddress:   "0",
-		MultiNamespaceMode:          v.Experimental.MultiNamespaceMode.Enabled,
+		MultiNamespaceMode:          v.Sync.ToHost.Namespaces.Enabled,
 		SyncAllSecrets:              v.Sync.ToHost.Secrets.All,
 		SyncAllConfigMaps:           v.Sync.ToHost.ConfigMaps.All,
 		ProxyMetricsServer:          v.Integrations.MetricsServer.Enabled,
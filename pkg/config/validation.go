@@ -5,13 +5,20 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"regexp"
 	"slices"
+	"strings"
+	"time"
 
 	"github.com/ghodss/yaml"
 	"github.com/loft-sh/vcluster/config"
+	patchesexpression "github.com/loft-sh/vcluster/pkg/patches/expression"
+	proxyaudit "github.com/loft-sh/vcluster/pkg/server/audit"
 	"github.com/loft-sh/vcluster/pkg/util/toleration"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 var allowedPodSecurityStandards = map[string]bool{
@@ -22,6 +29,8 @@ var allowedPodSecurityStandards = map[string]bool{
 
 var (
 	verbs = []string{"get", "list", "create", "update", "patch", "watch", "delete", "deletecollection"}
+
+	auditStages = []string{"RequestReceived", "ResponseStarted", "ResponseComplete", "Panic"}
 )
 
 func ValidateConfigAndSetDefaults(config *VirtualClusterConfig) error {
@@ -38,6 +47,21 @@ func ValidateConfigAndSetDefaults(config *VirtualClusterConfig) error {
 		}
 	}
 
+	// validate image rules
+	err := validateTranslateImageRules(config.Sync.ToHost.Pods.TranslateImageRules)
+	if err != nil {
+		return err
+	}
+
+	// migrate deprecated multi namespace mode
+	migrateMultiNamespaceMode(config)
+
+	// migrate deprecated deny proxy requests
+	migrateDenyProxyRequests(config)
+
+	// migrate simple generic sync exports
+	migrateGenericSyncExports(config)
+
 	// check if enable scheduler works correctly
 	if config.ControlPlane.Advanced.VirtualScheduler.Enabled && !config.Sync.FromHost.Nodes.Selector.All && len(config.Sync.FromHost.Nodes.Selector.Labels) == 0 {
 		config.Sync.FromHost.Nodes.Selector.All = true
@@ -69,8 +93,50 @@ func ValidateConfigAndSetDefaults(config *VirtualClusterConfig) error {
 		return fmt.Errorf("you cannot enable both sync.fromHost.storageClasses.enabled and sync.toHost.storageClasses.enabled at the same time. Choose only one of them")
 	}
 
+	// validate secret backend
+	err = validateSecretBackend(config.Sync.ToHost.Secrets)
+	if err != nil {
+		return err
+	}
+
+	// validate encryption at rest
+	err = validateEncryption(config.ControlPlane.Encryption)
+	if err != nil {
+		return err
+	}
+
+	// validate oidc authentication
+	err = validateOIDC(config.ControlPlane.Auth.OIDC)
+	if err != nil {
+		return err
+	}
+
+	// validate certificate renewal
+	err = validateCertificatesRenewal(config.ControlPlane.Certificates.Renewal)
+	if err != nil {
+		return err
+	}
+
+	// validate external certificate authorities
+	err = validateCertificateAuthority(config.Distro(), config.ControlPlane.Certificates)
+	if err != nil {
+		return err
+	}
+
+	// validate host gateways
+	err = validateGateways(config.Sync.FromHost.Gateways)
+	if err != nil {
+		return err
+	}
+
+	// validate host resource quotas
+	err = validateResourceQuotas(config.Sync)
+	if err != nil {
+		return err
+	}
+
 	// validate central admission control
-	err := validateCentralAdmissionControl(config)
+	err = validateCentralAdmissionControl(config)
 	if err != nil {
 		return err
 	}
@@ -78,7 +144,19 @@ func ValidateConfigAndSetDefaults(config *VirtualClusterConfig) error {
 	// validate generic sync config
 	err = validateGenericSyncConfig(config.Experimental.GenericSync)
 	if err != nil {
-		return fmt.Errorf("validate experimental.genericSync")
+		return fmt.Errorf("validate experimental.genericSync: %w", err)
+	}
+
+	// validate custom resources
+	err = validateCustomResources(config.Sync, config.Experimental.GenericSync)
+	if err != nil {
+		return err
+	}
+
+	// validate proxy audit
+	err = validateProxyAudit(config.ControlPlane.Proxy.Audit)
+	if err != nil {
+		return fmt.Errorf("validate controlPlane.proxy.audit: %w", err)
 	}
 
 	// validate distro
@@ -92,12 +170,10 @@ func ValidateConfigAndSetDefaults(config *VirtualClusterConfig) error {
 		return err
 	}
 
-	// check deny proxy requests
-	for _, c := range config.Experimental.DenyProxyRequests {
-		err := validateCheck(c)
-		if err != nil {
-			return err
-		}
+	// check proxy request rules
+	err = validateRequestRules(config.ControlPlane.Proxy.RequestRules)
+	if err != nil {
+		return err
 	}
 
 	// check resolve dns
@@ -114,6 +190,52 @@ func ValidateConfigAndSetDefaults(config *VirtualClusterConfig) error {
 	return nil
 }
 
+func validateTranslateImageRules(rules []config.TranslateImageRule) error {
+	for idx, rule := range rules {
+		if rule.Pattern == "" && rule.Regex == "" {
+			return fmt.Errorf("sync.toHost.pods.translateImageRules[%d]: either pattern or regex is required", idx)
+		} else if rule.Pattern != "" && rule.Regex != "" {
+			return fmt.Errorf("sync.toHost.pods.translateImageRules[%d]: pattern and regex cannot be used together", idx)
+		} else if rule.Replacement == "" {
+			return fmt.Errorf("sync.toHost.pods.translateImageRules[%d]: replacement is required", idx)
+		} else if strings.Count(rule.Pattern, "*") > 1 {
+			return fmt.Errorf("sync.toHost.pods.translateImageRules[%d]: pattern can only contain a single *", idx)
+		}
+
+		if rule.Regex != "" {
+			_, err := regexp.Compile(rule.Regex)
+			if err != nil {
+				return fmt.Errorf("sync.toHost.pods.translateImageRules[%d]: invalid regex: %w", idx, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateProxyAudit(audit config.ControlPlaneProxyAudit) error {
+	if !audit.Enabled {
+		return nil
+	}
+
+	if audit.Log.Path == "" && audit.Webhook.Config == "" {
+		return fmt.Errorf("either log.path or webhook.config is required")
+	}
+
+	for _, stage := range audit.Policy.OmitStages {
+		if !slices.Contains(auditStages, stage) {
+			return fmt.Errorf("invalid stage %q in policy.omitStages, must be one of: %s", stage, strings.Join(auditStages, ", "))
+		}
+	}
+
+	_, err := proxyaudit.NewPolicy(audit.Policy)
+	if err != nil {
+		return fmt.Errorf("policy: %w", err)
+	}
+
+	return nil
+}
+
 func validateDistro(config *VirtualClusterConfig) error {
 	enabledDistros := 0
 	if config.Config.ControlPlane.Distro.K3S.Enabled {
@@ -132,6 +254,75 @@ func validateDistro(config *VirtualClusterConfig) error {
 	return nil
 }
 
+func validateCustomResources(sync config.Sync, genericSync config.ExperimentalGenericSync) error {
+	for key, customResource := range sync.ToHost.CustomResources {
+		if !customResource.Enabled {
+			continue
+		}
+
+		err := validateCustomResourceName(key)
+		if err != nil {
+			return fmt.Errorf("sync.toHost.customResources: %w", err)
+		}
+
+		if sync.FromHost.CustomResources[key].Enabled {
+			return fmt.Errorf("you cannot enable both sync.toHost.customResources.%s and sync.fromHost.customResources.%s at the same time. Choose only one of them", key, key)
+		}
+	}
+
+	for key, customResource := range sync.FromHost.CustomResources {
+		if !customResource.Enabled {
+			continue
+		}
+
+		err := validateCustomResourceName(key)
+		if err != nil {
+			return fmt.Errorf("sync.fromHost.customResources: %w", err)
+		}
+	}
+
+	// the same resource can't be synced by both the custom resource and the generic syncers
+	for idx, exp := range genericSync.Exports {
+		if exp == nil {
+			continue
+		}
+
+		key := genericSyncResourceName(exp.TypeInformation)
+		if sync.ToHost.CustomResources[key].Enabled || sync.FromHost.CustomResources[key].Enabled {
+			return fmt.Errorf("experimental.genericSync.export[%d] syncs %s (%s) which is also configured in sync.toHost.customResources or sync.fromHost.customResources. Choose only one of them", idx, exp.Kind, exp.APIVersion)
+		}
+	}
+	for idx, imp := range genericSync.Imports {
+		if imp == nil {
+			continue
+		}
+
+		key := genericSyncResourceName(imp.TypeInformation)
+		if sync.ToHost.CustomResources[key].Enabled || sync.FromHost.CustomResources[key].Enabled {
+			return fmt.Errorf("experimental.genericSync.import[%d] syncs %s (%s) which is also configured in sync.toHost.customResources or sync.fromHost.customResources. Choose only one of them", idx, imp.Kind, imp.APIVersion)
+		}
+	}
+
+	return nil
+}
+
+// genericSyncResourceName returns the custom resource name in the form resource.group for the given
+// generic sync type. The resource is guessed from the kind, as custom resources are usually named that way.
+func genericSyncResourceName(typeInformation config.TypeInformation) string {
+	gvk := schema.FromAPIVersionAndKind(typeInformation.APIVersion, typeInformation.Kind)
+	plural, _ := meta.UnsafeGuessKindToResource(gvk)
+	return plural.Resource + "." + plural.Group
+}
+
+func validateCustomResourceName(name string) error {
+	resource, group, found := strings.Cut(name, ".")
+	if !found || resource == "" || group == "" {
+		return fmt.Errorf("invalid custom resource %q, expected the form resource.group, e.g. certificates.cert-manager.io", name)
+	}
+
+	return nil
+}
+
 func validateGenericSyncConfig(config config.ExperimentalGenericSync) error {
 	err := validateExportDuplicates(config.Exports)
 	if err != nil {
@@ -235,6 +426,30 @@ func validateGenericSyncConfig(config config.ExperimentalGenericSync) error {
 }
 
 func validatePatch(patch *config.Patch) error {
+	for _, condition := range patch.Conditions {
+		if condition == nil || condition.Expression == "" {
+			continue
+		}
+
+		_, err := patchesexpression.Compile(condition.Expression)
+		if err != nil {
+			return fmt.Errorf("invalid condition: %w", err)
+		}
+	}
+
+	if patch.Expression != "" {
+		if patch.Operation != config.PatchTypeReplace && patch.Operation != config.PatchTypeAdd {
+			return fmt.Errorf("expression is not supported for operation %s", patch.Operation)
+		} else if patch.Value != nil {
+			return fmt.Errorf("value and expression cannot be used together")
+		}
+
+		_, err := patchesexpression.Compile(patch.Expression)
+		if err != nil {
+			return err
+		}
+	}
+
 	switch patch.Operation {
 	case config.PatchTypeRemove, config.PatchTypeReplace, config.PatchTypeAdd:
 		if patch.FromPath != "" {
@@ -374,40 +589,57 @@ func validateWebhookClientCfg(clientCfg admissionregistrationv1.WebhookClientCon
 	return nil
 }
 
-func validateCheck(check config.DenyRule) error {
-	for _, ns := range check.Namespaces {
-		errors := validation.ValidateNamespaceName(ns, false)
-		if len(errors) != 0 {
-			return fmt.Errorf("invalid Namespaces in %q check: %v", check.Name, errors)
-		}
-	}
-	var err error
-	for _, r := range check.Rules {
-		err = validateWildcardOrExact(r.Verbs, "create", "get", "update", "patch", "delete")
-		if err != nil {
-			return fmt.Errorf("invalid Verb defined in the %q check: %w", check.Name, err)
-		}
+func validateRequestRules(requestRules config.ControlPlaneProxyRequestRules) error {
+	names := map[string]bool{}
+	lists := []struct {
+		name  string
+		rules []config.DenyRule
+	}{{"deny", requestRules.Deny}, {"allow", requestRules.Allow}}
+	for _, list := range lists {
+		for idx, rule := range list.rules {
+			if rule.Name == "" {
+				return fmt.Errorf("controlPlane.proxy.requestRules.%s[%d]: name is required", list.name, idx)
+			} else if names[rule.Name] {
+				return fmt.Errorf("controlPlane.proxy.requestRules.%s[%d]: name %q is used by multiple rules", list.name, idx, rule.Name)
+			}
+			names[rule.Name] = true
 
-		err = validateWildcardOrAny(r.APIGroups)
-		if err != nil {
-			return fmt.Errorf("invalid APIGroup defined in the %q check: %w", check.Name, err)
-		}
+			for _, ns := range rule.Namespaces {
+				errors := validation.ValidateNamespaceName(ns, false)
+				if len(errors) != 0 {
+					return fmt.Errorf("invalid Namespaces in %q rule: %v", rule.Name, errors)
+				}
+			}
 
-		err = validateWildcardOrAny(r.APIVersions)
-		if err != nil {
-			return fmt.Errorf("invalid APIVersion defined in the %q check: %w", check.Name, err)
-		}
+			for _, r := range rule.Rules {
+				err := validateWildcardOrExact(r.Verbs, "get", "list", "watch", "create", "update", "patch", "delete", "deletecollection")
+				if err != nil {
+					return fmt.Errorf("invalid Verb defined in the %q rule: %w", rule.Name, err)
+				}
+
+				err = validateWildcardOrAny(r.APIGroups)
+				if err != nil {
+					return fmt.Errorf("invalid APIGroup defined in the %q rule: %w", rule.Name, err)
+				}
+
+				err = validateWildcardOrAny(r.APIVersions)
+				if err != nil {
+					return fmt.Errorf("invalid APIVersion defined in the %q rule: %w", rule.Name, err)
+				}
 
-		if r.Scope != nil {
-			switch *r.Scope {
-			case string(admissionregistrationv1.ClusterScope):
-			case string(admissionregistrationv1.NamespacedScope):
-			case string(admissionregistrationv1.AllScopes):
-			default:
-				return fmt.Errorf("invalid Scope defined in the %q check: %q", check.Name, *r.Scope)
+				if r.Scope != nil {
+					switch *r.Scope {
+					case string(admissionregistrationv1.ClusterScope):
+					case string(admissionregistrationv1.NamespacedScope):
+					case string(admissionregistrationv1.AllScopes):
+					default:
+						return fmt.Errorf("invalid Scope defined in the %q rule: %q", rule.Name, *r.Scope)
+					}
+				}
 			}
 		}
 	}
+
 	return nil
 }
 
@@ -458,3 +690,255 @@ func validateK0sAndNoExperimentalKubeconfig(c *VirtualClusterConfig) error {
 	}
 	return nil
 }
+
+// migrateMultiNamespaceMode moves the deprecated experimental.multiNamespaceMode options to sync.toHost.namespaces
+func migrateMultiNamespaceMode(config *VirtualClusterConfig) {
+	if !config.Experimental.MultiNamespaceMode.Enabled {
+		return
+	}
+
+	config.Sync.ToHost.Namespaces.Enabled = true
+	for k, v := range config.Experimental.MultiNamespaceMode.NamespaceLabels {
+		if config.Sync.ToHost.Namespaces.Labels == nil {
+			config.Sync.ToHost.Namespaces.Labels = map[string]string{}
+		}
+		if _, ok := config.Sync.ToHost.Namespaces.Labels[k]; !ok {
+			config.Sync.ToHost.Namespaces.Labels[k] = v
+		}
+	}
+}
+
+// migrateDenyProxyRequests moves the deprecated experimental.denyProxyRequests rules to controlPlane.proxy.requestRules.deny
+func migrateDenyProxyRequests(config *VirtualClusterConfig) {
+	for idx, rule := range config.Experimental.DenyProxyRequests {
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("deny-proxy-requests-%d", idx)
+		}
+
+		config.ControlPlane.Proxy.RequestRules.Deny = append(config.ControlPlane.Proxy.RequestRules.Deny, rule)
+	}
+
+	config.Experimental.DenyProxyRequests = nil
+}
+
+// migrateGenericSyncExports moves deprecated experimental.genericSync exports without any patches, selectors or hooks
+// to sync.toHost.customResources, which syncs them the same way. Exports that use any other option are kept as is.
+func migrateGenericSyncExports(vConfig *VirtualClusterConfig) {
+	genericSync := &vConfig.Experimental.GenericSync
+	exports := make([]*config.Export, 0, len(genericSync.Exports))
+	for _, exp := range genericSync.Exports {
+		if !isSimpleGenericSyncExport(exp, genericSync.Hooks) {
+			exports = append(exports, exp)
+			continue
+		}
+
+		// keep the export if the resource is already configured, so validation reports the conflict
+		key := genericSyncResourceName(exp.TypeInformation)
+		if _, ok := vConfig.Sync.ToHost.CustomResources[key]; ok {
+			exports = append(exports, exp)
+			continue
+		} else if _, ok := vConfig.Sync.FromHost.CustomResources[key]; ok {
+			exports = append(exports, exp)
+			continue
+		}
+
+		if vConfig.Sync.ToHost.CustomResources == nil {
+			vConfig.Sync.ToHost.CustomResources = map[string]config.SyncToHostCustomResource{}
+		}
+		vConfig.Sync.ToHost.CustomResources[key] = config.SyncToHostCustomResource{Enabled: true}
+	}
+
+	genericSync.Exports = exports
+}
+
+func isSimpleGenericSyncExport(exp *config.Export, hooks *config.Hooks) bool {
+	if exp == nil || exp.Kind == "" || exp.Optional || exp.ReplaceWhenInvalid || len(exp.Patches) > 0 || len(exp.ReversePatches) > 0 {
+		return false
+	} else if exp.Selector != nil && len(exp.Selector.LabelSelector) > 0 {
+		return false
+	}
+
+	// core resources are not custom resources
+	gv, err := schema.ParseGroupVersion(exp.APIVersion)
+	if err != nil || gv.Group == "" {
+		return false
+	}
+
+	if hooks != nil {
+		for _, hook := range append(slices.Clone(hooks.HostToVirtual), hooks.VirtualToHost...) {
+			if hook != nil && hook.TypeInformation == exp.TypeInformation {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func validateGateways(gateways config.SyncFromHostGateways) error {
+	if !gateways.Enabled {
+		return nil
+	}
+
+	if len(gateways.Namespaces) == 0 {
+		return fmt.Errorf("sync.fromHost.gateways.namespaces is required if sync.fromHost.gateways.enabled is true")
+	}
+	for _, namespace := range gateways.Namespaces {
+		if errs := validation.ValidateNamespaceName(namespace, false); len(errs) > 0 {
+			return fmt.Errorf("invalid sync.fromHost.gateways.namespaces entry %q: %s", namespace, strings.Join(errs, ", "))
+		}
+	}
+
+	return nil
+}
+
+func validateResourceQuotas(sync config.Sync) error {
+	if !sync.FromHost.ResourceQuotas.Enabled {
+		return nil
+	}
+
+	if sync.ToHost.Namespaces.Enabled {
+		return fmt.Errorf("sync.fromHost.resourceQuotas cannot be used together with sync.toHost.namespaces, because there is no single host namespace")
+	} else if sync.FromHost.ResourceQuotas.Namespace == "" {
+		return fmt.Errorf("sync.fromHost.resourceQuotas.namespace is required if sync.fromHost.resourceQuotas.enabled is true")
+	} else if errs := validation.ValidateNamespaceName(sync.FromHost.ResourceQuotas.Namespace, false); len(errs) > 0 {
+		return fmt.Errorf("invalid sync.fromHost.resourceQuotas.namespace %q: %s", sync.FromHost.ResourceQuotas.Namespace, strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+func validateSecretBackend(secrets config.SyncSecrets) error {
+	switch secrets.Backend.Type {
+	case "", config.SecretBackendTypeHost:
+		return nil
+	case config.SecretBackendTypeCSI, config.SecretBackendTypeKMS:
+		if secrets.All {
+			return fmt.Errorf("sync.toHost.secrets.all cannot be used together with secret backend %q", secrets.Backend.Type)
+		} else if secrets.Backend.CSI.Driver == "" {
+			return fmt.Errorf("sync.toHost.secrets.backend.csi.driver is required for secret backend %q", secrets.Backend.Type)
+		} else if secrets.Backend.CSI.SecretProviderClass == "" {
+			return fmt.Errorf("sync.toHost.secrets.backend.csi.secretProviderClass is required for secret backend %q", secrets.Backend.Type)
+		}
+		if secrets.Backend.Type != config.SecretBackendTypeKMS {
+			return nil
+		}
+
+		if secrets.Backend.KMS.Name == "" {
+			return fmt.Errorf("sync.toHost.secrets.backend.kms.name is required for secret backend %q", config.SecretBackendTypeKMS)
+		} else if !strings.HasPrefix(secrets.Backend.KMS.Endpoint, "unix://") {
+			return fmt.Errorf("sync.toHost.secrets.backend.kms.endpoint needs to be a unix socket starting with unix://, got %q", secrets.Backend.KMS.Endpoint)
+		} else if secrets.Backend.KMS.Timeout != "" {
+			if _, err := time.ParseDuration(secrets.Backend.KMS.Timeout); err != nil {
+				return fmt.Errorf("invalid sync.toHost.secrets.backend.kms.timeout %q: %w", secrets.Backend.KMS.Timeout, err)
+			}
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("invalid sync.toHost.secrets.backend.type %q, must be one of: %s, %s, %s", secrets.Backend.Type, config.SecretBackendTypeHost, config.SecretBackendTypeCSI, config.SecretBackendTypeKMS)
+}
+
+func validateEncryption(encryption config.ControlPlaneEncryption) error {
+	if !encryption.Enabled {
+		return nil
+	}
+
+	for _, resource := range encryption.Resources {
+		if strings.Contains(resource, "*") {
+			return fmt.Errorf("wildcards are not supported in controlPlane.encryption.resources, got %q", resource)
+		}
+	}
+
+	switch encryption.Provider {
+	case "", config.EncryptionProviderAESCBC, config.EncryptionProviderSecretbox:
+		if encryption.KeyRotationPeriod != "" {
+			if _, err := time.ParseDuration(encryption.KeyRotationPeriod); err != nil {
+				return fmt.Errorf("invalid controlPlane.encryption.keyRotationPeriod %q: %w", encryption.KeyRotationPeriod, err)
+			}
+		}
+
+		return nil
+	case config.EncryptionProviderKMS:
+		if encryption.KMS.Name == "" {
+			return fmt.Errorf("controlPlane.encryption.kms.name is required for provider %q", config.EncryptionProviderKMS)
+		} else if !strings.HasPrefix(encryption.KMS.Endpoint, "unix://") {
+			return fmt.Errorf("controlPlane.encryption.kms.endpoint needs to be a unix socket starting with unix://, got %q", encryption.KMS.Endpoint)
+		} else if encryption.KMS.Timeout != "" {
+			if _, err := time.ParseDuration(encryption.KMS.Timeout); err != nil {
+				return fmt.Errorf("invalid controlPlane.encryption.kms.timeout %q: %w", encryption.KMS.Timeout, err)
+			}
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("invalid controlPlane.encryption.provider %q, must be one of: %s, %s, %s", encryption.Provider, config.EncryptionProviderAESCBC, config.EncryptionProviderSecretbox, config.EncryptionProviderKMS)
+}
+
+func validateCertificatesRenewal(renewal config.ControlPlaneCertificatesRenewal) error {
+	if !renewal.Enabled {
+		return nil
+	}
+
+	renewBefore, err := time.ParseDuration(renewal.RenewBefore)
+	if err != nil {
+		return fmt.Errorf("invalid controlPlane.certificates.renewal.renewBefore %q: %w", renewal.RenewBefore, err)
+	} else if renewBefore <= 0 {
+		return fmt.Errorf("controlPlane.certificates.renewal.renewBefore needs to be greater than zero")
+	}
+	if renewal.CATrustPeriod != "" {
+		if _, err := time.ParseDuration(renewal.CATrustPeriod); err != nil {
+			return fmt.Errorf("invalid controlPlane.certificates.renewal.caTrustPeriod %q: %w", renewal.CATrustPeriod, err)
+		}
+	}
+
+	return nil
+}
+
+func validateCertificateAuthority(distro string, certificates config.ControlPlaneCertificates) error {
+	if certificates.CA.SecretName == "" && !certificates.CertManager.Enabled {
+		return nil
+	} else if distro != config.K8SDistro {
+		return fmt.Errorf("controlPlane.certificates.ca and controlPlane.certificates.certManager are only supported for the k8s distro")
+	}
+
+	if certificates.CA.SecretName != "" && certificates.Renewal.RotateCA {
+		return fmt.Errorf("controlPlane.certificates.renewal.rotateCA cannot be used with an external CA, please rotate the CA within secret %s instead", certificates.CA.SecretName)
+	}
+	if certificates.CertManager.Enabled && certificates.CertManager.IssuerRef.Name == "" {
+		return fmt.Errorf("controlPlane.certificates.certManager.issuerRef.name is required if controlPlane.certificates.certManager.enabled is true")
+	}
+
+	return nil
+}
+
+func validateOIDC(oidc config.ControlPlaneAuthOIDC) error {
+	if !oidc.Enabled {
+		return nil
+	}
+
+	issuerURL, err := url.Parse(oidc.IssuerURL)
+	if err != nil || issuerURL.Scheme != "https" || issuerURL.Host == "" {
+		return fmt.Errorf("controlPlane.auth.oidc.issuerURL %q needs to be a valid https url", oidc.IssuerURL)
+	}
+	if oidc.ClientID == "" {
+		return fmt.Errorf("controlPlane.auth.oidc.clientID is required if controlPlane.auth.oidc.enabled is true")
+	}
+	if oidc.CA != "" && !x509.NewCertPool().AppendCertsFromPEM([]byte(oidc.CA)) {
+		return fmt.Errorf("controlPlane.auth.oidc.ca is not a valid PEM encoded certificate")
+	}
+	for claim := range oidc.RequiredClaims {
+		if claim == "" || strings.ContainsAny(claim, "=,") {
+			return fmt.Errorf("invalid controlPlane.auth.oidc.requiredClaims key %q", claim)
+		}
+	}
+	for _, value := range oidc.RequiredClaims {
+		if strings.Contains(value, ",") {
+			return fmt.Errorf("invalid controlPlane.auth.oidc.requiredClaims value %q, must not contain ','", value)
+		}
+	}
+
+	return nil
+}
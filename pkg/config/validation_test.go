@@ -1,6 +1,7 @@
 package config
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/loft-sh/vcluster/config"
@@ -125,3 +126,716 @@ func mutHook(clientCfg config.ValidatingWebhookClientConfig) config.MutatingWebh
 	}
 	return hook
 }
+
+func TestValidateCustomResources(t *testing.T) {
+	testCases := []struct {
+		name        string
+		toHost      map[string]config.SyncToHostCustomResource
+		fromHost    map[string]config.SyncFromHostCustomResource
+		genericSync config.ExperimentalGenericSync
+		wantErr     string
+	}{
+		{
+			name:     "valid",
+			toHost:   map[string]config.SyncToHostCustomResource{"certificates.cert-manager.io": {Enabled: true}},
+			fromHost: map[string]config.SyncFromHostCustomResource{"clusterissuers.cert-manager.io": {Enabled: true}},
+		},
+		{
+			name:    "missing group",
+			toHost:  map[string]config.SyncToHostCustomResource{"certificates": {Enabled: true}},
+			wantErr: `sync.toHost.customResources: invalid custom resource "certificates", expected the form resource.group, e.g. certificates.cert-manager.io`,
+		},
+		{
+			name:     "invalid disabled",
+			fromHost: map[string]config.SyncFromHostCustomResource{".cert-manager.io": {}},
+		},
+		{
+			name:     "both directions",
+			toHost:   map[string]config.SyncToHostCustomResource{"certificates.cert-manager.io": {Enabled: true}},
+			fromHost: map[string]config.SyncFromHostCustomResource{"certificates.cert-manager.io": {Enabled: true}},
+			wantErr:  "you cannot enable both sync.toHost.customResources.certificates.cert-manager.io and sync.fromHost.customResources.certificates.cert-manager.io at the same time. Choose only one of them",
+		},
+		{
+			name:   "generic sync export",
+			toHost: map[string]config.SyncToHostCustomResource{"certificates.cert-manager.io": {Enabled: true}},
+			genericSync: config.ExperimentalGenericSync{
+				Exports: []*config.Export{{SyncBase: config.SyncBase{TypeInformation: config.TypeInformation{APIVersion: "cert-manager.io/v1", Kind: "Certificate"}}}},
+			},
+			wantErr: "experimental.genericSync.export[0] syncs Certificate (cert-manager.io/v1) which is also configured in sync.toHost.customResources or sync.fromHost.customResources. Choose only one of them",
+		},
+		{
+			name:     "generic sync import",
+			fromHost: map[string]config.SyncFromHostCustomResource{"clusterissuers.cert-manager.io": {Enabled: true}},
+			genericSync: config.ExperimentalGenericSync{
+				Imports: []*config.Import{{SyncBase: config.SyncBase{TypeInformation: config.TypeInformation{APIVersion: "cert-manager.io/v1", Kind: "ClusterIssuer"}}}},
+			},
+			wantErr: "experimental.genericSync.import[0] syncs ClusterIssuer (cert-manager.io/v1) which is also configured in sync.toHost.customResources or sync.fromHost.customResources. Choose only one of them",
+		},
+		{
+			name:   "generic sync other resource",
+			toHost: map[string]config.SyncToHostCustomResource{"certificates.cert-manager.io": {Enabled: true}},
+			genericSync: config.ExperimentalGenericSync{
+				Exports: []*config.Export{{SyncBase: config.SyncBase{TypeInformation: config.TypeInformation{APIVersion: "cert-manager.io/v1", Kind: "Issuer"}}}},
+			},
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			sync := config.Sync{}
+			sync.ToHost.CustomResources = tt.toHost
+			sync.FromHost.CustomResources = tt.fromHost
+
+			err := validateCustomResources(sync, tt.genericSync)
+			if err != nil && (tt.wantErr == "" || tt.wantErr != err.Error()) {
+				t.Errorf("wanted err to be %s but got %s", tt.wantErr, err.Error())
+			} else if err == nil && tt.wantErr != "" {
+				t.Errorf("wanted err to be %s but got nil", tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidatePatchExpression(t *testing.T) {
+	testCases := []struct {
+		name    string
+		patch   *config.Patch
+		wantErr string
+	}{
+		{
+			name: "valid",
+			patch: &config.Patch{
+				Operation:  config.PatchTypeReplace,
+				Path:       "metadata.name",
+				Expression: "'prefix-' + object.metadata.name",
+				Conditions: []*config.PatchCondition{{Expression: "object.spec.replicas > 3"}},
+			},
+		},
+		{
+			name: "unsupported operation",
+			patch: &config.Patch{
+				Operation:  config.PatchTypeRemove,
+				Path:       "metadata.name",
+				Expression: "object.metadata.name",
+			},
+			wantErr: "expression is not supported for operation remove",
+		},
+		{
+			name: "value and expression",
+			patch: &config.Patch{
+				Operation:  config.PatchTypeAdd,
+				Path:       "metadata.name",
+				Value:      "test",
+				Expression: "object.metadata.name",
+			},
+			wantErr: "value and expression cannot be used together",
+		},
+		{
+			name: "invalid condition",
+			patch: &config.Patch{
+				Operation:  config.PatchTypeRemove,
+				Path:       "metadata.name",
+				Conditions: []*config.PatchCondition{{Expression: "object.spec.replicas >"}},
+			},
+			wantErr: "invalid condition: compile expression",
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePatch(tt.patch)
+			if err != nil && (tt.wantErr == "" || !strings.HasPrefix(err.Error(), tt.wantErr)) {
+				t.Errorf("wanted err to be %s but got %s", tt.wantErr, err.Error())
+			} else if err == nil && tt.wantErr != "" {
+				t.Errorf("wanted err to be %s but got nil", tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateProxyAudit(t *testing.T) {
+	testCases := []struct {
+		name    string
+		audit   config.ControlPlaneProxyAudit
+		wantErr string
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name: "valid",
+			audit: config.ControlPlaneProxyAudit{
+				Enabled: true,
+				Policy: config.ControlPlaneProxyAuditPolicy{
+					OmitStages: []string{"RequestReceived"},
+					Rules: []map[string]interface{}{
+						{"level": "RequestResponse", "resources": []interface{}{map[string]interface{}{"group": "", "resources": []interface{}{"pods/exec"}}}},
+					},
+				},
+				Log: config.ControlPlaneProxyAuditLog{Path: "-"},
+			},
+		},
+		{
+			name:    "missing backend",
+			audit:   config.ControlPlaneProxyAudit{Enabled: true},
+			wantErr: "either log.path or webhook.config is required",
+		},
+		{
+			name: "invalid stage",
+			audit: config.ControlPlaneProxyAudit{
+				Enabled: true,
+				Policy:  config.ControlPlaneProxyAuditPolicy{OmitStages: []string{"Started"}},
+				Webhook: config.ControlPlaneProxyAuditWebhook{Config: "apiVersion: v1"},
+			},
+			wantErr: `invalid stage "Started" in policy.omitStages`,
+		},
+		{
+			name: "invalid rule",
+			audit: config.ControlPlaneProxyAudit{
+				Enabled: true,
+				Policy: config.ControlPlaneProxyAuditPolicy{
+					Rules: []map[string]interface{}{{"level": "Metadata", "verb": "get"}},
+				},
+				Log: config.ControlPlaneProxyAuditLog{Path: "-"},
+			},
+			wantErr: "policy: decode rules",
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateProxyAudit(tt.audit)
+			if err != nil && (tt.wantErr == "" || !strings.HasPrefix(err.Error(), tt.wantErr)) {
+				t.Errorf("wanted err to be %s but got %s", tt.wantErr, err.Error())
+			} else if err == nil && tt.wantErr != "" {
+				t.Errorf("wanted err to be %s but got nil", tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateRequestRules(t *testing.T) {
+	testCases := []struct {
+		name         string
+		requestRules config.ControlPlaneProxyRequestRules
+		wantErr      string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "valid",
+			requestRules: config.ControlPlaneProxyRequestRules{
+				Deny: []config.DenyRule{{
+					Name:       "no-exec",
+					Namespaces: []string{"default"},
+					Rules:      []config.RuleWithVerbs{{APIGroups: []string{""}, Resources: []string{"pods/exec"}, Verbs: []string{"*"}}},
+				}},
+				Allow: []config.DenyRule{{Name: "devs", Groups: []string{"dev"}}},
+			},
+		},
+		{
+			name: "missing name",
+			requestRules: config.ControlPlaneProxyRequestRules{
+				Allow: []config.DenyRule{{Users: []string{"alice"}}},
+			},
+			wantErr: "controlPlane.proxy.requestRules.allow[0]: name is required",
+		},
+		{
+			name: "duplicate name",
+			requestRules: config.ControlPlaneProxyRequestRules{
+				Deny:  []config.DenyRule{{Name: "rule"}},
+				Allow: []config.DenyRule{{Name: "rule"}},
+			},
+			wantErr: `controlPlane.proxy.requestRules.allow[0]: name "rule" is used by multiple rules`,
+		},
+		{
+			name: "invalid verb",
+			requestRules: config.ControlPlaneProxyRequestRules{
+				Deny: []config.DenyRule{{Name: "rule", Rules: []config.RuleWithVerbs{{Verbs: []string{"exec"}}}}},
+			},
+			wantErr: `invalid Verb defined in the "rule" rule`,
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateRequestRules(tt.requestRules)
+			if err != nil && (tt.wantErr == "" || !strings.HasPrefix(err.Error(), tt.wantErr)) {
+				t.Errorf("wanted err to be %s but got %s", tt.wantErr, err.Error())
+			} else if err == nil && tt.wantErr != "" {
+				t.Errorf("wanted err to be %s but got nil", tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateTranslateImageRules(t *testing.T) {
+	testCases := []struct {
+		name    string
+		rules   []config.TranslateImageRule
+		wantErr string
+	}{
+		{
+			name: "valid",
+			rules: []config.TranslateImageRule{
+				{Pattern: "docker.io/*", Replacement: "mirror.corp/dockerhub/*"},
+				{Regex: `ghcr\.io/(.+)`, Replacement: "mirror.corp/ghcr/$1", Namespaces: []string{"test"}},
+			},
+		},
+		{
+			name:    "missing pattern",
+			rules:   []config.TranslateImageRule{{Replacement: "nginx"}},
+			wantErr: "sync.toHost.pods.translateImageRules[0]: either pattern or regex is required",
+		},
+		{
+			name:    "pattern and regex",
+			rules:   []config.TranslateImageRule{{Pattern: "nginx", Regex: "nginx", Replacement: "nginx"}},
+			wantErr: "sync.toHost.pods.translateImageRules[0]: pattern and regex cannot be used together",
+		},
+		{
+			name:    "multiple wildcards",
+			rules:   []config.TranslateImageRule{{Pattern: "docker.io/*:*", Replacement: "mirror.corp/*"}},
+			wantErr: "sync.toHost.pods.translateImageRules[0]: pattern can only contain a single *",
+		},
+		{
+			name:    "invalid regex",
+			rules:   []config.TranslateImageRule{{Regex: "ghcr.io/(", Replacement: "mirror.corp"}},
+			wantErr: "sync.toHost.pods.translateImageRules[0]: invalid regex",
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTranslateImageRules(tt.rules)
+			if err != nil && (tt.wantErr == "" || !strings.HasPrefix(err.Error(), tt.wantErr)) {
+				t.Errorf("wanted err to be %s but got %s", tt.wantErr, err.Error())
+			} else if err == nil && tt.wantErr != "" {
+				t.Errorf("wanted err to be %s but got nil", tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateGateways(t *testing.T) {
+	testCases := []struct {
+		name     string
+		gateways config.SyncFromHostGateways
+		wantErr  string
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name:     "enabled",
+			gateways: config.SyncFromHostGateways{Enabled: true, Namespaces: []string{"gateway-system"}},
+		},
+		{
+			name:     "missing namespaces",
+			gateways: config.SyncFromHostGateways{Enabled: true},
+			wantErr:  "sync.fromHost.gateways.namespaces is required",
+		},
+		{
+			name:     "invalid namespace",
+			gateways: config.SyncFromHostGateways{Enabled: true, Namespaces: []string{"Gateways"}},
+			wantErr:  "invalid sync.fromHost.gateways.namespaces entry",
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateGateways(tt.gateways)
+			if err != nil && (tt.wantErr == "" || !strings.HasPrefix(err.Error(), tt.wantErr)) {
+				t.Errorf("wanted err to be %s but got %s", tt.wantErr, err.Error())
+			} else if err == nil && tt.wantErr != "" {
+				t.Errorf("wanted err to be %s but got nil", tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateResourceQuotas(t *testing.T) {
+	resourceQuotas := config.SyncFromHostResourceQuotas{Enabled: true, Namespace: "vcluster-quotas"}
+
+	testCases := []struct {
+		name    string
+		sync    config.Sync
+		wantErr string
+	}{
+		{
+			name: "disabled",
+			sync: config.Sync{ToHost: config.SyncToHost{Namespaces: config.SyncToHostNamespaces{Enabled: true}}},
+		},
+		{
+			name: "enabled",
+			sync: config.Sync{FromHost: config.SyncFromHost{ResourceQuotas: resourceQuotas}},
+		},
+		{
+			name: "multi namespace mode",
+			sync: config.Sync{
+				ToHost:   config.SyncToHost{Namespaces: config.SyncToHostNamespaces{Enabled: true}},
+				FromHost: config.SyncFromHost{ResourceQuotas: resourceQuotas},
+			},
+			wantErr: "sync.fromHost.resourceQuotas cannot be used together with sync.toHost.namespaces",
+		},
+		{
+			name:    "invalid namespace",
+			sync:    config.Sync{FromHost: config.SyncFromHost{ResourceQuotas: config.SyncFromHostResourceQuotas{Enabled: true, Namespace: "Quotas"}}},
+			wantErr: "invalid sync.fromHost.resourceQuotas.namespace",
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateResourceQuotas(tt.sync)
+			if err != nil && (tt.wantErr == "" || !strings.HasPrefix(err.Error(), tt.wantErr)) {
+				t.Errorf("wanted err to be %s but got %s", tt.wantErr, err.Error())
+			} else if err == nil && tt.wantErr != "" {
+				t.Errorf("wanted err to be %s but got nil", tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateSecretBackend(t *testing.T) {
+	csiBackend := config.SecretBackend{
+		Type: config.SecretBackendTypeCSI,
+		CSI: config.SecretBackendCSI{
+			Driver:              "secrets-store.csi.k8s.io",
+			SecretProviderClass: "vcluster-secrets",
+		},
+	}
+	kmsBackend := config.SecretBackend{
+		Type: config.SecretBackendTypeKMS,
+		CSI:  csiBackend.CSI,
+		KMS: config.ControlPlaneEncryptionKMS{
+			Name:     "vault",
+			Endpoint: "unix:///var/run/kms-plugin/socket.sock",
+		},
+	}
+	kmsInvalidEndpoint := kmsBackend
+	kmsInvalidEndpoint.KMS.Endpoint = "/var/run/kms-plugin/socket.sock"
+
+	testCases := []struct {
+		name    string
+		secrets config.SyncSecrets
+		wantErr string
+	}{
+		{
+			name:    "default",
+			secrets: config.SyncSecrets{Enabled: true},
+		},
+		{
+			name:    "csi",
+			secrets: config.SyncSecrets{Enabled: true, Backend: csiBackend},
+		},
+		{
+			name:    "unknown type",
+			secrets: config.SyncSecrets{Enabled: true, Backend: config.SecretBackend{Type: "vault"}},
+			wantErr: "invalid sync.toHost.secrets.backend.type",
+		},
+		{
+			name:    "csi with all",
+			secrets: config.SyncSecrets{Enabled: true, All: true, Backend: csiBackend},
+			wantErr: "sync.toHost.secrets.all cannot be used together with secret backend",
+		},
+		{
+			name: "csi without secret provider class",
+			secrets: config.SyncSecrets{Enabled: true, Backend: config.SecretBackend{
+				Type: config.SecretBackendTypeCSI,
+				CSI:  config.SecretBackendCSI{Driver: "secrets-store.csi.k8s.io"},
+			}},
+			wantErr: "sync.toHost.secrets.backend.csi.secretProviderClass is required",
+		},
+		{
+			name:    "kms",
+			secrets: config.SyncSecrets{Enabled: true, Backend: kmsBackend},
+		},
+		{
+			name:    "kms without csi driver",
+			secrets: config.SyncSecrets{Enabled: true, Backend: config.SecretBackend{Type: config.SecretBackendTypeKMS, KMS: kmsBackend.KMS}},
+			wantErr: "sync.toHost.secrets.backend.csi.driver is required",
+		},
+		{
+			name:    "kms with invalid endpoint",
+			secrets: config.SyncSecrets{Enabled: true, Backend: kmsInvalidEndpoint},
+			wantErr: "sync.toHost.secrets.backend.kms.endpoint needs to be a unix socket",
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSecretBackend(tt.secrets)
+			if err != nil && (tt.wantErr == "" || !strings.HasPrefix(err.Error(), tt.wantErr)) {
+				t.Errorf("wanted err to be %s but got %s", tt.wantErr, err.Error())
+			} else if err == nil && tt.wantErr != "" {
+				t.Errorf("wanted err to be %s but got nil", tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMigrateMultiNamespaceMode(t *testing.T) {
+	vConfig := &VirtualClusterConfig{}
+	vConfig.Experimental.MultiNamespaceMode.Enabled = true
+	vConfig.Experimental.MultiNamespaceMode.NamespaceLabels = map[string]string{"a": "deprecated", "b": "deprecated"}
+	vConfig.Sync.ToHost.Namespaces.Labels = map[string]string{"a": "a"}
+
+	migrateMultiNamespaceMode(vConfig)
+	if !vConfig.Sync.ToHost.Namespaces.Enabled {
+		t.Fatalf("expected sync.toHost.namespaces.enabled to be true")
+	}
+	if vConfig.Sync.ToHost.Namespaces.Labels["a"] != "a" || vConfig.Sync.ToHost.Namespaces.Labels["b"] != "deprecated" {
+		t.Fatalf("unexpected labels %v", vConfig.Sync.ToHost.Namespaces.Labels)
+	}
+}
+
+func TestMigrateDenyProxyRequests(t *testing.T) {
+	vConfig := &VirtualClusterConfig{}
+	vConfig.Experimental.DenyProxyRequests = []config.DenyRule{{Name: "no-exec"}, {}}
+	vConfig.ControlPlane.Proxy.RequestRules.Deny = []config.DenyRule{{Name: "no-delete"}}
+
+	migrateDenyProxyRequests(vConfig)
+	if len(vConfig.Experimental.DenyProxyRequests) != 0 {
+		t.Fatalf("expected experimental.denyProxyRequests to be empty")
+	}
+
+	names := []string{}
+	for _, rule := range vConfig.ControlPlane.Proxy.RequestRules.Deny {
+		names = append(names, rule.Name)
+	}
+	if strings.Join(names, ",") != "no-delete,no-exec,deny-proxy-requests-1" {
+		t.Fatalf("unexpected deny rules %v", names)
+	}
+}
+
+func TestMigrateGenericSyncExports(t *testing.T) {
+	certificate := config.TypeInformation{APIVersion: "cert-manager.io/v1", Kind: "Certificate"}
+	issuer := config.TypeInformation{APIVersion: "cert-manager.io/v1", Kind: "Issuer"}
+	order := config.TypeInformation{APIVersion: "acme.cert-manager.io/v1", Kind: "Order"}
+	challenge := config.TypeInformation{APIVersion: "acme.cert-manager.io/v1", Kind: "Challenge"}
+
+	vConfig := &VirtualClusterConfig{}
+	vConfig.Experimental.GenericSync = config.ExperimentalGenericSync{
+		Exports: []*config.Export{
+			{SyncBase: config.SyncBase{TypeInformation: certificate}},
+			{SyncBase: config.SyncBase{TypeInformation: issuer, Patches: []*config.Patch{{Operation: config.PatchTypeRemove, Path: "spec.ca"}}}},
+			{SyncBase: config.SyncBase{TypeInformation: order}, Selector: &config.Selector{LabelSelector: map[string]string{"a": "b"}}},
+			{SyncBase: config.SyncBase{TypeInformation: challenge}},
+			{SyncBase: config.SyncBase{TypeInformation: config.TypeInformation{APIVersion: "v1", Kind: "ConfigMap"}}},
+		},
+		Hooks: &config.Hooks{
+			VirtualToHost: []*config.Hook{{TypeInformation: challenge}},
+		},
+	}
+
+	migrateGenericSyncExports(vConfig)
+	if len(vConfig.Sync.ToHost.CustomResources) != 1 || !vConfig.Sync.ToHost.CustomResources["certificates.cert-manager.io"].Enabled {
+		t.Fatalf("unexpected custom resources %v", vConfig.Sync.ToHost.CustomResources)
+	}
+
+	kinds := []string{}
+	for _, exp := range vConfig.Experimental.GenericSync.Exports {
+		kinds = append(kinds, exp.Kind)
+	}
+	if strings.Join(kinds, ",") != "Issuer,Order,Challenge,ConfigMap" {
+		t.Fatalf("unexpected exports %v", kinds)
+	}
+}
+
+func TestValidateEncryption(t *testing.T) {
+	testCases := []struct {
+		name       string
+		encryption config.ControlPlaneEncryption
+		wantErr    string
+	}{
+		{
+			name:       "disabled",
+			encryption: config.ControlPlaneEncryption{Provider: "unknown"},
+		},
+		{
+			name:       "aescbc with rotation",
+			encryption: config.ControlPlaneEncryption{Enabled: true, Provider: config.EncryptionProviderAESCBC, KeyRotationPeriod: "720h"},
+		},
+		{
+			name:       "invalid rotation period",
+			encryption: config.ControlPlaneEncryption{Enabled: true, Provider: config.EncryptionProviderSecretbox, KeyRotationPeriod: "30d"},
+			wantErr:    "invalid controlPlane.encryption.keyRotationPeriod",
+		},
+		{
+			name:       "unknown provider",
+			encryption: config.ControlPlaneEncryption{Enabled: true, Provider: "aesgcm"},
+			wantErr:    "invalid controlPlane.encryption.provider",
+		},
+		{
+			name:       "wildcard resources",
+			encryption: config.ControlPlaneEncryption{Enabled: true, Resources: []string{"*.*"}},
+			wantErr:    "wildcards are not supported in controlPlane.encryption.resources",
+		},
+		{
+			name: "kms",
+			encryption: config.ControlPlaneEncryption{Enabled: true, Provider: config.EncryptionProviderKMS, KMS: config.ControlPlaneEncryptionKMS{
+				Name:     "vault",
+				Endpoint: "unix:///var/run/kms-plugin/socket.sock",
+				Timeout:  "3s",
+			}},
+		},
+		{
+			name: "kms without unix socket",
+			encryption: config.ControlPlaneEncryption{Enabled: true, Provider: config.EncryptionProviderKMS, KMS: config.ControlPlaneEncryptionKMS{
+				Name:     "vault",
+				Endpoint: "https://vault:8200",
+			}},
+			wantErr: "controlPlane.encryption.kms.endpoint needs to be a unix socket",
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateEncryption(tt.encryption)
+			if err != nil && (tt.wantErr == "" || !strings.HasPrefix(err.Error(), tt.wantErr)) {
+				t.Errorf("wanted err to be %s but got %s", tt.wantErr, err.Error())
+			} else if err == nil && tt.wantErr != "" {
+				t.Errorf("wanted err to be %s but got nil", tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateCertificatesRenewal(t *testing.T) {
+	testCases := []struct {
+		name    string
+		renewal config.ControlPlaneCertificatesRenewal
+		wantErr string
+	}{
+		{
+			name:    "disabled",
+			renewal: config.ControlPlaneCertificatesRenewal{RenewBefore: "invalid"},
+		},
+		{
+			name:    "enabled",
+			renewal: config.ControlPlaneCertificatesRenewal{Enabled: true, RenewBefore: "720h", CATrustPeriod: "24h"},
+		},
+		{
+			name:    "invalid renew before",
+			renewal: config.ControlPlaneCertificatesRenewal{Enabled: true, RenewBefore: "30d"},
+			wantErr: "invalid controlPlane.certificates.renewal.renewBefore",
+		},
+		{
+			name:    "zero renew before",
+			renewal: config.ControlPlaneCertificatesRenewal{Enabled: true, RenewBefore: "0s"},
+			wantErr: "controlPlane.certificates.renewal.renewBefore needs to be greater than zero",
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCertificatesRenewal(tt.renewal)
+			if err != nil && (tt.wantErr == "" || !strings.HasPrefix(err.Error(), tt.wantErr)) {
+				t.Errorf("wanted err to be %s but got %s", tt.wantErr, err.Error())
+			} else if err == nil && tt.wantErr != "" {
+				t.Errorf("wanted err to be %s but got nil", tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateCertificateAuthority(t *testing.T) {
+	testCases := []struct {
+		name         string
+		distro       string
+		certificates config.ControlPlaneCertificates
+		wantErr      string
+	}{
+		{
+			name:   "self-generated ca",
+			distro: config.K3SDistro,
+		},
+		{
+			name:   "external ca",
+			distro: config.K8SDistro,
+			certificates: config.ControlPlaneCertificates{
+				CA: config.ControlPlaneCertificatesCA{SecretName: "my-ca"},
+			},
+		},
+		{
+			name:   "cert-manager",
+			distro: config.K8SDistro,
+			certificates: config.ControlPlaneCertificates{
+				CertManager: config.ControlPlaneCertificatesCertManager{Enabled: true, IssuerRef: config.CertManagerIssuerRef{Name: "my-issuer", Kind: "ClusterIssuer"}},
+			},
+		},
+		{
+			name:   "unsupported distro",
+			distro: config.K3SDistro,
+			certificates: config.ControlPlaneCertificates{
+				CA: config.ControlPlaneCertificatesCA{SecretName: "my-ca"},
+			},
+			wantErr: "controlPlane.certificates.ca and controlPlane.certificates.certManager are only supported for the k8s distro",
+		},
+		{
+			name:   "external ca rotation",
+			distro: config.K8SDistro,
+			certificates: config.ControlPlaneCertificates{
+				Renewal: config.ControlPlaneCertificatesRenewal{Enabled: true, RotateCA: true},
+				CA:      config.ControlPlaneCertificatesCA{SecretName: "my-ca"},
+			},
+			wantErr: "controlPlane.certificates.renewal.rotateCA cannot be used with an external CA",
+		},
+		{
+			name:   "missing issuer",
+			distro: config.K8SDistro,
+			certificates: config.ControlPlaneCertificates{
+				CertManager: config.ControlPlaneCertificatesCertManager{Enabled: true},
+			},
+			wantErr: "controlPlane.certificates.certManager.issuerRef.name is required",
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCertificateAuthority(tt.distro, tt.certificates)
+			if err != nil && (tt.wantErr == "" || !strings.HasPrefix(err.Error(), tt.wantErr)) {
+				t.Errorf("wanted err to be %s but got %s", tt.wantErr, err.Error())
+			} else if err == nil && tt.wantErr != "" {
+				t.Errorf("wanted err to be %s but got nil", tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateOIDC(t *testing.T) {
+	testCases := []struct {
+		name    string
+		oidc    config.ControlPlaneAuthOIDC
+		wantErr string
+	}{
+		{
+			name: "disabled",
+			oidc: config.ControlPlaneAuthOIDC{IssuerURL: "invalid"},
+		},
+		{
+			name: "enabled",
+			oidc: config.ControlPlaneAuthOIDC{Enabled: true, IssuerURL: "https://dex.example.com", ClientID: "vcluster", RequiredClaims: map[string]string{"hd": "example.com"}},
+		},
+		{
+			name:    "http issuer",
+			oidc:    config.ControlPlaneAuthOIDC{Enabled: true, IssuerURL: "http://dex.example.com", ClientID: "vcluster"},
+			wantErr: "controlPlane.auth.oidc.issuerURL \"http://dex.example.com\" needs to be a valid https url",
+		},
+		{
+			name:    "missing client id",
+			oidc:    config.ControlPlaneAuthOIDC{Enabled: true, IssuerURL: "https://dex.example.com"},
+			wantErr: "controlPlane.auth.oidc.clientID is required",
+		},
+		{
+			name:    "invalid ca",
+			oidc:    config.ControlPlaneAuthOIDC{Enabled: true, IssuerURL: "https://dex.example.com", ClientID: "vcluster", CA: "invalid"},
+			wantErr: "controlPlane.auth.oidc.ca is not a valid PEM encoded certificate",
+		},
+		{
+			name:    "invalid required claim",
+			oidc:    config.ControlPlaneAuthOIDC{Enabled: true, IssuerURL: "https://dex.example.com", ClientID: "vcluster", RequiredClaims: map[string]string{"hd": "a,b"}},
+			wantErr: "invalid controlPlane.auth.oidc.requiredClaims value",
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateOIDC(tt.oidc)
+			if err != nil && (tt.wantErr == "" || !strings.HasPrefix(err.Error(), tt.wantErr)) {
+				t.Errorf("wanted err to be %s but got %s", tt.wantErr, err.Error())
+			} else if err == nil && tt.wantErr != "" {
+				t.Errorf("wanted err to be %s but got nil", tt.wantErr)
+			}
+		})
+	}
+}
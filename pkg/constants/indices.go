@@ -9,6 +9,8 @@ const (
 	IndexByIngressSecret = "IndexByIngressSecret"
 	IndexByPodSecret     = "IndexByPodSecret"
 	IndexByConfigMap     = "IndexByConfigMap"
+	IndexByJobSecret     = "IndexByJobSecret"
+	IndexByJobConfigMap  = "IndexByJobConfigMap"
 	// IndexByHostName is used to map rewritten hostnames(advertised as node addresses) to nodenames
 	IndexByHostName = "IndexByHostName"
 
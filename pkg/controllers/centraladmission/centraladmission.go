@@ -0,0 +1,307 @@
+package centraladmission
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	vclusterconfig "github.com/loft-sh/vcluster/config"
+	"github.com/loft-sh/vcluster/pkg/config"
+	"github.com/loft-sh/vcluster/pkg/constants"
+	"github.com/loft-sh/vcluster/pkg/util/loghelper"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const (
+	// NamePrefix is the name prefix of the webhook configurations that are managed by vCluster
+	NamePrefix = "vcluster-central-admission-"
+
+	// ManagedLabel is the label of the webhook configurations that are managed by vCluster
+	ManagedLabel = "vcluster.loft.sh/central-admission"
+)
+
+// Reconciler makes sure the webhooks of policies.centralAdmission are registered within the virtual cluster, so
+// that the virtual api server calls them for every request, including the ones of the virtual controller manager.
+// Changes to these webhook configurations within the virtual cluster are reverted.
+type Reconciler struct {
+	client.Client
+	Log loghelper.Logger
+
+	validatingWebhooks map[string]*admissionregistrationv1.ValidatingWebhookConfiguration
+	mutatingWebhooks   map[string]*admissionregistrationv1.MutatingWebhookConfiguration
+}
+
+// New parses the webhooks of policies.centralAdmission and returns a reconciler for them
+func New(virtualClient client.Client, centralAdmission vclusterconfig.CentralAdmission) (*Reconciler, error) {
+	validatingWebhooks, mutatingWebhooks, err := config.ParseExtraHooks(centralAdmission.ValidatingWebhooks, centralAdmission.MutatingWebhooks)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Reconciler{
+		Client:             virtualClient,
+		Log:                loghelper.New("central-admission-controller"),
+		validatingWebhooks: map[string]*admissionregistrationv1.ValidatingWebhookConfiguration{},
+		mutatingWebhooks:   map[string]*admissionregistrationv1.MutatingWebhookConfiguration{},
+	}
+	for idx := range validatingWebhooks {
+		webhookConfiguration := &validatingWebhooks[idx]
+		setMetadata(&webhookConfiguration.ObjectMeta, idx)
+		for i := range webhookConfiguration.Webhooks {
+			webhook := &webhookConfiguration.Webhooks[i]
+			webhook.ClientConfig = translateClientConfig(webhook.ClientConfig)
+			webhook.FailurePolicy, webhook.MatchPolicy, webhook.TimeoutSeconds = defaultPolicies(webhook.FailurePolicy, webhook.MatchPolicy, webhook.TimeoutSeconds)
+			webhook.NamespaceSelector, webhook.ObjectSelector = defaultSelectors(webhook.NamespaceSelector, webhook.ObjectSelector)
+			defaultRules(webhook.Rules)
+		}
+		if r.validatingWebhooks[webhookConfiguration.Name] != nil {
+			return nil, fmt.Errorf("validating webhook configuration %s is defined twice", webhookConfiguration.Name)
+		}
+		r.validatingWebhooks[webhookConfiguration.Name] = webhookConfiguration
+	}
+	for idx := range mutatingWebhooks {
+		webhookConfiguration := &mutatingWebhooks[idx]
+		setMetadata(&webhookConfiguration.ObjectMeta, idx)
+		for i := range webhookConfiguration.Webhooks {
+			webhook := &webhookConfiguration.Webhooks[i]
+			webhook.ClientConfig = translateClientConfig(webhook.ClientConfig)
+			webhook.FailurePolicy, webhook.MatchPolicy, webhook.TimeoutSeconds = defaultPolicies(webhook.FailurePolicy, webhook.MatchPolicy, webhook.TimeoutSeconds)
+			webhook.NamespaceSelector, webhook.ObjectSelector = defaultSelectors(webhook.NamespaceSelector, webhook.ObjectSelector)
+			defaultRules(webhook.Rules)
+			if webhook.ReinvocationPolicy == nil {
+				webhook.ReinvocationPolicy = ptr.To(admissionregistrationv1.NeverReinvocationPolicy)
+			}
+		}
+		if r.mutatingWebhooks[webhookConfiguration.Name] != nil {
+			return nil, fmt.Errorf("mutating webhook configuration %s is defined twice", webhookConfiguration.Name)
+		}
+		r.mutatingWebhooks[webhookConfiguration.Name] = webhookConfiguration
+	}
+
+	return r, nil
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	err := r.reconcileValidatingWebhooks(ctx, req.Name)
+	if err != nil {
+		return ctrl.Result{RequeueAfter: time.Second}, err
+	}
+
+	err = r.reconcileMutatingWebhooks(ctx, req.Name)
+	if err != nil {
+		return ctrl.Result{RequeueAfter: time.Second}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// EnsureAll creates or updates all webhook configurations and removes the ones that are not configured anymore
+func (r *Reconciler) EnsureAll(ctx context.Context) error {
+	names := map[string]bool{}
+	for name := range r.validatingWebhooks {
+		names[name] = true
+	}
+	for name := range r.mutatingWebhooks {
+		names[name] = true
+	}
+
+	validatingList := &admissionregistrationv1.ValidatingWebhookConfigurationList{}
+	err := r.List(ctx, validatingList, client.MatchingLabels{ManagedLabel: "true"})
+	if err != nil {
+		return err
+	}
+	for _, webhookConfiguration := range validatingList.Items {
+		names[webhookConfiguration.Name] = true
+	}
+
+	mutatingList := &admissionregistrationv1.MutatingWebhookConfigurationList{}
+	err = r.List(ctx, mutatingList, client.MatchingLabels{ManagedLabel: "true"})
+	if err != nil {
+		return err
+	}
+	for _, webhookConfiguration := range mutatingList.Items {
+		names[webhookConfiguration.Name] = true
+	}
+
+	for name := range names {
+		_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: name}})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) reconcileValidatingWebhooks(ctx context.Context, name string) error {
+	desired := r.validatingWebhooks[name]
+	current := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+	err := r.Get(ctx, types.NamespacedName{Name: name}, current)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return err
+	} else if kerrors.IsNotFound(err) {
+		if desired == nil {
+			return nil
+		}
+
+		r.Log.Infof("create validating webhook configuration %s", name)
+		return r.Create(ctx, desired.DeepCopy())
+	} else if desired == nil {
+		if current.Labels[ManagedLabel] != "true" {
+			return nil
+		}
+
+		r.Log.Infof("delete validating webhook configuration %s", name)
+		return client.IgnoreNotFound(r.Delete(ctx, current))
+	} else if equality.Semantic.DeepEqual(current.Labels, desired.Labels) && equality.Semantic.DeepEqual(current.Webhooks, desired.Webhooks) {
+		return nil
+	}
+
+	r.Log.Infof("revert changes of validating webhook configuration %s", name)
+	current.Labels = desired.Labels
+	current.Webhooks = desired.Webhooks
+	return r.Update(ctx, current)
+}
+
+func (r *Reconciler) reconcileMutatingWebhooks(ctx context.Context, name string) error {
+	desired := r.mutatingWebhooks[name]
+	current := &admissionregistrationv1.MutatingWebhookConfiguration{}
+	err := r.Get(ctx, types.NamespacedName{Name: name}, current)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return err
+	} else if kerrors.IsNotFound(err) {
+		if desired == nil {
+			return nil
+		}
+
+		r.Log.Infof("create mutating webhook configuration %s", name)
+		return r.Create(ctx, desired.DeepCopy())
+	} else if desired == nil {
+		if current.Labels[ManagedLabel] != "true" {
+			return nil
+		}
+
+		r.Log.Infof("delete mutating webhook configuration %s", name)
+		return client.IgnoreNotFound(r.Delete(ctx, current))
+	} else if equality.Semantic.DeepEqual(current.Labels, desired.Labels) && equality.Semantic.DeepEqual(current.Webhooks, desired.Webhooks) {
+		return nil
+	}
+
+	r.Log.Infof("revert changes of mutating webhook configuration %s", name)
+	current.Labels = desired.Labels
+	current.Webhooks = desired.Webhooks
+	return r.Update(ctx, current)
+}
+
+// SetupWithManager adds the controller to the manager
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// create the webhook configurations initially, afterwards changes are reverted by the controller
+	err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		return wait.PollUntilContextCancel(ctx, time.Second*5, true, func(ctx context.Context) (bool, error) {
+			err := r.EnsureAll(ctx)
+			if err != nil {
+				r.Log.Errorf("error ensuring central admission webhooks: %v", err)
+				return false, nil
+			}
+
+			return true, nil
+		})
+	}))
+	if err != nil {
+		return err
+	}
+
+	managed := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return r.validatingWebhooks[obj.GetName()] != nil || r.mutatingWebhooks[obj.GetName()] != nil || obj.GetLabels()[ManagedLabel] == "true"
+	})
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.Options{
+			CacheSyncTimeout: constants.DefaultCacheSyncTimeout,
+		}).
+		Named("central_admission").
+		For(&admissionregistrationv1.ValidatingWebhookConfiguration{}, builder.WithPredicates(managed)).
+		Watches(&admissionregistrationv1.MutatingWebhookConfiguration{}, &handler.EnqueueRequestForObject{}, builder.WithPredicates(managed)).
+		Complete(r)
+}
+
+func setMetadata(obj *metav1.ObjectMeta, idx int) {
+	if obj.Name == "" {
+		obj.Name = strconv.Itoa(idx)
+	}
+
+	obj.Name = NamePrefix + obj.Name
+	obj.Labels = map[string]string{ManagedLabel: "true"}
+	obj.Annotations = nil
+}
+
+// translateClientConfig rewrites service references to urls, as the services are located within the host cluster
+// and the virtual api server resolves them through the host cluster dns
+func translateClientConfig(clientConfig admissionregistrationv1.WebhookClientConfig) admissionregistrationv1.WebhookClientConfig {
+	if clientConfig.Service == nil {
+		return clientConfig
+	}
+
+	port := int32(443)
+	if clientConfig.Service.Port != nil {
+		port = *clientConfig.Service.Port
+	}
+	url := "https://" + net.JoinHostPort(clientConfig.Service.Name+"."+clientConfig.Service.Namespace+".svc", strconv.Itoa(int(port)))
+	if clientConfig.Service.Path != nil {
+		url += *clientConfig.Service.Path
+	}
+
+	return admissionregistrationv1.WebhookClientConfig{
+		URL:      &url,
+		CABundle: clientConfig.CABundle,
+	}
+}
+
+// defaultPolicies applies the defaults of the api server, so that the stored webhooks equal the desired ones
+func defaultPolicies(failurePolicy *admissionregistrationv1.FailurePolicyType, matchPolicy *admissionregistrationv1.MatchPolicyType, timeoutSeconds *int32) (*admissionregistrationv1.FailurePolicyType, *admissionregistrationv1.MatchPolicyType, *int32) {
+	if failurePolicy == nil {
+		failurePolicy = ptr.To(admissionregistrationv1.Fail)
+	}
+	if matchPolicy == nil {
+		matchPolicy = ptr.To(admissionregistrationv1.Equivalent)
+	}
+	if timeoutSeconds == nil {
+		timeoutSeconds = ptr.To[int32](10)
+	}
+
+	return failurePolicy, matchPolicy, timeoutSeconds
+}
+
+// defaultSelectors matches all namespaces and objects if no selector is set
+func defaultSelectors(namespaceSelector, objectSelector *metav1.LabelSelector) (*metav1.LabelSelector, *metav1.LabelSelector) {
+	if namespaceSelector == nil {
+		namespaceSelector = &metav1.LabelSelector{}
+	}
+	if objectSelector == nil {
+		objectSelector = &metav1.LabelSelector{}
+	}
+
+	return namespaceSelector, objectSelector
+}
+
+func defaultRules(rules []admissionregistrationv1.RuleWithOperations) {
+	for idx := range rules {
+		if rules[idx].Scope == nil {
+			rules[idx].Scope = ptr.To(admissionregistrationv1.AllScopes)
+		}
+	}
+}
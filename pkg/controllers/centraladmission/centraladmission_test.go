@@ -0,0 +1,70 @@
+package centraladmission
+
+import (
+	"context"
+	"testing"
+
+	vclusterconfig "github.com/loft-sh/vcluster/config"
+	"github.com/loft-sh/vcluster/pkg/scheme"
+	testingutil "github.com/loft-sh/vcluster/pkg/util/testing"
+	"gotest.tools/v3/assert"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+	stale := &admissionregistrationv1.ValidatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: NamePrefix + "stale", Labels: map[string]string{ManagedLabel: "true"}}}
+	tenant := &admissionregistrationv1.ValidatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: "tenant"}}
+	virtualClient := testingutil.NewFakeClient(scheme.Scheme, stale, tenant)
+
+	r, err := New(virtualClient, vclusterconfig.CentralAdmission{
+		ValidatingWebhooks: []vclusterconfig.ValidatingWebhookConfiguration{{
+			Metadata: vclusterconfig.ObjectMeta{Name: "policies"},
+			Webhooks: []vclusterconfig.ValidatingWebhook{{
+				Name: "pods.policies.loft.sh",
+				ClientConfig: vclusterconfig.ValidatingWebhookClientConfig{
+					Service: &vclusterconfig.ValidatingWebhookServiceReference{
+						Name:      "kyverno-svc",
+						Namespace: "kyverno",
+						Path:      ptr.To("/validate"),
+					},
+				},
+				SideEffects:             ptr.To("None"),
+				AdmissionReviewVersions: []string{"v1"},
+			}},
+		}},
+	})
+	assert.NilError(t, err)
+
+	// webhooks are created with host service urls and stale ones are removed
+	assert.NilError(t, r.EnsureAll(ctx))
+	created := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+	assert.NilError(t, virtualClient.Get(ctx, types.NamespacedName{Name: NamePrefix + "policies"}, created))
+	assert.Equal(t, len(created.Webhooks), 1)
+	assert.Equal(t, *created.Webhooks[0].ClientConfig.URL, "https://kyverno-svc.kyverno.svc:443/validate")
+	assert.Assert(t, created.Webhooks[0].ClientConfig.Service == nil)
+	assert.Equal(t, *created.Webhooks[0].FailurePolicy, admissionregistrationv1.Fail)
+	assert.Assert(t, kerrors.IsNotFound(virtualClient.Get(ctx, types.NamespacedName{Name: stale.Name}, stale)))
+	assert.NilError(t, virtualClient.Get(ctx, types.NamespacedName{Name: tenant.Name}, tenant))
+
+	// changes within the virtual cluster are reverted
+	created.Labels = nil
+	created.Webhooks[0].FailurePolicy = ptr.To(admissionregistrationv1.Ignore)
+	assert.NilError(t, virtualClient.Update(ctx, created))
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: created.Name}})
+	assert.NilError(t, err)
+	assert.NilError(t, virtualClient.Get(ctx, types.NamespacedName{Name: created.Name}, created))
+	assert.Equal(t, created.Labels[ManagedLabel], "true")
+	assert.Equal(t, *created.Webhooks[0].FailurePolicy, admissionregistrationv1.Fail)
+
+	// deleted webhooks are recreated
+	assert.NilError(t, virtualClient.Delete(ctx, created))
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: created.Name}})
+	assert.NilError(t, err)
+	assert.NilError(t, virtualClient.Get(ctx, types.NamespacedName{Name: created.Name}, created))
+}
@@ -0,0 +1,163 @@
+package generic
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/loft-sh/vcluster/pkg/mappings/generic"
+	"github.com/loft-sh/vcluster/pkg/syncer"
+	"github.com/loft-sh/vcluster/pkg/syncer/synccontext"
+	syncertypes "github.com/loft-sh/vcluster/pkg/syncer/types"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsv1clientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+)
+
+// ResolveCustomResource looks up the custom resource definition with the given name in the host
+// cluster and returns the group version kind of its storage version as well as if it's namespaced.
+var ResolveCustomResource = func(ctx context.Context, pConfig *rest.Config, name string) (schema.GroupVersionKind, bool, error) {
+	pClient, err := apiextensionsv1clientset.NewForConfig(pConfig)
+	if err != nil {
+		return schema.GroupVersionKind{}, false, err
+	}
+
+	crd, err := pClient.ApiextensionsV1().CustomResourceDefinitions().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return schema.GroupVersionKind{}, false, fmt.Errorf("get custom resource definition %s in host cluster: %w", name, err)
+	}
+
+	for _, version := range crd.Spec.Versions {
+		if version.Storage {
+			return schema.GroupVersionKind{
+				Group:   crd.Spec.Group,
+				Version: version.Name,
+				Kind:    crd.Spec.Names.Kind,
+			}, crd.Spec.Scope == apiextensionsv1.NamespaceScoped, nil
+		}
+	}
+
+	return schema.GroupVersionKind{}, false, fmt.Errorf("custom resource definition %s has no storage version", name)
+}
+
+// CreateCustomResourceSyncers creates the syncers for all custom resources configured in
+// sync.toHost.customResources and sync.fromHost.customResources.
+func CreateCustomResourceSyncers(ctx *synccontext.ControllerContext) error {
+	registerCtx := ctx.ToRegisterContext()
+	for name, customResource := range ctx.Config.Sync.ToHost.CustomResources {
+		if !customResource.Enabled {
+			continue
+		}
+
+		err := registerCustomResourceSyncer(registerCtx, name, true)
+		if err != nil {
+			return fmt.Errorf("sync.toHost.customResources.%s: %w", name, err)
+		}
+	}
+
+	for name, customResource := range ctx.Config.Sync.FromHost.CustomResources {
+		if !customResource.Enabled {
+			continue
+		}
+
+		err := registerCustomResourceSyncer(registerCtx, name, false)
+		if err != nil {
+			return fmt.Errorf("sync.fromHost.customResources.%s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func registerCustomResourceSyncer(ctx *synccontext.RegisterContext, name string, toHost bool) error {
+	gvk, namespaced, err := ResolveCustomResource(ctx, ctx.PhysicalManager.GetConfig(), name)
+	if err != nil {
+		return err
+	} else if toHost && !namespaced {
+		return fmt.Errorf("only namespaced resources can be synced to the host cluster")
+	} else if !toHost && namespaced {
+		return fmt.Errorf("only cluster scoped resources can be synced from the host cluster")
+	}
+
+	klog.FromContext(ctx).Info("Ensure custom resource definition from host cluster", "crd", name, "groupVersionKind", gvk)
+	_, hasStatusSubresource, err := translate.EnsureCRDFromPhysicalCluster(ctx, ctx.PhysicalManager.GetConfig(), ctx.VirtualManager.GetConfig(), gvk)
+	if err != nil {
+		return fmt.Errorf("ensure custom resource definition in virtual cluster: %w", err)
+	}
+
+	var s syncertypes.Syncer
+	if toHost {
+		s, err = NewToHostCustomResourceSyncer(ctx, name, gvk, hasStatusSubresource)
+	} else {
+		s, err = NewFromHostCustomResourceSyncer(ctx, name, gvk, hasStatusSubresource)
+	}
+	if err != nil {
+		return err
+	}
+
+	klog.FromContext(ctx).Info("Registering custom resource syncer", "crd", name, "toHost", toHost)
+	return syncer.RegisterSyncer(ctx, s)
+}
+
+func newCustomResourceMapper(ctx *synccontext.RegisterContext, gvk schema.GroupVersionKind, toHost bool) (*unstructured.Unstructured, synccontext.Mapper, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	if ctx.Mappings.Has(gvk) {
+		mapper, err := ctx.Mappings.ByGVK(gvk)
+		return obj, mapper, err
+	}
+
+	var (
+		mapper synccontext.Mapper
+		err    error
+	)
+	if toHost {
+		mapper, err = generic.NewMapper(ctx, obj, translate.Default.HostName)
+	} else {
+		mapper, err = generic.NewMirrorMapper(obj)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return obj, mapper, ctx.Mappings.AddMapper(mapper)
+}
+
+// copyCustomResourceFields copies all top level fields except metadata and status from one object to another
+func copyCustomResourceFields(from, to *unstructured.Unstructured) {
+	for key := range to.Object {
+		if isCustomResourceMetaField(key) {
+			continue
+		} else if _, ok := from.Object[key]; !ok {
+			delete(to.Object, key)
+		}
+	}
+
+	for key, value := range from.Object {
+		if isCustomResourceMetaField(key) {
+			continue
+		}
+
+		to.Object[key] = runtime.DeepCopyJSONValue(value)
+	}
+}
+
+// copyCustomResourceStatus copies the status of one object to another
+func copyCustomResourceStatus(from, to *unstructured.Unstructured) {
+	status, ok := from.Object["status"]
+	if !ok {
+		delete(to.Object, "status")
+		return
+	}
+
+	to.Object["status"] = runtime.DeepCopyJSONValue(status)
+}
+
+func isCustomResourceMetaField(key string) bool {
+	return key == "apiVersion" || key == "kind" || key == "metadata" || key == "status"
+}
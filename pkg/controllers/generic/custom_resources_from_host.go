@@ -0,0 +1,91 @@
+package generic
+
+import (
+	"fmt"
+
+	"github.com/loft-sh/vcluster/pkg/patcher"
+	"github.com/loft-sh/vcluster/pkg/syncer"
+	"github.com/loft-sh/vcluster/pkg/syncer/synccontext"
+	syncertypes "github.com/loft-sh/vcluster/pkg/syncer/types"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NewFromHostCustomResourceSyncer creates a syncer that mirrors the given cluster scoped custom resource
+// read-only from the host cluster into the virtual cluster.
+func NewFromHostCustomResourceSyncer(ctx *synccontext.RegisterContext, name string, gvk schema.GroupVersionKind, hasStatusSubresource bool) (syncertypes.Syncer, error) {
+	obj, mapper, err := newCustomResourceMapper(ctx, gvk, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fromHostCustomResourceSyncer{
+		Mapper: mapper,
+
+		name:                 name,
+		obj:                  obj,
+		hasStatusSubresource: hasStatusSubresource,
+	}, nil
+}
+
+type fromHostCustomResourceSyncer struct {
+	synccontext.Mapper
+
+	name                 string
+	obj                  client.Object
+	hasStatusSubresource bool
+}
+
+var _ syncertypes.Syncer = &fromHostCustomResourceSyncer{}
+
+func (s *fromHostCustomResourceSyncer) Name() string {
+	return s.name
+}
+
+func (s *fromHostCustomResourceSyncer) Resource() client.Object {
+	return s.obj.DeepCopyObject().(client.Object)
+}
+
+func (s *fromHostCustomResourceSyncer) Syncer() syncertypes.Sync[client.Object] {
+	return syncer.ToGenericSyncer[*unstructured.Unstructured](s)
+}
+
+func (s *fromHostCustomResourceSyncer) SyncToVirtual(ctx *synccontext.SyncContext, event *synccontext.SyncToVirtualEvent[*unstructured.Unstructured]) (ctrl.Result, error) {
+	vObj := translate.CopyObjectWithName(event.Host, types.NamespacedName{Name: event.Host.GetName(), Namespace: event.Host.GetNamespace()}, false)
+	delete(vObj.Object, "status")
+	ctx.Log.Infof("create %s %s, because it does not exist in virtual cluster", s.name, vObj.GetName())
+	return ctrl.Result{}, ctx.VirtualClient.Create(ctx, vObj)
+}
+
+func (s *fromHostCustomResourceSyncer) Sync(ctx *synccontext.SyncContext, event *synccontext.SyncEvent[*unstructured.Unstructured]) (_ ctrl.Result, retErr error) {
+	var options []patcher.Option
+	if !s.hasStatusSubresource {
+		options = append(options, patcher.NoStatusSubResource())
+	}
+
+	patch, err := patcher.NewSyncerPatcher(ctx, event.Host, event.Virtual, options...)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("new syncer patcher: %w", err)
+	}
+	defer func() {
+		if err := patch.Patch(ctx, event.Host, event.Virtual); err != nil {
+			retErr = utilerrors.NewAggregate([]error{retErr, err})
+		}
+	}()
+
+	event.Virtual.SetAnnotations(event.Host.GetAnnotations())
+	event.Virtual.SetLabels(event.Host.GetLabels())
+	copyCustomResourceFields(event.Host, event.Virtual)
+	copyCustomResourceStatus(event.Host, event.Virtual)
+	return ctrl.Result{}, nil
+}
+
+func (s *fromHostCustomResourceSyncer) SyncToHost(ctx *synccontext.SyncContext, event *synccontext.SyncToHostEvent[*unstructured.Unstructured]) (ctrl.Result, error) {
+	ctx.Log.Infof("delete virtual %s %s, because physical object is missing", s.name, event.Virtual.GetName())
+	return ctrl.Result{}, ctx.VirtualClient.Delete(ctx, event.Virtual)
+}
@@ -0,0 +1,204 @@
+package generic
+
+import (
+	"testing"
+
+	"github.com/loft-sh/vcluster/pkg/syncer/synccontext"
+	syncertesting "github.com/loft-sh/vcluster/pkg/syncer/testing"
+	syncertypes "github.com/loft-sh/vcluster/pkg/syncer/types"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var testGVK = schema.GroupVersionKind{Group: "test.vcluster.loft.sh", Version: "v1", Kind: "Test"}
+
+func newTestObject(name, namespace string, annotations, labels map[string]string, fields map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	for k, v := range fields {
+		obj.Object[k] = v
+	}
+	obj.SetGroupVersionKind(testGVK)
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+	obj.SetAnnotations(annotations)
+	obj.SetLabels(labels)
+	return obj
+}
+
+func TestToHostCustomResourceSync(t *testing.T) {
+	translate.Default = translate.NewSingleNamespaceTranslator(syncertesting.DefaultTestTargetNamespace)
+
+	hostName := translate.Default.HostName("test", "default")
+	hostAnnotations := map[string]string{
+		translate.NameAnnotation:      "test",
+		translate.NamespaceAnnotation: "default",
+		translate.UIDAnnotation:       "",
+		translate.KindAnnotation:      testGVK.String(),
+	}
+	hostLabels := map[string]string{
+		translate.NamespaceLabel: "default",
+		translate.MarkerLabel:    translate.VClusterName,
+	}
+
+	vObj := newTestObject("test", "default", nil, nil, map[string]interface{}{
+		"driver": "test-driver",
+	})
+	vObjUpdated := newTestObject("test", "default", nil, nil, map[string]interface{}{
+		"driver":         "test-driver",
+		"deletionPolicy": "Delete",
+	})
+	vObjWithStatus := newTestObject("test", "default", nil, nil, map[string]interface{}{
+		"driver":         "test-driver",
+		"deletionPolicy": "Delete",
+		"status":         map[string]interface{}{"ready": true},
+	})
+	pObj := newTestObject(hostName, syncertesting.DefaultTestTargetNamespace, hostAnnotations, hostLabels, map[string]interface{}{
+		"driver": "test-driver",
+	})
+	pObjWithStatus := newTestObject(hostName, syncertesting.DefaultTestTargetNamespace, hostAnnotations, hostLabels, map[string]interface{}{
+		"driver": "test-driver",
+		"status": map[string]interface{}{"ready": true},
+	})
+	pObjUpdated := newTestObject(hostName, syncertesting.DefaultTestTargetNamespace, hostAnnotations, hostLabels, map[string]interface{}{
+		"driver":         "test-driver",
+		"deletionPolicy": "Delete",
+		"status":         map[string]interface{}{"ready": true},
+	})
+
+	newSyncer := func(ctx *synccontext.RegisterContext) (syncertypes.Object, error) {
+		return NewToHostCustomResourceSyncer(ctx, "tests.test.vcluster.loft.sh", testGVK, true)
+	}
+
+	syncertesting.RunTests(t, []*syncertesting.SyncTest{
+		{
+			Name:                "Create host object",
+			InitialVirtualState: []runtime.Object{vObj.DeepCopy()},
+			ExpectedVirtualState: map[schema.GroupVersionKind][]runtime.Object{
+				testGVK: {vObj.DeepCopy()},
+			},
+			ExpectedPhysicalState: map[schema.GroupVersionKind][]runtime.Object{
+				testGVK: {pObj.DeepCopy()},
+			},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := syncertesting.FakeStartSyncer(t, ctx, newSyncer)
+				_, err := syncer.(*toHostCustomResourceSyncer).SyncToHost(syncCtx, synccontext.NewSyncToHostEvent(vObj.DeepCopy()))
+				assert.NilError(t, err)
+			},
+		},
+		{
+			Name:                 "Sync spec to host and status back",
+			InitialVirtualState:  []runtime.Object{vObjUpdated.DeepCopy()},
+			InitialPhysicalState: []runtime.Object{pObjWithStatus.DeepCopy()},
+			ExpectedVirtualState: map[schema.GroupVersionKind][]runtime.Object{
+				testGVK: {vObjWithStatus.DeepCopy()},
+			},
+			ExpectedPhysicalState: map[schema.GroupVersionKind][]runtime.Object{
+				testGVK: {pObjUpdated.DeepCopy()},
+			},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := syncertesting.FakeStartSyncer(t, ctx, newSyncer)
+				_, err := syncer.(*toHostCustomResourceSyncer).Sync(syncCtx, synccontext.NewSyncEvent(pObjWithStatus.DeepCopy(), vObjUpdated.DeepCopy()))
+				assert.NilError(t, err)
+			},
+		},
+		{
+			Name:                 "Delete host object",
+			InitialPhysicalState: []runtime.Object{pObj.DeepCopy()},
+			ExpectedPhysicalState: map[schema.GroupVersionKind][]runtime.Object{
+				testGVK: {},
+			},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := syncertesting.FakeStartSyncer(t, ctx, newSyncer)
+				_, err := syncer.(*toHostCustomResourceSyncer).SyncToVirtual(syncCtx, synccontext.NewSyncToVirtualEvent(pObj.DeepCopy()))
+				assert.NilError(t, err)
+			},
+		},
+	})
+}
+
+func TestFromHostCustomResourceSync(t *testing.T) {
+	pObj := newTestObject("test", "", map[string]string{"a": "b"}, map[string]string{"c": "d"}, map[string]interface{}{
+		"driver": "test-driver",
+	})
+	pObjWithStatus := newTestObject("test", "", map[string]string{"a": "b"}, map[string]string{"c": "d"}, map[string]interface{}{
+		"driver": "test-driver",
+		"status": map[string]interface{}{"ready": true},
+	})
+	pObjUpdated := newTestObject("test", "", map[string]string{"a": "c"}, map[string]string{"c": "d"}, map[string]interface{}{
+		"driver":         "test-driver",
+		"deletionPolicy": "Retain",
+		"status":         map[string]interface{}{"ready": true},
+	})
+	vObj := newTestObject("test", "", map[string]string{"a": "b", "other": "annotation"}, map[string]string{"c": "d"}, map[string]interface{}{
+		"driver":     "test-driver",
+		"parameters": map[string]interface{}{"a": "b"},
+	})
+	vObjSyncStatus := newTestObject("test", "", map[string]string{"a": "b", translate.SyncStatusAnnotation: `{"phase":"Synced"}`}, map[string]string{"c": "d"}, map[string]interface{}{
+		"driver": "test-driver",
+	})
+	vObjUpdated := newTestObject("test", "", map[string]string{"a": "c", translate.SyncStatusAnnotation: `{"phase":"Synced"}`}, map[string]string{"c": "d"}, map[string]interface{}{
+		"driver":         "test-driver",
+		"deletionPolicy": "Retain",
+		"status":         map[string]interface{}{"ready": true},
+	})
+
+	newSyncer := func(ctx *synccontext.RegisterContext) (syncertypes.Object, error) {
+		return NewFromHostCustomResourceSyncer(ctx, "tests.test.vcluster.loft.sh", testGVK, true)
+	}
+
+	syncertesting.RunTests(t, []*syncertesting.SyncTest{
+		{
+			Name:                 "Create virtual object",
+			InitialPhysicalState: []runtime.Object{pObj.DeepCopy()},
+			ExpectedVirtualState: map[schema.GroupVersionKind][]runtime.Object{
+				testGVK: {pObj.DeepCopy()},
+			},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := syncertesting.FakeStartSyncer(t, ctx, newSyncer)
+				_, err := syncer.(*fromHostCustomResourceSyncer).SyncToVirtual(syncCtx, synccontext.NewSyncToVirtualEvent(pObj.DeepCopy()))
+				assert.NilError(t, err)
+			},
+		},
+		{
+			Name:                 "Sync virtual object",
+			InitialVirtualState:  []runtime.Object{vObjSyncStatus.DeepCopy()},
+			InitialPhysicalState: []runtime.Object{pObjUpdated.DeepCopy()},
+			ExpectedVirtualState: map[schema.GroupVersionKind][]runtime.Object{
+				testGVK: {vObjUpdated.DeepCopy()},
+			},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := syncertesting.FakeStartSyncer(t, ctx, newSyncer)
+				_, err := syncer.(*fromHostCustomResourceSyncer).Sync(syncCtx, synccontext.NewSyncEvent(pObjUpdated.DeepCopy(), vObjSyncStatus.DeepCopy()))
+				assert.NilError(t, err)
+			},
+		},
+		{
+			Name:                 "Overwrite virtual changes",
+			InitialVirtualState:  []runtime.Object{vObj.DeepCopy()},
+			InitialPhysicalState: []runtime.Object{pObjWithStatus.DeepCopy()},
+			ExpectedVirtualState: map[schema.GroupVersionKind][]runtime.Object{
+				testGVK: {pObjWithStatus.DeepCopy()},
+			},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := syncertesting.FakeStartSyncer(t, ctx, newSyncer)
+				_, err := syncer.(*fromHostCustomResourceSyncer).Sync(syncCtx, synccontext.NewSyncEvent(pObjWithStatus.DeepCopy(), vObj.DeepCopy()))
+				assert.NilError(t, err)
+			},
+		},
+		{
+			Name:                "Delete virtual object",
+			InitialVirtualState: []runtime.Object{vObj.DeepCopy()},
+			ExpectedVirtualState: map[schema.GroupVersionKind][]runtime.Object{
+				testGVK: {},
+			},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := syncertesting.FakeStartSyncer(t, ctx, newSyncer)
+				_, err := syncer.(*fromHostCustomResourceSyncer).SyncToHost(syncCtx, synccontext.NewSyncToHostEvent(vObj.DeepCopy()))
+				assert.NilError(t, err)
+			},
+		},
+	})
+}
@@ -0,0 +1,90 @@
+package generic
+
+import (
+	"fmt"
+
+	"github.com/loft-sh/vcluster/pkg/patcher"
+	"github.com/loft-sh/vcluster/pkg/syncer"
+	"github.com/loft-sh/vcluster/pkg/syncer/synccontext"
+	"github.com/loft-sh/vcluster/pkg/syncer/translator"
+	syncertypes "github.com/loft-sh/vcluster/pkg/syncer/types"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NewToHostCustomResourceSyncer creates a syncer that syncs the given namespaced custom resource from the
+// virtual cluster to the host cluster and the status back from the host cluster.
+func NewToHostCustomResourceSyncer(ctx *synccontext.RegisterContext, name string, gvk schema.GroupVersionKind, hasStatusSubresource bool) (syncertypes.Syncer, error) {
+	obj, mapper, err := newCustomResourceMapper(ctx, gvk, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &toHostCustomResourceSyncer{
+		GenericTranslator: translator.NewGenericTranslator(ctx, name, obj, mapper),
+
+		hasStatusSubresource: hasStatusSubresource,
+	}, nil
+}
+
+type toHostCustomResourceSyncer struct {
+	syncertypes.GenericTranslator
+
+	hasStatusSubresource bool
+}
+
+var _ syncertypes.Syncer = &toHostCustomResourceSyncer{}
+
+func (s *toHostCustomResourceSyncer) Syncer() syncertypes.Sync[client.Object] {
+	return syncer.ToGenericSyncer[*unstructured.Unstructured](s)
+}
+
+func (s *toHostCustomResourceSyncer) SyncToHost(ctx *synccontext.SyncContext, event *synccontext.SyncToHostEvent[*unstructured.Unstructured]) (ctrl.Result, error) {
+	if event.IsDelete() {
+		return syncer.DeleteVirtualObject(ctx, event.Virtual, "host object was deleted")
+	}
+
+	pObj := translate.HostMetadata(ctx, event.Virtual, s.VirtualToHost(ctx, types.NamespacedName{Name: event.Virtual.GetName(), Namespace: event.Virtual.GetNamespace()}, event.Virtual))
+	delete(pObj.Object, "status")
+	return syncer.CreateHostObject(ctx, event.Virtual, pObj, s.EventRecorder())
+}
+
+func (s *toHostCustomResourceSyncer) Sync(ctx *synccontext.SyncContext, event *synccontext.SyncEvent[*unstructured.Unstructured]) (_ ctrl.Result, retErr error) {
+	var options []patcher.Option
+	if !s.hasStatusSubresource {
+		options = append(options, patcher.NoStatusSubResource())
+	}
+
+	patch, err := patcher.NewSyncerPatcher(ctx, event.Host, event.Virtual, options...)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("new syncer patcher: %w", err)
+	}
+	defer func() {
+		if err := patch.Patch(ctx, event.Host, event.Virtual); err != nil {
+			retErr = utilerrors.NewAggregate([]error{retErr, err})
+		}
+		if retErr != nil {
+			s.EventRecorder().Eventf(event.Virtual, "Warning", "SyncError", "Error syncing: %v", retErr)
+		}
+	}()
+
+	// check annotations & labels
+	event.Host.SetAnnotations(translate.HostAnnotations(event.Virtual, event.Host))
+	event.Host.SetLabels(translate.HostLabels(ctx, event.Virtual, event.Host))
+
+	// the virtual object is the source of truth for everything except the status,
+	// which is owned by the controller running in the host cluster
+	copyCustomResourceFields(event.Virtual, event.Host)
+	copyCustomResourceStatus(event.Host, event.Virtual)
+	return ctrl.Result{}, nil
+}
+
+func (s *toHostCustomResourceSyncer) SyncToVirtual(ctx *synccontext.SyncContext, event *synccontext.SyncToVirtualEvent[*unstructured.Unstructured]) (_ ctrl.Result, retErr error) {
+	// virtual object is not here anymore, so we delete
+	return syncer.DeleteHostObject(ctx, event.Host, "virtual object was deleted")
+}
@@ -40,7 +40,7 @@ func CreateImporters(ctx *synccontext.ControllerContext) error {
 	}
 
 	registerCtx := ctx.ToRegisterContext()
-	if !registerCtx.Config.Experimental.MultiNamespaceMode.Enabled {
+	if !registerCtx.Config.Sync.ToHost.Namespaces.Enabled {
 		return fmt.Errorf("invalid configuration, 'import' type sync of the generic CRDs is allowed only in the multi-namespace mode")
 	}
 
@@ -344,7 +344,7 @@ func (s *importer) isVirtualManaged(vObj client.Object) bool {
 	return vObj.GetAnnotations() != nil && vObj.GetAnnotations()[translate.ControllerLabel] != "" && vObj.GetAnnotations()[translate.ControllerLabel] == s.Name()
 }
 
-func (s *importer) IsManaged(_ *synccontext.SyncContext, pObj client.Object) (bool, error) {
+func (s *importer) IsManaged(ctx *synccontext.SyncContext, pObj client.Object) (bool, error) {
 	if s.syncerOptions.IsClusterScopedCRD {
 		return true, nil
 	}
@@ -353,7 +353,7 @@ func (s *importer) IsManaged(_ *synccontext.SyncContext, pObj client.Object) (bo
 	}
 
 	// check if the pObj belong to a namespace managed by this vcluster
-	if !translate.Default.IsTargetedNamespace(pObj.GetNamespace()) {
+	if !translate.Default.IsTargetedNamespace(ctx, pObj.GetNamespace()) {
 		return false, nil
 	}
 
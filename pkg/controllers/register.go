@@ -16,9 +16,11 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
+	"github.com/loft-sh/vcluster/pkg/controllers/centraladmission"
 	"github.com/loft-sh/vcluster/pkg/controllers/coredns"
 	"github.com/loft-sh/vcluster/pkg/controllers/k8sdefaultendpoint"
 	"github.com/loft-sh/vcluster/pkg/controllers/podsecurity"
@@ -43,6 +45,14 @@ func RegisterControllers(ctx *synccontext.ControllerContext, syncers []syncertyp
 		}
 	}
 
+	// register controller that maintains the central admission webhooks
+	if len(ctx.Config.Policies.CentralAdmission.ValidatingWebhooks) > 0 || len(ctx.Config.Policies.CentralAdmission.MutatingWebhooks) > 0 {
+		err := registerCentralAdmissionController(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
 	// register controller that keeps CoreDNS NodeHosts config up to date
 	err = registerCoreDNSController(ctx)
 	if err != nil {
@@ -97,10 +107,21 @@ func RegisterControllers(ctx *synccontext.ControllerContext, syncers []syncertyp
 		}
 	}
 
+	// make sure all priority syncers exist now that every syncer is registered
+	err = syncer.ValidatePrioritySyncers(ctx.Config)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
 func registerGenericSyncController(ctx *synccontext.ControllerContext) error {
+	genericSync := ctx.Config.Experimental.GenericSync
+	if len(genericSync.Exports) > 0 || len(genericSync.Imports) > 0 {
+		klog.Warningf("experimental.genericSync is deprecated and will be removed in a future version, please use sync.toHost.customResources and sync.fromHost.customResources instead")
+	}
+
 	err := generic.CreateExporters(ctx)
 	if err != nil {
 		return err
@@ -111,12 +132,17 @@ func registerGenericSyncController(ctx *synccontext.ControllerContext) error {
 		return err
 	}
 
+	err = generic.CreateCustomResourceSyncers(ctx)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
 func registerServiceSyncControllers(ctx *synccontext.ControllerContext) error {
 	hostNamespace := ctx.Config.WorkloadTargetNamespace
-	if ctx.Config.Experimental.MultiNamespaceMode.Enabled {
+	if ctx.Config.Sync.ToHost.Namespaces.Enabled {
 		hostNamespace = ctx.Config.WorkloadNamespace
 	}
 
@@ -186,7 +212,7 @@ func registerServiceSyncControllers(ctx *synccontext.ControllerContext) error {
 			Log:                   loghelper.New("map-virtual-service-syncer"),
 		}
 
-		if ctx.Config.Experimental.MultiNamespaceMode.Enabled {
+		if ctx.Config.Sync.ToHost.Namespaces.Enabled {
 			controller.CreateEndpoints = true
 		}
 
@@ -255,6 +281,19 @@ func registerCoreDNSController(ctx *synccontext.ControllerContext) error {
 	return nil
 }
 
+func registerCentralAdmissionController(ctx *synccontext.ControllerContext) error {
+	controller, err := centraladmission.New(ctx.VirtualManager.GetClient(), ctx.Config.Policies.CentralAdmission)
+	if err != nil {
+		return fmt.Errorf("parse central admission webhooks: %w", err)
+	}
+
+	err = controller.SetupWithManager(ctx.VirtualManager)
+	if err != nil {
+		return fmt.Errorf("unable to setup central admission controller: %w", err)
+	}
+	return nil
+}
+
 func registerPodSecurityController(ctx *synccontext.ControllerContext) error {
 	controller := &podsecurity.Reconciler{
 		Client:              ctx.VirtualManager.GetClient(),
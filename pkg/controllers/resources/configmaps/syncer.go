@@ -13,6 +13,7 @@ import (
 	"github.com/loft-sh/vcluster/pkg/syncer/translator"
 	syncertypes "github.com/loft-sh/vcluster/pkg/syncer/types"
 	"github.com/loft-sh/vcluster/pkg/util/translate"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -34,6 +35,7 @@ func New(ctx *synccontext.RegisterContext) (syncertypes.Object, error) {
 		GenericTranslator: translator.NewGenericTranslator(ctx, "configmap", &corev1.ConfigMap{}, mapper),
 
 		syncAllConfigMaps: ctx.Config.Sync.ToHost.ConfigMaps.All,
+		includeJobs:       ctx.Config.Sync.ToHost.Jobs.Enabled,
 	}, nil
 }
 
@@ -41,6 +43,7 @@ type configMapSyncer struct {
 	syncertypes.GenericTranslator
 
 	syncAllConfigMaps bool
+	includeJobs       bool
 }
 
 var _ syncertypes.Syncer = &configMapSyncer{}
@@ -52,6 +55,16 @@ func (s *configMapSyncer) Syncer() syncertypes.Sync[client.Object] {
 var _ syncertypes.IndicesRegisterer = &configMapSyncer{}
 
 func (s *configMapSyncer) RegisterIndices(ctx *synccontext.RegisterContext) error {
+	// index jobs by the config maps used in their pod template
+	if s.includeJobs {
+		err := ctx.VirtualManager.GetFieldIndexer().IndexField(ctx, &batchv1.Job{}, constants.IndexByJobConfigMap, func(rawObj client.Object) []string {
+			return configNamesFromJob(rawObj.(*batchv1.Job))
+		})
+		if err != nil {
+			return err
+		}
+	}
+
 	// index pods by their used config maps
 	return ctx.VirtualManager.GetFieldIndexer().IndexField(ctx, &corev1.Pod{}, constants.IndexByConfigMap, func(rawObj client.Object) []string {
 		pod := rawObj.(*corev1.Pod)
@@ -62,6 +75,10 @@ func (s *configMapSyncer) RegisterIndices(ctx *synccontext.RegisterContext) erro
 var _ syncertypes.ControllerModifier = &configMapSyncer{}
 
 func (s *configMapSyncer) ModifyController(_ *synccontext.RegisterContext, builder *builder.Builder) (*builder.Builder, error) {
+	if s.includeJobs {
+		builder = builder.Watches(&batchv1.Job{}, handler.EnqueueRequestsFromMapFunc(mapJobs))
+	}
+
 	return builder.Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(mapPods)), nil
 }
 
@@ -139,9 +156,22 @@ func (s *configMapSyncer) isConfigMapUsed(ctx *synccontext.SyncContext, vObj run
 	err := ctx.VirtualClient.List(ctx, podList, client.MatchingFields{constants.IndexByConfigMap: configMap.Namespace + "/" + configMap.Name})
 	if err != nil {
 		return false, err
+	} else if len(podList.Items) > 0 {
+		return true, nil
+	}
+
+	// check if the config map is used by a job pod template
+	if s.includeJobs {
+		jobList := &batchv1.JobList{}
+		err := ctx.VirtualClient.List(ctx, jobList, client.MatchingFields{constants.IndexByJobConfigMap: configMap.Namespace + "/" + configMap.Name})
+		if err != nil {
+			return false, err
+		}
+
+		return len(jobList.Items) > 0, nil
 	}
 
-	return len(podList.Items) > 0, nil
+	return false, nil
 }
 
 func mapPods(_ context.Context, obj client.Object) []reconcile.Request {
@@ -150,8 +180,20 @@ func mapPods(_ context.Context, obj client.Object) []reconcile.Request {
 		return nil
 	}
 
+	return namesToRequests(configNamesFromPod(pod))
+}
+
+func mapJobs(_ context.Context, obj client.Object) []reconcile.Request {
+	job, ok := obj.(*batchv1.Job)
+	if !ok {
+		return nil
+	}
+
+	return namesToRequests(configNamesFromJob(job))
+}
+
+func namesToRequests(names []string) []reconcile.Request {
 	requests := []reconcile.Request{}
-	names := configNamesFromPod(pod)
 	for _, name := range names {
 		splitted := strings.Split(name, "/")
 		if len(splitted) == 2 {
@@ -8,6 +8,7 @@ import (
 	syncertesting "github.com/loft-sh/vcluster/pkg/syncer/testing"
 	"github.com/loft-sh/vcluster/pkg/util/translate"
 	"gotest.tools/assert"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -182,4 +183,17 @@ func TestMapping(t *testing.T) {
 	if len(requests) != 2 || requests[0].Name != "a" || requests[0].Namespace != "test" || requests[1].Name != "b" || requests[1].Namespace != "test" {
 		t.Fatalf("Wrong pod requests returned: %#+v", requests)
 	}
+	// job pods use the same config maps and the root ca with the service account token
+	job := &batchv1.Job{
+		ObjectMeta: pod.ObjectMeta,
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: pod.Spec,
+			},
+		},
+	}
+	requests = mapJobs(context.Background(), job)
+	if len(requests) != 3 || requests[0].Name != "a" || requests[1].Name != "b" || requests[2].Name != "kube-root-ca.crt" || requests[2].Namespace != "test" {
+		t.Fatalf("Wrong job requests returned: %#+v", requests)
+	}
 }
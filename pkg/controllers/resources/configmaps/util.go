@@ -1,10 +1,23 @@
 package configmaps
 
 import (
+	"github.com/loft-sh/vcluster/pkg/controllers/resources/jobs"
 	"github.com/loft-sh/vcluster/pkg/util/translate"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 )
 
+func configNamesFromJob(job *batchv1.Job) []string {
+	configMaps := configNamesFromPod(jobs.TemplatePod(job))
+
+	// job pods mount the root ca config map together with the service account token
+	if job.Spec.Template.Spec.AutomountServiceAccountToken == nil || *job.Spec.Template.Spec.AutomountServiceAccountToken {
+		configMaps = append(configMaps, job.Namespace+"/kube-root-ca.crt")
+	}
+
+	return translate.UniqueSlice(configMaps)
+}
+
 func configNamesFromPod(pod *corev1.Pod) []string {
 	configMaps := []string{}
 	for _, c := range pod.Spec.Containers {
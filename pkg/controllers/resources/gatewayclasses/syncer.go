@@ -0,0 +1,75 @@
+package gatewayclasses
+
+import (
+	"fmt"
+
+	"github.com/loft-sh/vcluster/pkg/mappings"
+	"github.com/loft-sh/vcluster/pkg/patcher"
+	"github.com/loft-sh/vcluster/pkg/syncer"
+	"github.com/loft-sh/vcluster/pkg/syncer/synccontext"
+	syncertypes "github.com/loft-sh/vcluster/pkg/syncer/types"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func New(ctx *synccontext.RegisterContext) (syncertypes.Object, error) {
+	mapper, err := ctx.Mappings.ByGVK(mappings.GatewayClasses())
+	if err != nil {
+		return nil, err
+	}
+
+	return &gatewayClassSyncer{
+		Mapper: mapper,
+	}, nil
+}
+
+type gatewayClassSyncer struct {
+	synccontext.Mapper
+}
+
+func (s *gatewayClassSyncer) Name() string {
+	return "gatewayclass"
+}
+
+func (s *gatewayClassSyncer) Resource() client.Object {
+	return &gatewayv1.GatewayClass{}
+}
+
+var _ syncertypes.Syncer = &gatewayClassSyncer{}
+
+func (s *gatewayClassSyncer) Syncer() syncertypes.Sync[client.Object] {
+	return syncer.ToGenericSyncer[*gatewayv1.GatewayClass](s)
+}
+
+func (s *gatewayClassSyncer) SyncToVirtual(ctx *synccontext.SyncContext, event *synccontext.SyncToVirtualEvent[*gatewayv1.GatewayClass]) (ctrl.Result, error) {
+	vObj := translate.CopyObjectWithName(event.Host, types.NamespacedName{Name: event.Host.Name}, false)
+	ctx.Log.Infof("create gateway class %s, because it does not exist in virtual cluster", vObj.Name)
+	return ctrl.Result{}, ctx.VirtualClient.Create(ctx, vObj)
+}
+
+func (s *gatewayClassSyncer) Sync(ctx *synccontext.SyncContext, event *synccontext.SyncEvent[*gatewayv1.GatewayClass]) (_ ctrl.Result, retErr error) {
+	patch, err := patcher.NewSyncerPatcher(ctx, event.Host, event.Virtual)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("new syncer patcher: %w", err)
+	}
+	defer func() {
+		if err := patch.Patch(ctx, event.Host, event.Virtual); err != nil {
+			retErr = utilerrors.NewAggregate([]error{retErr, err})
+		}
+	}()
+
+	event.Virtual.Annotations = event.Host.Annotations
+	event.Virtual.Labels = event.Host.Labels
+	event.Virtual.Spec = *event.Host.Spec.DeepCopy()
+	event.Virtual.Status = *event.Host.Status.DeepCopy()
+	return ctrl.Result{}, nil
+}
+
+func (s *gatewayClassSyncer) SyncToHost(ctx *synccontext.SyncContext, event *synccontext.SyncToHostEvent[*gatewayv1.GatewayClass]) (ctrl.Result, error) {
+	ctx.Log.Infof("delete virtual gateway class %s, because physical object is missing", event.Virtual.Name)
+	return ctrl.Result{}, ctx.VirtualClient.Delete(ctx, event.Virtual)
+}
@@ -0,0 +1,105 @@
+package gateways
+
+import (
+	"fmt"
+
+	"github.com/loft-sh/vcluster/pkg/mappings"
+	"github.com/loft-sh/vcluster/pkg/patcher"
+	"github.com/loft-sh/vcluster/pkg/syncer"
+	"github.com/loft-sh/vcluster/pkg/syncer/synccontext"
+	syncertypes "github.com/loft-sh/vcluster/pkg/syncer/types"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func New(ctx *synccontext.RegisterContext) (syncertypes.Object, error) {
+	mapper, err := ctx.Mappings.ByGVK(mappings.Gateways())
+	if err != nil {
+		return nil, err
+	}
+
+	return &gatewaySyncer{
+		Mapper: mapper,
+	}, nil
+}
+
+type gatewaySyncer struct {
+	synccontext.Mapper
+}
+
+func (s *gatewaySyncer) Name() string {
+	return "gateway"
+}
+
+func (s *gatewaySyncer) Resource() client.Object {
+	return &gatewayv1.Gateway{}
+}
+
+var _ syncertypes.Syncer = &gatewaySyncer{}
+
+func (s *gatewaySyncer) Syncer() syncertypes.Sync[client.Object] {
+	return syncer.ToGenericSyncer[*gatewayv1.Gateway](s)
+}
+
+func (s *gatewaySyncer) SyncToVirtual(ctx *synccontext.SyncContext, event *synccontext.SyncToVirtualEvent[*gatewayv1.Gateway]) (ctrl.Result, error) {
+	err := ensureVirtualNamespace(ctx, event.Host.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	vObj := translate.CopyObjectWithName(event.Host, types.NamespacedName{Name: event.Host.Name, Namespace: event.Host.Namespace}, false)
+	ctx.Log.Infof("create gateway %s/%s, because it does not exist in virtual cluster", vObj.Namespace, vObj.Name)
+	return ctrl.Result{}, ctx.VirtualClient.Create(ctx, vObj)
+}
+
+func (s *gatewaySyncer) Sync(ctx *synccontext.SyncContext, event *synccontext.SyncEvent[*gatewayv1.Gateway]) (_ ctrl.Result, retErr error) {
+	patch, err := patcher.NewSyncerPatcher(ctx, event.Host, event.Virtual)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("new syncer patcher: %w", err)
+	}
+	defer func() {
+		if err := patch.Patch(ctx, event.Host, event.Virtual); err != nil {
+			retErr = utilerrors.NewAggregate([]error{retErr, err})
+		}
+	}()
+
+	event.Virtual.Annotations = event.Host.Annotations
+	event.Virtual.Labels = event.Host.Labels
+	event.Virtual.Spec = *event.Host.Spec.DeepCopy()
+	event.Virtual.Status = *event.Host.Status.DeepCopy()
+	return ctrl.Result{}, nil
+}
+
+func (s *gatewaySyncer) SyncToHost(ctx *synccontext.SyncContext, event *synccontext.SyncToHostEvent[*gatewayv1.Gateway]) (ctrl.Result, error) {
+	// gateways are only synced from the host cluster, so if this is called
+	// the gateway was deleted in the host cluster or created within the
+	// virtual cluster and should be deleted to avoid confusion
+	ctx.Log.Infof("delete virtual gateway %s/%s, because physical object is missing", event.Virtual.Namespace, event.Virtual.Name)
+	return ctrl.Result{}, ctx.VirtualClient.Delete(ctx, event.Virtual)
+}
+
+func ensureVirtualNamespace(ctx *synccontext.SyncContext, namespace string) error {
+	err := ctx.VirtualClient.Get(ctx, types.NamespacedName{Name: namespace}, &corev1.Namespace{})
+	if err == nil || !kerrors.IsNotFound(err) {
+		return err
+	}
+
+	ctx.Log.Infof("create namespace %s, because it does not exist in virtual cluster", namespace)
+	err = ctx.VirtualClient.Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: namespace,
+		},
+	})
+	if err != nil && !kerrors.IsAlreadyExists(err) {
+		return err
+	}
+
+	return nil
+}
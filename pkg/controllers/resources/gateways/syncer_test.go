@@ -0,0 +1,145 @@
+package gateways
+
+import (
+	"testing"
+
+	"github.com/loft-sh/vcluster/pkg/config"
+	"github.com/loft-sh/vcluster/pkg/syncer/synccontext"
+	syncertesting "github.com/loft-sh/vcluster/pkg/syncer/testing"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestSync(t *testing.T) {
+	pObj := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "shared-gateway",
+			Namespace: "gateway-system",
+		},
+		Spec: gatewayv1.GatewaySpec{
+			GatewayClassName: "test-class",
+			Listeners: []gatewayv1.Listener{
+				{
+					Name:     "http",
+					Port:     80,
+					Protocol: gatewayv1.HTTPProtocolType,
+				},
+			},
+		},
+	}
+	vObj := pObj.DeepCopy()
+
+	pObjUpdated := pObj.DeepCopy()
+	pObjUpdated.Status = gatewayv1.GatewayStatus{
+		Conditions: []metav1.Condition{
+			{
+				Type:   string(gatewayv1.GatewayConditionProgrammed),
+				Status: metav1.ConditionTrue,
+				Reason: string(gatewayv1.GatewayReasonProgrammed),
+			},
+		},
+	}
+	vObjUpdated := vObj.DeepCopy()
+	vObjUpdated.Status = pObjUpdated.Status
+
+	vNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "gateway-system",
+		},
+	}
+
+	adjustConfig := func(vConfig *config.VirtualClusterConfig) {
+		vConfig.Sync.FromHost.Gateways.Enabled = true
+		vConfig.Sync.FromHost.Gateways.Namespaces = []string{"gateway-system"}
+	}
+
+	otherNamespaceObj := pObj.DeepCopy()
+	otherNamespaceObj.Namespace = "other"
+	labeledObj := pObj.DeepCopy()
+	labeledObj.Labels = map[string]string{"shared": "true"}
+
+	syncertesting.RunTests(t, []*syncertesting.SyncTest{
+		{
+			Name:                 "Sync Up",
+			AdjustConfig:         adjustConfig,
+			InitialVirtualState:  []runtime.Object{},
+			InitialPhysicalState: []runtime.Object{pObj},
+			ExpectedVirtualState: map[schema.GroupVersionKind][]runtime.Object{
+				corev1.SchemeGroupVersion.WithKind("Namespace"):  {vNamespace},
+				gatewayv1.SchemeGroupVersion.WithKind("Gateway"): {vObj},
+			},
+			ExpectedPhysicalState: map[schema.GroupVersionKind][]runtime.Object{
+				gatewayv1.SchemeGroupVersion.WithKind("Gateway"): {pObj},
+			},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := syncertesting.FakeStartSyncer(t, ctx, New)
+				_, err := syncer.(*gatewaySyncer).SyncToVirtual(syncCtx, synccontext.NewSyncToVirtualEvent(pObj))
+				assert.NilError(t, err)
+			},
+		},
+		{
+			Name:                  "Sync Down",
+			AdjustConfig:          adjustConfig,
+			InitialVirtualState:   []runtime.Object{vObj},
+			ExpectedVirtualState:  map[schema.GroupVersionKind][]runtime.Object{},
+			ExpectedPhysicalState: map[schema.GroupVersionKind][]runtime.Object{},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := syncertesting.FakeStartSyncer(t, ctx, New)
+				_, err := syncer.(*gatewaySyncer).SyncToHost(syncCtx, synccontext.NewSyncToHostEvent(vObj))
+				assert.NilError(t, err)
+			},
+		},
+		{
+			Name:                 "Sync",
+			AdjustConfig:         adjustConfig,
+			InitialVirtualState:  []runtime.Object{vObj},
+			InitialPhysicalState: []runtime.Object{pObjUpdated},
+			ExpectedVirtualState: map[schema.GroupVersionKind][]runtime.Object{
+				gatewayv1.SchemeGroupVersion.WithKind("Gateway"): {vObjUpdated},
+			},
+			ExpectedPhysicalState: map[schema.GroupVersionKind][]runtime.Object{
+				gatewayv1.SchemeGroupVersion.WithKind("Gateway"): {pObjUpdated},
+			},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := syncertesting.FakeStartSyncer(t, ctx, New)
+				_, err := syncer.(*gatewaySyncer).Sync(syncCtx, synccontext.NewSyncEvent(pObjUpdated, vObj))
+				assert.NilError(t, err)
+			},
+		},
+		{
+			Name:         "Is managed",
+			AdjustConfig: adjustConfig,
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := syncertesting.FakeStartSyncer(t, ctx, New)
+				managed, err := syncer.(*gatewaySyncer).IsManaged(syncCtx, pObj)
+				assert.NilError(t, err)
+				assert.Assert(t, managed)
+
+				managed, err = syncer.(*gatewaySyncer).IsManaged(syncCtx, otherNamespaceObj)
+				assert.NilError(t, err)
+				assert.Assert(t, !managed)
+			},
+		},
+		{
+			Name: "Is managed with selector",
+			AdjustConfig: func(vConfig *config.VirtualClusterConfig) {
+				adjustConfig(vConfig)
+				vConfig.Sync.FromHost.Gateways.Selector = map[string]string{"shared": "true"}
+			},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := syncertesting.FakeStartSyncer(t, ctx, New)
+				managed, err := syncer.(*gatewaySyncer).IsManaged(syncCtx, labeledObj)
+				assert.NilError(t, err)
+				assert.Assert(t, managed)
+
+				managed, err = syncer.(*gatewaySyncer).IsManaged(syncCtx, pObj)
+				assert.NilError(t, err)
+				assert.Assert(t, !managed)
+			},
+		},
+	})
+}
@@ -0,0 +1,137 @@
+package gateways
+
+import (
+	"github.com/loft-sh/vcluster/pkg/mappings"
+	"github.com/loft-sh/vcluster/pkg/syncer/synccontext"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// TranslateParentRefs translates the parent references of a route into the host cluster. Gateways are shared
+// host objects and are referenced by their original name, while other parents (e.g. services for mesh routes)
+// are translated to their host name.
+func TranslateParentRefs(ctx *synccontext.SyncContext, vNamespace string, parentRefs []gatewayv1.ParentReference) []gatewayv1.ParentReference {
+	if parentRefs == nil {
+		return nil
+	}
+
+	retRefs := make([]gatewayv1.ParentReference, 0, len(parentRefs))
+	for _, parentRef := range parentRefs {
+		retRefs = append(retRefs, translateParentRef(ctx, vNamespace, parentRef))
+	}
+
+	return retRefs
+}
+
+// TranslateRouteStatus translates the status of a host route back into the virtual cluster by replacing
+// the parent references with the ones found in the virtual route.
+func TranslateRouteStatus(ctx *synccontext.SyncContext, vNamespace string, vParentRefs []gatewayv1.ParentReference, pStatus *gatewayv1.RouteStatus) gatewayv1.RouteStatus {
+	retStatus := pStatus.DeepCopy()
+	for i := range retStatus.Parents {
+		for _, vParentRef := range vParentRefs {
+			if parentRefEqual(translateParentRef(ctx, vNamespace, vParentRef), retStatus.Parents[i].ParentRef, translate.Default.HostNamespace(vNamespace)) {
+				retStatus.Parents[i].ParentRef = vParentRef
+				break
+			}
+		}
+	}
+
+	return *retStatus
+}
+
+// TranslateBackendObjectReference translates a backend reference of a route into the host cluster.
+func TranslateBackendObjectReference(ctx *synccontext.SyncContext, vNamespace string, ref gatewayv1.BackendObjectReference) gatewayv1.BackendObjectReference {
+	retRef := *ref.DeepCopy()
+	namespace := vNamespace
+	if retRef.Namespace != nil && *retRef.Namespace != "" {
+		namespace = string(*retRef.Namespace)
+	}
+
+	pName := translate.Default.HostName(string(retRef.Name), namespace)
+	if backendGroupKind(retRef.Group, retRef.Kind) == serviceGroupKind {
+		pName = mappings.VirtualToHostName(ctx, string(retRef.Name), namespace, mappings.Services())
+	}
+
+	retRef.Name = gatewayv1.ObjectName(pName)
+	retRef.Namespace = hostNamespaceRef(retRef.Namespace, translate.Default.HostNamespace(namespace), translate.Default.HostNamespace(vNamespace))
+	return retRef
+}
+
+// TranslateLocalObjectReference translates a local reference (e.g. an extension filter) of a route into the host cluster.
+func TranslateLocalObjectReference(vNamespace string, ref gatewayv1.LocalObjectReference) gatewayv1.LocalObjectReference {
+	ref.Name = gatewayv1.ObjectName(translate.Default.HostName(string(ref.Name), vNamespace))
+	return ref
+}
+
+func translateParentRef(ctx *synccontext.SyncContext, vNamespace string, parentRef gatewayv1.ParentReference) gatewayv1.ParentReference {
+	retRef := *parentRef.DeepCopy()
+	namespace := vNamespace
+	if retRef.Namespace != nil && *retRef.Namespace != "" {
+		namespace = string(*retRef.Namespace)
+	}
+
+	var pName, pNamespace string
+	switch parentGroupKind(retRef.Group, retRef.Kind) {
+	case gatewayGroupKind:
+		hostGateway := mappings.VirtualToHost(ctx, string(retRef.Name), namespace, mappings.Gateways())
+		pName, pNamespace = hostGateway.Name, hostGateway.Namespace
+	case serviceGroupKind:
+		hostService := mappings.VirtualToHost(ctx, string(retRef.Name), namespace, mappings.Services())
+		pName, pNamespace = hostService.Name, hostService.Namespace
+	default:
+		pName, pNamespace = translate.Default.HostName(string(retRef.Name), namespace), translate.Default.HostNamespace(namespace)
+	}
+
+	retRef.Name = gatewayv1.ObjectName(pName)
+	retRef.Namespace = hostNamespaceRef(retRef.Namespace, pNamespace, translate.Default.HostNamespace(vNamespace))
+	return retRef
+}
+
+// hostNamespaceRef only sets the namespace of a reference if it was set before or
+// if the referenced object lives in another host namespace than the route itself.
+func hostNamespaceRef(vNamespaceRef *gatewayv1.Namespace, pNamespace, pRouteNamespace string) *gatewayv1.Namespace {
+	if vNamespaceRef == nil && pNamespace == pRouteNamespace {
+		return nil
+	}
+
+	namespace := gatewayv1.Namespace(pNamespace)
+	return &namespace
+}
+
+func parentRefEqual(a, b gatewayv1.ParentReference, defaultNamespace string) bool {
+	return parentGroupKind(a.Group, a.Kind) == parentGroupKind(b.Group, b.Kind) &&
+		a.Name == b.Name &&
+		valueOrDefault(a.Namespace, gatewayv1.Namespace(defaultNamespace)) == valueOrDefault(b.Namespace, gatewayv1.Namespace(defaultNamespace)) &&
+		valueOrDefault(a.SectionName, "") == valueOrDefault(b.SectionName, "") &&
+		valueOrDefault(a.Port, 0) == valueOrDefault(b.Port, 0)
+}
+
+var (
+	gatewayGroupKind = schema.GroupKind{Group: gatewayv1.GroupName, Kind: "Gateway"}
+	serviceGroupKind = schema.GroupKind{Kind: "Service"}
+)
+
+// parentGroupKind returns the group kind of a parent reference, which defaults to a gateway
+func parentGroupKind(group *gatewayv1.Group, kind *gatewayv1.Kind) schema.GroupKind {
+	return schema.GroupKind{
+		Group: string(valueOrDefault(group, gatewayv1.GroupName)),
+		Kind:  string(valueOrDefault(kind, "Gateway")),
+	}
+}
+
+// backendGroupKind returns the group kind of a backend reference, which defaults to a service
+func backendGroupKind(group *gatewayv1.Group, kind *gatewayv1.Kind) schema.GroupKind {
+	return schema.GroupKind{
+		Group: string(valueOrDefault(group, "")),
+		Kind:  string(valueOrDefault(kind, "Service")),
+	}
+}
+
+func valueOrDefault[T comparable](value *T, defaultValue T) T {
+	if value == nil {
+		return defaultValue
+	}
+
+	return *value
+}
@@ -0,0 +1,48 @@
+package grpcroutes
+
+import (
+	"github.com/loft-sh/vcluster/pkg/controllers/resources/gateways"
+	"github.com/loft-sh/vcluster/pkg/syncer/synccontext"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func (s *grpcRouteSyncer) translate(ctx *synccontext.SyncContext, vObj *gatewayv1.GRPCRoute) *gatewayv1.GRPCRoute {
+	newRoute := translate.HostMetadata(ctx, vObj, s.VirtualToHost(ctx, types.NamespacedName{Name: vObj.GetName(), Namespace: vObj.GetNamespace()}, vObj))
+	newRoute.Spec = *translateSpec(ctx, vObj.Namespace, &vObj.Spec)
+	newRoute.Status = gatewayv1.GRPCRouteStatus{}
+	return newRoute
+}
+
+func (s *grpcRouteSyncer) translateUpdate(ctx *synccontext.SyncContext, pObj, vObj *gatewayv1.GRPCRoute) {
+	pObj.Annotations = translate.HostAnnotations(vObj, pObj)
+	pObj.Labels = translate.HostLabels(ctx, vObj, pObj)
+	pObj.Spec = *translateSpec(ctx, vObj.Namespace, &vObj.Spec)
+}
+
+func translateSpec(ctx *synccontext.SyncContext, namespace string, vSpec *gatewayv1.GRPCRouteSpec) *gatewayv1.GRPCRouteSpec {
+	retSpec := vSpec.DeepCopy()
+	retSpec.ParentRefs = gateways.TranslateParentRefs(ctx, namespace, retSpec.ParentRefs)
+	for i, rule := range retSpec.Rules {
+		translateFilters(ctx, namespace, rule.Filters)
+		for j, backendRef := range rule.BackendRefs {
+			retSpec.Rules[i].BackendRefs[j].BackendObjectReference = gateways.TranslateBackendObjectReference(ctx, namespace, backendRef.BackendObjectReference)
+			translateFilters(ctx, namespace, backendRef.Filters)
+		}
+	}
+
+	return retSpec
+}
+
+func translateFilters(ctx *synccontext.SyncContext, namespace string, filters []gatewayv1.GRPCRouteFilter) {
+	for i, filter := range filters {
+		if filter.RequestMirror != nil {
+			filters[i].RequestMirror.BackendRef = gateways.TranslateBackendObjectReference(ctx, namespace, filter.RequestMirror.BackendRef)
+		}
+		if filter.ExtensionRef != nil {
+			translatedRef := gateways.TranslateLocalObjectReference(namespace, *filter.ExtensionRef)
+			filters[i].ExtensionRef = &translatedRef
+		}
+	}
+}
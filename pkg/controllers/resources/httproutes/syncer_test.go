@@ -0,0 +1,185 @@
+package httproutes
+
+import (
+	"testing"
+
+	"github.com/loft-sh/vcluster/pkg/config"
+	"github.com/loft-sh/vcluster/pkg/syncer/synccontext"
+	syncertesting "github.com/loft-sh/vcluster/pkg/syncer/testing"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestSync(t *testing.T) {
+	gatewayNamespace := gatewayv1.Namespace("gateway-system")
+	vObjectMeta := metav1.ObjectMeta{
+		Name:      "testroute",
+		Namespace: "test",
+	}
+	pObjectMeta := metav1.ObjectMeta{
+		Name:      translate.Default.HostName("testroute", "test"),
+		Namespace: "test",
+		Annotations: map[string]string{
+			translate.NameAnnotation:      vObjectMeta.Name,
+			translate.NamespaceAnnotation: vObjectMeta.Namespace,
+			translate.UIDAnnotation:       "",
+			translate.KindAnnotation:      gatewayv1.SchemeGroupVersion.WithKind("HTTPRoute").String(),
+		},
+		Labels: map[string]string{
+			translate.MarkerLabel:    translate.VClusterName,
+			translate.NamespaceLabel: vObjectMeta.Namespace,
+		},
+	}
+	vParentRefs := []gatewayv1.ParentReference{
+		{
+			Name:      "shared-gateway",
+			Namespace: &gatewayNamespace,
+		},
+	}
+	vSpec := gatewayv1.HTTPRouteSpec{
+		CommonRouteSpec: gatewayv1.CommonRouteSpec{
+			ParentRefs: vParentRefs,
+		},
+		Hostnames: []gatewayv1.Hostname{"test.example.com"},
+		Rules: []gatewayv1.HTTPRouteRule{
+			{
+				Filters: []gatewayv1.HTTPRouteFilter{
+					{
+						Type: gatewayv1.HTTPRouteFilterRequestMirror,
+						RequestMirror: &gatewayv1.HTTPRequestMirrorFilter{
+							BackendRef: gatewayv1.BackendObjectReference{
+								Name: "mirrorservice",
+							},
+						},
+					},
+				},
+				BackendRefs: []gatewayv1.HTTPBackendRef{
+					{
+						BackendRef: gatewayv1.BackendRef{
+							BackendObjectReference: gatewayv1.BackendObjectReference{
+								Name: "testservice",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	pSpec := gatewayv1.HTTPRouteSpec{
+		CommonRouteSpec: gatewayv1.CommonRouteSpec{
+			ParentRefs: vParentRefs,
+		},
+		Hostnames: []gatewayv1.Hostname{"test.example.com"},
+		Rules: []gatewayv1.HTTPRouteRule{
+			{
+				Filters: []gatewayv1.HTTPRouteFilter{
+					{
+						Type: gatewayv1.HTTPRouteFilterRequestMirror,
+						RequestMirror: &gatewayv1.HTTPRequestMirrorFilter{
+							BackendRef: gatewayv1.BackendObjectReference{
+								Name: gatewayv1.ObjectName(translate.Default.HostName("mirrorservice", "test")),
+							},
+						},
+					},
+				},
+				BackendRefs: []gatewayv1.HTTPBackendRef{
+					{
+						BackendRef: gatewayv1.BackendRef{
+							BackendObjectReference: gatewayv1.BackendObjectReference{
+								Name: gatewayv1.ObjectName(translate.Default.HostName("testservice", "test")),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	gatewayGroup := gatewayv1.Group(gatewayv1.GroupName)
+	gatewayKind := gatewayv1.Kind("Gateway")
+	hostStatus := gatewayv1.HTTPRouteStatus{
+		RouteStatus: gatewayv1.RouteStatus{
+			Parents: []gatewayv1.RouteParentStatus{
+				{
+					ParentRef: gatewayv1.ParentReference{
+						Group:     &gatewayGroup,
+						Kind:      &gatewayKind,
+						Name:      "shared-gateway",
+						Namespace: &gatewayNamespace,
+					},
+					ControllerName: "example.com/gateway-controller",
+					Conditions: []metav1.Condition{
+						{
+							Type:   string(gatewayv1.RouteConditionAccepted),
+							Status: metav1.ConditionTrue,
+							Reason: string(gatewayv1.RouteReasonAccepted),
+						},
+					},
+				},
+			},
+		},
+	}
+	virtualStatus := *hostStatus.DeepCopy()
+	virtualStatus.Parents[0].ParentRef = vParentRefs[0]
+
+	vRoute := &gatewayv1.HTTPRoute{
+		ObjectMeta: vObjectMeta,
+		Spec:       vSpec,
+	}
+	pRoute := &gatewayv1.HTTPRoute{
+		ObjectMeta: pObjectMeta,
+		Spec:       pSpec,
+	}
+	pRouteWithStatus := pRoute.DeepCopy()
+	pRouteWithStatus.Status = hostStatus
+	vRouteWithStatus := vRoute.DeepCopy()
+	vRouteWithStatus.Status = virtualStatus
+
+	adjustConfig := func(vConfig *config.VirtualClusterConfig) {
+		vConfig.Sync.ToHost.Gateway.Enabled = true
+	}
+
+	syncertesting.RunTests(t, []*syncertesting.SyncTest{
+		{
+			Name:                 "Create forward",
+			AdjustConfig:         adjustConfig,
+			InitialVirtualState:  []runtime.Object{vRoute.DeepCopy()},
+			InitialPhysicalState: []runtime.Object{},
+			ExpectedVirtualState: map[schema.GroupVersionKind][]runtime.Object{
+				gatewayv1.SchemeGroupVersion.WithKind("HTTPRoute"): {vRoute.DeepCopy()},
+			},
+			ExpectedPhysicalState: map[schema.GroupVersionKind][]runtime.Object{
+				gatewayv1.SchemeGroupVersion.WithKind("HTTPRoute"): {pRoute.DeepCopy()},
+			},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := syncertesting.FakeStartSyncer(t, ctx, New)
+				_, err := syncer.(*httpRouteSyncer).SyncToHost(syncCtx, synccontext.NewSyncToHostEvent(vRoute.DeepCopy()))
+				assert.NilError(t, err)
+			},
+		},
+		{
+			Name:                 "Sync status backwards",
+			AdjustConfig:         adjustConfig,
+			InitialVirtualState:  []runtime.Object{vRoute.DeepCopy()},
+			InitialPhysicalState: []runtime.Object{pRouteWithStatus.DeepCopy()},
+			ExpectedVirtualState: map[schema.GroupVersionKind][]runtime.Object{
+				gatewayv1.SchemeGroupVersion.WithKind("HTTPRoute"): {vRouteWithStatus.DeepCopy()},
+			},
+			ExpectedPhysicalState: map[schema.GroupVersionKind][]runtime.Object{
+				gatewayv1.SchemeGroupVersion.WithKind("HTTPRoute"): {pRouteWithStatus.DeepCopy()},
+			},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := syncertesting.FakeStartSyncer(t, ctx, New)
+				vRoute := vRoute.DeepCopy()
+				vRoute.ResourceVersion = syncertesting.FakeClientResourceVersion
+				pRoute := pRouteWithStatus.DeepCopy()
+				pRoute.ResourceVersion = syncertesting.FakeClientResourceVersion
+				_, err := syncer.(*httpRouteSyncer).Sync(syncCtx, synccontext.NewSyncEvent(pRoute, vRoute))
+				assert.NilError(t, err)
+			},
+		},
+	})
+}
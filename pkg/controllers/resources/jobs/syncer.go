@@ -0,0 +1,160 @@
+package jobs
+
+import (
+	"fmt"
+
+	"github.com/loft-sh/vcluster/pkg/mappings"
+	"github.com/loft-sh/vcluster/pkg/patcher"
+	"github.com/loft-sh/vcluster/pkg/syncer"
+	"github.com/loft-sh/vcluster/pkg/syncer/synccontext"
+	"github.com/loft-sh/vcluster/pkg/syncer/translator"
+	syncertypes "github.com/loft-sh/vcluster/pkg/syncer/types"
+	"github.com/loft-sh/vcluster/pkg/util/toleration"
+	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	translatepods "github.com/loft-sh/vcluster/pkg/controllers/resources/pods/translate"
+)
+
+func New(ctx *synccontext.RegisterContext) (syncertypes.Object, error) {
+	mapper, err := ctx.Mappings.ByGVK(mappings.Jobs())
+	if err != nil {
+		return nil, err
+	}
+
+	// parse node selector
+	var nodeSelector map[string]string
+	if len(ctx.Config.Sync.FromHost.Nodes.Selector.Labels) > 0 {
+		nodeSelector = ctx.Config.Sync.FromHost.Nodes.Selector.Labels
+	}
+
+	// parse tolerations
+	var tolerations []corev1.Toleration
+	for _, t := range ctx.Config.Sync.ToHost.Pods.EnforceTolerations {
+		tol, err := toleration.ParseToleration(t)
+		if err == nil {
+			tolerations = append(tolerations, tol)
+		}
+	}
+
+	// create new namespaced translator
+	genericTranslator := translator.NewGenericTranslator(ctx, "job", &batchv1.Job{}, mapper)
+
+	// create pod translator for the job pod template
+	podTranslator, err := translatepods.NewTranslator(ctx, genericTranslator.EventRecorder())
+	if err != nil {
+		return nil, errors.Wrap(err, "create pod translator")
+	}
+
+	return &jobSyncer{
+		GenericTranslator: genericTranslator,
+
+		serviceName:   ctx.Config.WorkloadService,
+		podTranslator: podTranslator,
+		nodeSelector:  nodeSelector,
+		tolerations:   tolerations,
+		secretBackend: ctx.Config.Sync.ToHost.Secrets.Backend.Type,
+	}, nil
+}
+
+type jobSyncer struct {
+	syncertypes.GenericTranslator
+
+	serviceName   string
+	podTranslator translatepods.Translator
+	nodeSelector  map[string]string
+	tolerations   []corev1.Toleration
+	secretBackend string
+}
+
+var _ syncertypes.Syncer = &jobSyncer{}
+
+func (s *jobSyncer) Syncer() syncertypes.Sync[client.Object] {
+	return syncer.ToGenericSyncer[*batchv1.Job](s)
+}
+
+func (s *jobSyncer) SyncToHost(ctx *synccontext.SyncContext, event *synccontext.SyncToHostEvent[*batchv1.Job]) (ctrl.Result, error) {
+	// if the job was already started on the host cluster and the host job is gone, we delete the
+	// virtual job as well, as we would otherwise run the same job a second time.
+	if event.IsDelete() || event.Virtual.DeletionTimestamp != nil || event.Virtual.Status.StartTime != nil {
+		return syncer.DeleteVirtualObject(ctx, event.Virtual, "host object was deleted")
+	}
+
+	// secrets can only be mounted as volumes if the csi or kms secret backend is used
+	if translatepods.UsesSecretVolumeBackend(s.secretBackend) {
+		err := translatepods.ValidateSecretReferences(TemplatePod(event.Virtual), s.secretBackend)
+		if err != nil {
+			ctx.Log.Errorf("%s job creation not allowed: %v", event.Virtual.Name, err)
+			s.EventRecorder().Eventf(event.Virtual, "Warning", "SyncError", `Job %s is forbidden: %v`, event.Virtual.Name, err)
+			return ctrl.Result{}, nil
+		}
+	}
+
+	pObj, err := s.translate(ctx, event.Virtual)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return syncer.CreateHostObject(ctx, event.Virtual, pObj, s.EventRecorder())
+}
+
+func (s *jobSyncer) Sync(ctx *synccontext.SyncContext, event *synccontext.SyncEvent[*batchv1.Job]) (_ ctrl.Result, retErr error) {
+	// the service account token secret should be removed together with the host job
+	err := setSATokenSecretOwner(ctx, event.Host, event.Virtual)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	patch, err := patcher.NewSyncerPatcher(ctx, event.Host, event.Virtual)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("new syncer patcher: %w", err)
+	}
+	defer func() {
+		if err := patch.Patch(ctx, event.Host, event.Virtual); err != nil {
+			retErr = utilerrors.NewAggregate([]error{retErr, err})
+		}
+		if retErr != nil {
+			s.EventRecorder().Eventf(event.Virtual, "Warning", "SyncError", "Error syncing: %v", retErr)
+		}
+	}()
+
+	// the virtual job controller is disabled, so the host job status is the source of truth
+	event.Virtual.Status = *event.Host.Status.DeepCopy()
+	s.translateUpdate(ctx, event.Host, event.Virtual)
+	return ctrl.Result{}, nil
+}
+
+func (s *jobSyncer) SyncToVirtual(ctx *synccontext.SyncContext, event *synccontext.SyncToVirtualEvent[*batchv1.Job]) (_ ctrl.Result, retErr error) {
+	// virtual object is not here anymore, so we delete the host job including its pods
+	ctx.Log.Infof("delete host %s/%s, because virtual object was deleted", event.Host.Namespace, event.Host.Name)
+	err := ctx.PhysicalClient.Delete(ctx, event.Host, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if err != nil && !kerrors.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func setSATokenSecretOwner(ctx *synccontext.SyncContext, pJob, vJob *batchv1.Job) error {
+	secret, err := translatepods.GetSecretIfExists(ctx, ctx.PhysicalClient, vJob.Name, vJob.Namespace)
+	if err := translatepods.IgnoreAcceptableErrors(err); err != nil {
+		return err
+	} else if secret == nil {
+		return nil
+	}
+
+	for _, owner := range secret.OwnerReferences {
+		if owner.UID == pJob.UID {
+			return nil
+		}
+	}
+
+	secret.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(pJob, batchv1.SchemeGroupVersion.WithKind("Job"))}
+	return ctx.PhysicalClient.Update(ctx, secret)
+}
@@ -0,0 +1,410 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+
+	vclusterconfig "github.com/loft-sh/vcluster/config"
+	"github.com/loft-sh/vcluster/pkg/config"
+	podtranslate "github.com/loft-sh/vcluster/pkg/controllers/resources/pods/translate"
+	"github.com/loft-sh/vcluster/pkg/scheme"
+	"github.com/loft-sh/vcluster/pkg/specialservices"
+	"github.com/loft-sh/vcluster/pkg/syncer/synccontext"
+	syncertesting "github.com/loft-sh/vcluster/pkg/syncer/testing"
+	testingutil "github.com/loft-sh/vcluster/pkg/util/testing"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	"gotest.tools/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+func TestSync(t *testing.T) {
+	translate.Default = translate.NewSingleNamespaceTranslator(syncertesting.DefaultTestTargetNamespace)
+	specialservices.Default = specialservices.NewDefaultServiceSyncer()
+
+	vNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "testns",
+		},
+	}
+	// automount is disabled, as requesting the token needs a running virtual api server
+	vServiceAccount := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "default",
+			Namespace: vNamespace.Name,
+		},
+		AutomountServiceAccountToken: ptr.To(false),
+	}
+	pVClusterService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      syncertesting.DefaultTestVClusterServiceName,
+			Namespace: syncertesting.DefaultTestCurrentNamespace,
+		},
+		Spec: corev1.ServiceSpec{
+			ClusterIP: "1.2.3.4",
+		},
+	}
+	pDNSService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      translate.Default.HostName("kube-dns", "kube-system"),
+			Namespace: syncertesting.DefaultTestTargetNamespace,
+		},
+		Spec: corev1.ServiceSpec{
+			ClusterIP: "2.2.2.2",
+		},
+	}
+
+	vObjectMeta := metav1.ObjectMeta{
+		Name:      "testjob",
+		Namespace: vNamespace.Name,
+		UID:       "123",
+	}
+	pObjectMeta := metav1.ObjectMeta{
+		Name:      translate.Default.HostName("testjob", "testns"),
+		Namespace: syncertesting.DefaultTestTargetNamespace,
+		Annotations: map[string]string{
+			translate.NameAnnotation:      vObjectMeta.Name,
+			translate.NamespaceAnnotation: vObjectMeta.Namespace,
+			translate.UIDAnnotation:       string(vObjectMeta.UID),
+			translate.KindAnnotation:      batchv1.SchemeGroupVersion.WithKind("Job").String(),
+		},
+		Labels: map[string]string{
+			translate.MarkerLabel:    translate.VClusterName,
+			translate.NamespaceLabel: vObjectMeta.Namespace,
+		},
+	}
+	vJob := &batchv1.Job{
+		ObjectMeta: vObjectMeta,
+		Spec: batchv1.JobSpec{
+			Parallelism: ptr.To(int32(1)),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					batchv1.ControllerUidLabel: string(vObjectMeta.UID),
+				},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"app":                      "test",
+						batchv1.ControllerUidLabel: string(vObjectMeta.UID),
+						batchv1.JobNameLabel:       vObjectMeta.Name,
+						legacyControllerUIDLabel:   string(vObjectMeta.UID),
+						legacyJobNameLabel:         vObjectMeta.Name,
+					},
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{
+						{
+							Name:  "test",
+							Image: "busybox",
+						},
+					},
+				},
+			},
+		},
+	}
+	pJob := &batchv1.Job{
+		ObjectMeta: pObjectMeta,
+		Spec: batchv1.JobSpec{
+			Parallelism: ptr.To(int32(1)),
+		},
+	}
+	hostStatus := batchv1.JobStatus{
+		StartTime: ptr.To(metav1.Unix(1700000000, 0)),
+		Active:    1,
+		Ready:     ptr.To(int32(1)),
+		Conditions: []batchv1.JobCondition{
+			{
+				Type:   batchv1.JobSuspended,
+				Status: corev1.ConditionFalse,
+			},
+		},
+	}
+	pJobWithStatus := pJob.DeepCopy()
+	pJobWithStatus.Status = hostStatus
+	vJobWithStatus := vJob.DeepCopy()
+	vJobWithStatus.Status = hostStatus
+
+	vJobWithSecretEnv := vJob.DeepCopy()
+	vJobWithSecretEnv.Spec.Template.Spec.Containers[0].EnvFrom = []corev1.EnvFromSource{
+		{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "credentials"}}},
+	}
+
+	vJobWithDownwardAPI := vJob.DeepCopy()
+	vJobWithDownwardAPI.Spec.Template.Spec.Containers[0].Env = []corev1.EnvVar{
+		{Name: "POD_NAME", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"}}},
+		{Name: "POD_NAMESPACE", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"}}},
+		{Name: "POD_UID", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.uid"}}},
+	}
+	vJobWithDownwardAPI.Spec.Template.Spec.Volumes = []corev1.Volume{
+		{
+			Name: "podinfo",
+			VolumeSource: corev1.VolumeSource{
+				Projected: &corev1.ProjectedVolumeSource{
+					Sources: []corev1.VolumeProjection{{
+						DownwardAPI: &corev1.DownwardAPIProjection{
+							Items: []corev1.DownwardAPIVolumeFile{
+								{Path: "name", FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"}},
+								{Path: "namespace", FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"}},
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+
+	adjustConfig := func(vConfig *config.VirtualClusterConfig) {
+		vConfig.Sync.ToHost.Jobs.Enabled = true
+	}
+
+	syncertesting.RunTests(t, []*syncertesting.SyncTest{
+		{
+			Name:                 "Create forward",
+			AdjustConfig:         adjustConfig,
+			InitialVirtualState:  []runtime.Object{vNamespace.DeepCopy(), vServiceAccount.DeepCopy(), vJob.DeepCopy()},
+			InitialPhysicalState: []runtime.Object{pVClusterService.DeepCopy(), pDNSService.DeepCopy()},
+			ExpectedVirtualState: map[schema.GroupVersionKind][]runtime.Object{
+				batchv1.SchemeGroupVersion.WithKind("Job"):           {vJob.DeepCopy()},
+				corev1.SchemeGroupVersion.WithKind("ServiceAccount"): {vServiceAccount.DeepCopy()},
+			},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := syncertesting.FakeStartSyncer(t, ctx, New)
+				_, err := syncer.(*jobSyncer).SyncToHost(syncCtx, synccontext.NewSyncToHostEvent(vJob.DeepCopy()))
+				assert.NilError(t, err)
+
+				createdJob := &batchv1.Job{}
+				err = ctx.PhysicalManager.GetClient().Get(ctx, types.NamespacedName{Name: pObjectMeta.Name, Namespace: pObjectMeta.Namespace}, createdJob)
+				assert.NilError(t, err)
+				assert.DeepEqual(t, createdJob.Labels, pObjectMeta.Labels)
+				assert.DeepEqual(t, createdJob.Annotations, pObjectMeta.Annotations)
+				assert.Assert(t, createdJob.Spec.Selector == nil)
+				assert.Assert(t, createdJob.Spec.ManualSelector == nil)
+
+				// the host pods should not be picked up by the pod syncer
+				templateLabels := createdJob.Spec.Template.Labels
+				assert.Equal(t, templateLabels[translate.Default.HostLabel(syncCtx, "app")], "test")
+				assert.Equal(t, templateLabels[translate.NamespaceLabel], vObjectMeta.Namespace)
+				for _, label := range []string{translate.MarkerLabel, batchv1.ControllerUidLabel, batchv1.JobNameLabel, legacyControllerUIDLabel, legacyJobNameLabel} {
+					_, ok := templateLabels[label]
+					assert.Assert(t, !ok, "unexpected template label %s", label)
+				}
+				for _, annotation := range []string{podtranslate.NameAnnotation, podtranslate.UIDAnnotation} {
+					_, ok := createdJob.Spec.Template.Annotations[annotation]
+					assert.Assert(t, !ok, "unexpected template annotation %s", annotation)
+				}
+				assert.Equal(t, createdJob.Spec.Template.Annotations[podtranslate.NamespaceAnnotation], vObjectMeta.Namespace)
+
+				// the pod spec should be translated
+				templateSpec := createdJob.Spec.Template.Spec
+				assert.Equal(t, templateSpec.Hostname, "")
+				assert.Equal(t, templateSpec.RestartPolicy, corev1.RestartPolicyNever)
+				assert.Equal(t, *templateSpec.AutomountServiceAccountToken, false)
+				assert.Equal(t, len(templateSpec.HostAliases), 1)
+				assert.Equal(t, templateSpec.HostAliases[0].IP, pVClusterService.Spec.ClusterIP)
+			},
+		},
+		{
+			Name:                 "Translate downward api",
+			AdjustConfig:         adjustConfig,
+			InitialVirtualState:  []runtime.Object{vNamespace.DeepCopy(), vServiceAccount.DeepCopy(), vJobWithDownwardAPI.DeepCopy()},
+			InitialPhysicalState: []runtime.Object{pVClusterService.DeepCopy(), pDNSService.DeepCopy()},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := syncertesting.FakeStartSyncer(t, ctx, New)
+				_, err := syncer.(*jobSyncer).SyncToHost(syncCtx, synccontext.NewSyncToHostEvent(vJobWithDownwardAPI.DeepCopy()))
+				assert.NilError(t, err)
+
+				createdJob := &batchv1.Job{}
+				err = ctx.PhysicalManager.GetClient().Get(ctx, types.NamespacedName{Name: pObjectMeta.Name, Namespace: pObjectMeta.Namespace}, createdJob)
+				assert.NilError(t, err)
+
+				// name and uid refer to the host pod, the namespace to the virtual one
+				namespacePath := "metadata.annotations['" + podtranslate.NamespaceAnnotation + "']"
+				envFieldPaths := map[string]string{}
+				for _, env := range createdJob.Spec.Template.Spec.Containers[0].Env {
+					if env.ValueFrom != nil && env.ValueFrom.FieldRef != nil {
+						envFieldPaths[env.Name] = env.ValueFrom.FieldRef.FieldPath
+					}
+				}
+				assert.Equal(t, envFieldPaths["POD_NAME"], "metadata.name")
+				assert.Equal(t, envFieldPaths["POD_NAMESPACE"], namespacePath)
+				assert.Equal(t, envFieldPaths["POD_UID"], "metadata.uid")
+				items := createdJob.Spec.Template.Spec.Volumes[0].Projected.Sources[0].DownwardAPI.Items
+				assert.Equal(t, items[0].FieldRef.FieldPath, "metadata.name")
+				assert.Equal(t, items[1].FieldRef.FieldPath, namespacePath)
+				assert.Equal(t, createdJob.Spec.Template.Annotations[podtranslate.NamespaceAnnotation], vObjectMeta.Namespace)
+			},
+		},
+		{
+			Name: "Reject secret environment variables with csi secret backend",
+			AdjustConfig: func(vConfig *config.VirtualClusterConfig) {
+				vConfig.Sync.ToHost.Jobs.Enabled = true
+				vConfig.Sync.ToHost.Secrets.Backend.Type = vclusterconfig.SecretBackendTypeCSI
+			},
+			InitialVirtualState:  []runtime.Object{vNamespace.DeepCopy(), vServiceAccount.DeepCopy(), vJobWithSecretEnv.DeepCopy()},
+			InitialPhysicalState: []runtime.Object{pVClusterService.DeepCopy(), pDNSService.DeepCopy()},
+			ExpectedPhysicalState: map[schema.GroupVersionKind][]runtime.Object{
+				batchv1.SchemeGroupVersion.WithKind("Job"): {},
+			},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := syncertesting.FakeStartSyncer(t, ctx, New)
+				_, err := syncer.(*jobSyncer).SyncToHost(syncCtx, synccontext.NewSyncToHostEvent(vJobWithSecretEnv.DeepCopy()))
+				assert.NilError(t, err)
+			},
+		},
+		{
+			Name:                 "Sync status backwards",
+			AdjustConfig:         adjustConfig,
+			InitialVirtualState:  []runtime.Object{vJob.DeepCopy()},
+			InitialPhysicalState: []runtime.Object{pJobWithStatus.DeepCopy()},
+			ExpectedVirtualState: map[schema.GroupVersionKind][]runtime.Object{
+				batchv1.SchemeGroupVersion.WithKind("Job"): {vJobWithStatus.DeepCopy()},
+			},
+			ExpectedPhysicalState: map[schema.GroupVersionKind][]runtime.Object{
+				batchv1.SchemeGroupVersion.WithKind("Job"): {pJobWithStatus.DeepCopy()},
+			},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := syncertesting.FakeStartSyncer(t, ctx, New)
+				vJob := vJob.DeepCopy()
+				vJob.ResourceVersion = syncertesting.FakeClientResourceVersion
+				pJob := pJobWithStatus.DeepCopy()
+				pJob.ResourceVersion = syncertesting.FakeClientResourceVersion
+				_, err := syncer.(*jobSyncer).Sync(syncCtx, synccontext.NewSyncEvent(pJob, vJob))
+				assert.NilError(t, err)
+			},
+		},
+		{
+			Name:                 "Update parallelism",
+			AdjustConfig:         adjustConfig,
+			InitialVirtualState:  []runtime.Object{vJob.DeepCopy()},
+			InitialPhysicalState: []runtime.Object{pJob.DeepCopy()},
+			ExpectedVirtualState: map[schema.GroupVersionKind][]runtime.Object{
+				batchv1.SchemeGroupVersion.WithKind("Job"): {vJob.DeepCopy()},
+			},
+			ExpectedPhysicalState: map[schema.GroupVersionKind][]runtime.Object{
+				batchv1.SchemeGroupVersion.WithKind("Job"): {
+					&batchv1.Job{
+						ObjectMeta: pObjectMeta,
+						Spec: batchv1.JobSpec{
+							Parallelism: ptr.To(int32(3)),
+							Suspend:     ptr.To(true),
+						},
+					},
+				},
+			},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := syncertesting.FakeStartSyncer(t, ctx, New)
+				vJob := vJob.DeepCopy()
+				vJob.ResourceVersion = syncertesting.FakeClientResourceVersion
+				vJob.Spec.Parallelism = ptr.To(int32(3))
+				vJob.Spec.Suspend = ptr.To(true)
+				pJob := pJob.DeepCopy()
+				pJob.ResourceVersion = syncertesting.FakeClientResourceVersion
+				_, err := syncer.(*jobSyncer).Sync(syncCtx, synccontext.NewSyncEvent(pJob, vJob))
+				assert.NilError(t, err)
+			},
+		},
+		{
+			Name:                  "Delete host job",
+			AdjustConfig:          adjustConfig,
+			InitialPhysicalState:  []runtime.Object{pJob.DeepCopy()},
+			ExpectedPhysicalState: map[schema.GroupVersionKind][]runtime.Object{},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := syncertesting.FakeStartSyncer(t, ctx, New)
+				_, err := syncer.(*jobSyncer).SyncToVirtual(syncCtx, synccontext.NewSyncToVirtualEvent(pJob.DeepCopy()))
+				assert.NilError(t, err)
+			},
+		},
+		{
+			Name:                 "Delete started virtual job",
+			AdjustConfig:         adjustConfig,
+			InitialVirtualState:  []runtime.Object{vJobWithStatus.DeepCopy()},
+			ExpectedVirtualState: map[schema.GroupVersionKind][]runtime.Object{},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := syncertesting.FakeStartSyncer(t, ctx, New)
+				_, err := syncer.(*jobSyncer).SyncToHost(syncCtx, synccontext.NewSyncToHostEvent(vJobWithStatus.DeepCopy()))
+				assert.NilError(t, err)
+			},
+		},
+	})
+}
+
+func TestInjectServiceAccountToken(t *testing.T) {
+	newPod := func(serviceAccountName string, volumeMounts ...corev1.VolumeMount) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "testjob", Namespace: "testns"},
+			Spec: corev1.PodSpec{
+				ServiceAccountName: serviceAccountName,
+				InitContainers:     []corev1.Container{{Name: "init"}},
+				Containers:         []corev1.Container{{Name: "test", VolumeMounts: volumeMounts}},
+			},
+		}
+	}
+	syncCtx := &synccontext.SyncContext{
+		Context: context.Background(),
+		VirtualClient: testingutil.NewFakeClient(scheme.Scheme,
+			&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "testns"}},
+			&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "no-token", Namespace: "testns"}, AutomountServiceAccountToken: ptr.To(false)},
+		),
+	}
+
+	// the token volume is mounted into all containers
+	vPod := newPod("")
+	assert.NilError(t, injectServiceAccountToken(syncCtx, vPod))
+	assert.Assert(t, hasServiceAccountToken(vPod))
+	assert.Equal(t, vPod.Spec.InitContainers[0].VolumeMounts[0].MountPath, serviceAccountTokenMountPath)
+	assert.Equal(t, vPod.Spec.Containers[0].VolumeMounts[0].MountPath, serviceAccountTokenMountPath)
+
+	// automount is disabled on the service account or the pod
+	vPod = newPod("no-token")
+	assert.NilError(t, injectServiceAccountToken(syncCtx, vPod))
+	assert.Assert(t, !hasServiceAccountToken(vPod))
+	vPod = newPod("")
+	vPod.Spec.AutomountServiceAccountToken = ptr.To(false)
+	assert.NilError(t, injectServiceAccountToken(syncCtx, vPod))
+	assert.Assert(t, !hasServiceAccountToken(vPod))
+
+	// user defined token mounts are kept
+	vPod = newPod("", corev1.VolumeMount{Name: "custom", MountPath: serviceAccountTokenMountPath})
+	assert.NilError(t, injectServiceAccountToken(syncCtx, vPod))
+	assert.Equal(t, len(vPod.Spec.Volumes), 0)
+
+	// the service account has to exist
+	assert.ErrorContains(t, injectServiceAccountToken(syncCtx, newPod("missing")), `serviceaccounts "missing" not found`)
+}
+
+func TestEnsureTokenBindingSecret(t *testing.T) {
+	vJob := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "testjob", Namespace: "testns", UID: "job-uid"}}
+	otherJob := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "otherjob", Namespace: "testns", UID: "other-uid"}}
+	ownedSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Name:            "testjob-token-binding",
+		Namespace:       "testns",
+		UID:             "secret-uid",
+		OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(vJob, batchv1.SchemeGroupVersion.WithKind("Job"))},
+	}}
+	forgedSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "otherjob-token-binding", Namespace: "testns"}}
+	syncCtx := &synccontext.SyncContext{
+		Context:       context.Background(),
+		VirtualClient: testingutil.NewFakeClient(scheme.Scheme, ownedSecret, forgedSecret),
+	}
+
+	// an existing secret controlled by the job is reused
+	secret, err := ensureTokenBindingSecret(syncCtx, vJob)
+	assert.NilError(t, err)
+	assert.Equal(t, secret.UID, types.UID("secret-uid"))
+
+	// a secret created in advance by someone else is rejected
+	_, err = ensureTokenBindingSecret(syncCtx, otherJob)
+	assert.ErrorContains(t, err, "token binding secret testns/otherjob-token-binding already exists and is not controlled by job otherjob")
+
+	// a new secret is created for other jobs
+	secret, err = ensureTokenBindingSecret(syncCtx, &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "newjob", Namespace: "testns", UID: "new-uid"}})
+	assert.NilError(t, err)
+	assert.Equal(t, metav1.GetControllerOf(secret).UID, types.UID("new-uid"))
+}
@@ -0,0 +1,332 @@
+package jobs
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/loft-sh/vcluster/pkg/controllers/resources/pods"
+	translatepods "github.com/loft-sh/vcluster/pkg/controllers/resources/pods/translate"
+	"github.com/loft-sh/vcluster/pkg/syncer/synccontext"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	legacyControllerUIDLabel = "controller-uid"
+	legacyJobNameLabel       = "job-name"
+
+	serviceAccountTokenVolumeName = "kube-api-access"
+	serviceAccountTokenMountPath  = "/var/run/secrets/kubernetes.io/serviceaccount"
+)
+
+func (s *jobSyncer) translate(ctx *synccontext.SyncContext, vJob *batchv1.Job) (*batchv1.Job, error) {
+	pJob := translate.HostMetadata(ctx, vJob, s.VirtualToHost(ctx, types.NamespacedName{Name: vJob.GetName(), Namespace: vJob.GetNamespace()}, vJob))
+	pJob.Status = batchv1.JobStatus{}
+
+	// the selector is generated by the host api server
+	pJob.Spec.Selector = nil
+	pJob.Spec.ManualSelector = nil
+
+	template, err := s.translatePodTemplate(ctx, vJob)
+	if err != nil {
+		return nil, err
+	}
+	pJob.Spec.Template = *template
+	return pJob, nil
+}
+
+func (s *jobSyncer) translateUpdate(ctx *synccontext.SyncContext, pJob, vJob *batchv1.Job) {
+	pJob.Annotations = translate.HostAnnotations(vJob, pJob)
+	pJob.Labels = translate.HostLabels(ctx, vJob, pJob)
+
+	// the pod template is immutable, so we only update the mutable fields
+	pJob.Spec.Parallelism = vJob.Spec.Parallelism
+	pJob.Spec.Suspend = vJob.Spec.Suspend
+	pJob.Spec.ActiveDeadlineSeconds = vJob.Spec.ActiveDeadlineSeconds
+}
+
+// TemplatePod builds a virtual pod from the pod template of the given job
+func TemplatePod(vJob *batchv1.Job) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        vJob.Name,
+			Namespace:   vJob.Namespace,
+			UID:         vJob.UID,
+			Labels:      stripJobLabels(vJob.Spec.Template.Labels),
+			Annotations: maps.Clone(vJob.Spec.Template.Annotations),
+		},
+		Spec: *vJob.Spec.Template.Spec.DeepCopy(),
+	}
+}
+
+func (s *jobSyncer) translatePodTemplate(ctx *synccontext.SyncContext, vJob *batchv1.Job) (*corev1.PodTemplateSpec, error) {
+	// build a virtual pod from the template, so we can reuse the pod translation
+	vPod := TemplatePod(vJob)
+
+	// job pods never pass the service account admission of the virtual cluster, so we add the token volume here
+	err := injectServiceAccountToken(ctx, vPod)
+	if err != nil {
+		return nil, err
+	}
+
+	// there is no virtual pod the tokens can be bound to, so we bind them to a secret owned by the job instead
+	if hasServiceAccountToken(vPod) {
+		secret, err := ensureTokenBindingSecret(ctx, vJob)
+		if err != nil {
+			return nil, err
+		}
+
+		if vPod.Annotations == nil {
+			vPod.Annotations = map[string]string{}
+		}
+		vPod.Annotations[translatepods.TokenBoundSecretAnnotation] = secret.Name
+		vPod.Annotations[translatepods.TokenBoundSecretUIDAnnotation] = string(secret.UID)
+	}
+
+	kubeIP, dnsIP, ptrServiceList, err := pods.GetK8sIPDNSIPServiceList(ctx, s.serviceName, vJob.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	pPod, err := s.podTranslator.Translate(ctx, vPod, ptrServiceList, dnsIP, kubeIP)
+	if err != nil {
+		return nil, err
+	}
+
+	// pods are created by the host job controller and have no virtual counterpart, so we make sure
+	// the pod syncer won't pick them up
+	delete(pPod.Labels, translate.MarkerLabel)
+	for _, annotation := range []string{
+		translatepods.NameAnnotation,
+		translatepods.UIDAnnotation,
+		translate.KindAnnotation,
+		translatepods.ClusterAutoScalerAnnotation,
+		translatepods.TokenBoundSecretAnnotation,
+		translatepods.TokenBoundSecretUIDAnnotation,
+	} {
+		delete(pPod.Annotations, annotation)
+	}
+
+	// the downward api references the virtual namespace through the namespace annotation, while name and uid
+	// of the pods are only known to the host, so we point them back to the host pod fields
+	if pPod.Annotations == nil {
+		pPod.Annotations = map[string]string{}
+	}
+	pPod.Annotations[translatepods.NamespaceAnnotation] = vJob.Namespace
+	restoreHostFieldRefs(&pPod.Spec)
+
+	// the host job controller will generate the hostname for each pod
+	pPod.Spec.Hostname = vJob.Spec.Template.Spec.Hostname
+
+	// ensure node selector & tolerations
+	if len(s.nodeSelector) > 0 && pPod.Spec.NodeName == "" {
+		if pPod.Spec.NodeSelector == nil {
+			pPod.Spec.NodeSelector = map[string]string{}
+		}
+		for k, v := range s.nodeSelector {
+			pPod.Spec.NodeSelector[k] = v
+		}
+	}
+	pPod.Spec.Tolerations = append(pPod.Spec.Tolerations, s.tolerations...)
+
+	return &corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      pPod.Labels,
+			Annotations: pPod.Annotations,
+		},
+		Spec: pPod.Spec,
+	}, nil
+}
+
+// restoreHostFieldRefs rewrites the downward api references to the name and uid annotations, which the
+// pod translator added, back to the fields of the host pod.
+func restoreHostFieldRefs(spec *corev1.PodSpec) {
+	for _, containers := range [][]corev1.Container{spec.InitContainers, spec.Containers} {
+		for i := range containers {
+			for j := range containers[i].Env {
+				if containers[i].Env[j].ValueFrom != nil {
+					restoreHostFieldRef(containers[i].Env[j].ValueFrom.FieldRef)
+				}
+			}
+		}
+	}
+
+	for _, volume := range spec.Volumes {
+		if volume.DownwardAPI != nil {
+			for i := range volume.DownwardAPI.Items {
+				restoreHostFieldRef(volume.DownwardAPI.Items[i].FieldRef)
+			}
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.DownwardAPI == nil {
+					continue
+				}
+				for i := range source.DownwardAPI.Items {
+					restoreHostFieldRef(source.DownwardAPI.Items[i].FieldRef)
+				}
+			}
+		}
+	}
+}
+
+func restoreHostFieldRef(fieldSelector *corev1.ObjectFieldSelector) {
+	if fieldSelector == nil {
+		return
+	}
+
+	switch fieldSelector.FieldPath {
+	case "metadata.annotations['" + translatepods.NameAnnotation + "']":
+		fieldSelector.FieldPath = "metadata.name"
+	case "metadata.annotations['" + translatepods.UIDAnnotation + "']":
+		fieldSelector.FieldPath = "metadata.uid"
+	}
+}
+
+// stripJobLabels removes the labels the virtual api server adds to the job pod template,
+// as the host api server will add its own ones.
+func stripJobLabels(labels map[string]string) map[string]string {
+	if labels == nil {
+		return nil
+	}
+
+	retLabels := map[string]string{}
+	for k, v := range labels {
+		if k == batchv1.ControllerUidLabel || k == batchv1.JobNameLabel || k == legacyControllerUIDLabel || k == legacyJobNameLabel {
+			continue
+		}
+
+		retLabels[k] = v
+	}
+
+	return retLabels
+}
+
+// injectServiceAccountToken adds the service account token volume to the pod the same way the service account
+// admission plugin of the virtual api server would.
+func injectServiceAccountToken(ctx *synccontext.SyncContext, vPod *corev1.Pod) error {
+	if vPod.Spec.AutomountServiceAccountToken != nil && !*vPod.Spec.AutomountServiceAccountToken {
+		return nil
+	}
+
+	serviceAccountName := vPod.Spec.ServiceAccountName
+	if serviceAccountName == "" {
+		serviceAccountName = "default"
+	}
+	vServiceAccount := &corev1.ServiceAccount{}
+	err := ctx.VirtualClient.Get(ctx, types.NamespacedName{Namespace: vPod.Namespace, Name: serviceAccountName}, vServiceAccount)
+	if err != nil {
+		return fmt.Errorf("get service account %s/%s: %w", vPod.Namespace, serviceAccountName, err)
+	} else if vPod.Spec.AutomountServiceAccountToken == nil && vServiceAccount.AutomountServiceAccountToken != nil && !*vServiceAccount.AutomountServiceAccountToken {
+		return nil
+	}
+
+	// don't override a token the user mounts on their own
+	for _, containers := range [][]corev1.Container{vPod.Spec.InitContainers, vPod.Spec.Containers} {
+		for _, container := range containers {
+			for _, volumeMount := range container.VolumeMounts {
+				if volumeMount.MountPath == serviceAccountTokenMountPath {
+					return nil
+				}
+			}
+		}
+	}
+
+	vPod.Spec.Volumes = append(vPod.Spec.Volumes, corev1.Volume{
+		Name: serviceAccountTokenVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{
+					{
+						ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+							Path:              "token",
+							ExpirationSeconds: ptr.To(int64(3607)),
+						},
+					},
+					{
+						ConfigMap: &corev1.ConfigMapProjection{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "kube-root-ca.crt"},
+							Items:                []corev1.KeyToPath{{Key: "ca.crt", Path: "ca.crt"}},
+						},
+					},
+					{
+						DownwardAPI: &corev1.DownwardAPIProjection{
+							Items: []corev1.DownwardAPIVolumeFile{
+								{
+									Path:     "namespace",
+									FieldRef: &corev1.ObjectFieldSelector{APIVersion: "v1", FieldPath: "metadata.namespace"},
+								},
+							},
+						},
+					},
+				},
+				DefaultMode: ptr.To(int32(0644)),
+			},
+		},
+	})
+	volumeMount := corev1.VolumeMount{Name: serviceAccountTokenVolumeName, ReadOnly: true, MountPath: serviceAccountTokenMountPath}
+	for i := range vPod.Spec.InitContainers {
+		vPod.Spec.InitContainers[i].VolumeMounts = append(vPod.Spec.InitContainers[i].VolumeMounts, volumeMount)
+	}
+	for i := range vPod.Spec.Containers {
+		vPod.Spec.Containers[i].VolumeMounts = append(vPod.Spec.Containers[i].VolumeMounts, volumeMount)
+	}
+
+	return nil
+}
+
+func hasServiceAccountToken(vPod *corev1.Pod) bool {
+	for _, volume := range vPod.Spec.Volumes {
+		if volume.Projected == nil {
+			continue
+		}
+
+		for _, source := range volume.Projected.Sources {
+			if source.ServiceAccountToken != nil {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// ensureTokenBindingSecret creates the virtual secret the service account tokens of the job pods are bound to. As the
+// secret is owned by the job, the tokens are invalidated as soon as the job is deleted. An existing secret is only
+// used if the job is its controller, as tokens bound to any other secret would outlive the job.
+func ensureTokenBindingSecret(ctx *synccontext.SyncContext, vJob *batchv1.Job) (*corev1.Secret, error) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      vJob.Name + "-token-binding",
+			Namespace: vJob.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(vJob, batchv1.SchemeGroupVersion.WithKind("Job")),
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+	}
+	err := ctx.VirtualClient.Create(ctx, secret)
+	if err == nil {
+		return secret, nil
+	} else if !kerrors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("create token binding secret %s/%s: %w", secret.Namespace, secret.Name, err)
+	}
+
+	existing := &corev1.Secret{}
+	err = ctx.VirtualClient.Get(ctx, types.NamespacedName{Namespace: secret.Namespace, Name: secret.Name}, existing)
+	if err != nil {
+		return nil, fmt.Errorf("get token binding secret %s/%s: %w", secret.Namespace, secret.Name, err)
+	}
+
+	owner := metav1.GetControllerOf(existing)
+	if owner == nil || owner.UID != vJob.UID {
+		return nil, fmt.Errorf("token binding secret %s/%s already exists and is not controlled by job %s", secret.Namespace, secret.Name, vJob.Name)
+	}
+
+	return existing, nil
+}
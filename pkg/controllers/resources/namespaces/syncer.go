@@ -3,14 +3,18 @@ package namespaces
 import (
 	"fmt"
 
+	"github.com/loft-sh/vcluster/pkg/constants"
 	"github.com/loft-sh/vcluster/pkg/mappings"
 	"github.com/loft-sh/vcluster/pkg/patcher"
 	"github.com/loft-sh/vcluster/pkg/syncer"
 	"github.com/loft-sh/vcluster/pkg/syncer/synccontext"
 	"github.com/loft-sh/vcluster/pkg/syncer/translator"
 	syncertypes "github.com/loft-sh/vcluster/pkg/syncer/types"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -25,8 +29,8 @@ var excludedAnnotations = []string{
 }
 
 const (
-	VClusterNameAnnotation      = "vcluster.loft.sh/vcluster-name"
-	VClusterNamespaceAnnotation = "vcluster.loft.sh/vcluster-namespace"
+	VClusterNameAnnotation      = translate.VClusterNameLabel
+	VClusterNamespaceAnnotation = translate.VClusterNamespaceLabel
 )
 
 func New(ctx *synccontext.RegisterContext) (syncertypes.Object, error) {
@@ -36,7 +40,7 @@ func New(ctx *synccontext.RegisterContext) (syncertypes.Object, error) {
 	}
 
 	namespaceLabels := map[string]string{}
-	for k, v := range ctx.Config.Experimental.MultiNamespaceMode.NamespaceLabels {
+	for k, v := range ctx.Config.Sync.ToHost.Namespaces.Labels {
 		namespaceLabels[k] = v
 	}
 	namespaceLabels[VClusterNameAnnotation] = ctx.Config.Name
@@ -48,7 +52,8 @@ func New(ctx *synccontext.RegisterContext) (syncertypes.Object, error) {
 
 		excludedAnnotations: excludedAnnotations,
 
-		namespaceLabels: namespaceLabels,
+		namespaceLabels:      namespaceLabels,
+		namespaceAnnotations: ctx.Config.Sync.ToHost.Namespaces.Annotations,
 	}, nil
 }
 
@@ -58,7 +63,8 @@ type namespaceSyncer struct {
 
 	excludedAnnotations []string
 
-	namespaceLabels map[string]string
+	namespaceLabels      map[string]string
+	namespaceAnnotations map[string]string
 }
 
 var _ syncertypes.Syncer = &namespaceSyncer{}
@@ -69,8 +75,14 @@ func (s *namespaceSyncer) Syncer() syncertypes.Sync[client.Object] {
 
 func (s *namespaceSyncer) SyncToHost(ctx *synccontext.SyncContext, event *synccontext.SyncToHostEvent[*corev1.Namespace]) (ctrl.Result, error) {
 	newNamespace := s.translate(ctx, event.Virtual)
+	err := s.checkCollision(ctx, event.Virtual, newNamespace.Name)
+	if err != nil {
+		s.EventRecorder().Eventf(event.Virtual, "Warning", "NamespaceCollision", "Error syncing: %v", err)
+		return ctrl.Result{}, err
+	}
+
 	ctx.Log.Infof("create physical namespace %s", newNamespace.Name)
-	err := ctx.PhysicalClient.Create(ctx, newNamespace)
+	err = ctx.PhysicalClient.Create(ctx, newNamespace)
 	if err != nil {
 		ctx.Log.Infof("error syncing %s to physical cluster: %v", event.Virtual.Name, err)
 		return ctrl.Result{}, err
@@ -100,6 +112,32 @@ func (s *namespaceSyncer) SyncToVirtual(ctx *synccontext.SyncContext, event *syn
 	return syncer.DeleteHostObject(ctx, event.Host, "virtual object was deleted")
 }
 
+// checkCollision makes sure that the host namespace is neither owned by someone else nor used by another virtual namespace
+func (s *namespaceSyncer) checkCollision(ctx *synccontext.SyncContext, vNamespace *corev1.Namespace, pName string) error {
+	pNamespace := &corev1.Namespace{}
+	err := ctx.PhysicalClient.Get(ctx, types.NamespacedName{Name: pName}, pNamespace)
+	if err == nil {
+		if pNamespace.Labels[VClusterNameAnnotation] != s.namespaceLabels[VClusterNameAnnotation] || pNamespace.Labels[VClusterNamespaceAnnotation] != s.namespaceLabels[VClusterNamespaceAnnotation] {
+			return fmt.Errorf("host namespace %s already exists and is not managed by this vCluster", pName)
+		}
+	} else if !kerrors.IsNotFound(err) {
+		return fmt.Errorf("get host namespace %s: %w", pName, err)
+	}
+
+	vNamespaces := &corev1.NamespaceList{}
+	err = ctx.VirtualClient.List(ctx, vNamespaces, client.MatchingFields{constants.IndexByPhysicalName: pName})
+	if err != nil {
+		return fmt.Errorf("list virtual namespaces: %w", err)
+	}
+	for _, other := range vNamespaces.Items {
+		if other.Name != vNamespace.Name {
+			return fmt.Errorf("virtual namespaces %s and %s map to the same host namespace %s", vNamespace.Name, other.Name, pName)
+		}
+	}
+
+	return nil
+}
+
 func (s *namespaceSyncer) EnsureWorkloadServiceAccount(ctx *synccontext.SyncContext, pNamespace string) error {
 	if s.workloadServiceAccountName == "" {
 		return nil
@@ -19,11 +19,26 @@ func (s *namespaceSyncer) translate(ctx *synccontext.SyncContext, vObj client.Ob
 		newNamespace.Labels[k] = v
 	}
 
+	// add user defined namespace annotations
+	if len(s.namespaceAnnotations) > 0 && newNamespace.Annotations == nil {
+		newNamespace.Annotations = map[string]string{}
+	}
+	for k, v := range s.namespaceAnnotations {
+		newNamespace.Annotations[k] = v
+	}
+
 	return newNamespace
 }
 
 func (s *namespaceSyncer) translateUpdate(ctx *synccontext.SyncContext, pObj, vObj *corev1.Namespace) {
 	pObj.Annotations = translate.HostAnnotations(vObj, pObj, s.excludedAnnotations...)
+	if len(s.namespaceAnnotations) > 0 && pObj.Annotations == nil {
+		pObj.Annotations = map[string]string{}
+	}
+	for k, v := range s.namespaceAnnotations {
+		pObj.Annotations[k] = v
+	}
+
 	updatedLabels := translate.HostLabels(ctx, vObj, pObj)
 	if updatedLabels == nil {
 		updatedLabels = map[string]string{}
@@ -102,6 +102,7 @@ func TestSync(t *testing.T) {
 				bindCompletedAnnotation:       "testannotation",
 				boundByControllerAnnotation:   "testannotation2",
 				storageProvisionerAnnotation:  "testannotation3",
+				storageResizerAnnotation:      "testannotation4",
 			},
 			Labels: pObjectMeta.Labels,
 		},
@@ -114,6 +115,7 @@ func TestSync(t *testing.T) {
 				bindCompletedAnnotation:      "testannotation",
 				boundByControllerAnnotation:  "testannotation2",
 				storageProvisionerAnnotation: "testannotation3",
+				storageResizerAnnotation:     "testannotation4",
 			},
 		},
 	}
@@ -124,6 +126,12 @@ func TestSync(t *testing.T) {
 		},
 		Status: corev1.PersistentVolumeClaimStatus{
 			AccessModes: []corev1.PersistentVolumeAccessMode{"testmode"},
+			Conditions: []corev1.PersistentVolumeClaimCondition{
+				{
+					Type:   corev1.PersistentVolumeClaimResizing,
+					Status: corev1.ConditionTrue,
+				},
+			},
 		},
 	}
 	backwardUpdatedStatusPvc := &corev1.PersistentVolumeClaim{
@@ -117,4 +117,17 @@ func (s *persistentVolumeClaimSyncer) translateUpdateBackwards(pObj, vObj *corev
 		}
 		vObj.Annotations[storageProvisionerAnnotation] = pObj.Annotations[storageProvisionerAnnotation]
 	}
+
+	// the resizer annotation shows which external resizer is handling a pending volume expansion,
+	// the resize conditions and allocated resources themselves are part of the copied status
+	if vObj.Annotations[storageResizerAnnotation] != pObj.Annotations[storageResizerAnnotation] {
+		if pObj.Annotations[storageResizerAnnotation] == "" {
+			delete(vObj.Annotations, storageResizerAnnotation)
+		} else {
+			if vObj.Annotations == nil {
+				vObj.Annotations = map[string]string{}
+			}
+			vObj.Annotations[storageResizerAnnotation] = pObj.Annotations[storageResizerAnnotation]
+		}
+	}
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/loft-sh/vcluster/pkg/util/translate"
 
+	corev1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -79,6 +80,51 @@ func TestSync(t *testing.T) {
 		},
 	}
 
+	disruptedAt := metav1.Unix(1000, 0)
+	hostClusterStatusPDB := &policyv1.PodDisruptionBudget{
+		ObjectMeta: *pObjectMeta.DeepCopy(),
+		Spec:       vclusterPDB.Spec,
+		Status: policyv1.PodDisruptionBudgetStatus{
+			ObservedGeneration: 3,
+			DisruptedPods: map[string]metav1.Time{
+				translate.Default.HostName("test-pod", vObjectMeta.Namespace): disruptedAt,
+			},
+			DisruptionsAllowed: 1,
+			CurrentHealthy:     11,
+			DesiredHealthy:     10,
+			ExpectedPods:       11,
+			Conditions: []metav1.Condition{
+				{
+					Type:               policyv1.DisruptionAllowedCondition,
+					Status:             metav1.ConditionTrue,
+					ObservedGeneration: 3,
+					Reason:             policyv1.SufficientPodsReason,
+					LastTransitionTime: disruptedAt,
+				},
+			},
+		},
+	}
+	hostClusterStatusPDB.Generation = 3
+
+	vclusterStatusPDB := vclusterPDB.DeepCopy()
+	vclusterStatusPDB.Generation = 1
+	vclusterStatusPDB.Status = *hostClusterStatusPDB.Status.DeepCopy()
+	vclusterStatusPDB.Status.ObservedGeneration = 1
+	vclusterStatusPDB.Status.Conditions[0].ObservedGeneration = 1
+	vclusterStatusPDB.Status.DisruptedPods = map[string]metav1.Time{
+		"test-pod": disruptedAt,
+	}
+
+	vclusterStatusMissingPDB := vclusterStatusPDB.DeepCopy()
+	vclusterStatusMissingPDB.Status = policyv1.PodDisruptionBudgetStatus{}
+
+	vclusterPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pod",
+			Namespace: vObjectMeta.Namespace,
+		},
+	}
+
 	syncertesting.RunTests(t, []*syncertesting.SyncTest{
 		{
 			Name: "Create Host Cluster PodDisruptionBudget",
@@ -137,5 +183,26 @@ func TestSync(t *testing.T) {
 				assert.NilError(t, err)
 			},
 		},
+		{
+			Name: "Sync Host Cluster PodDisruptionBudget's Status",
+			InitialVirtualState: []runtime.Object{
+				vclusterStatusMissingPDB.DeepCopy(),
+				vclusterPod.DeepCopy(),
+			},
+			InitialPhysicalState: []runtime.Object{
+				hostClusterStatusPDB.DeepCopy(),
+			},
+			ExpectedVirtualState: map[schema.GroupVersionKind][]runtime.Object{
+				policyv1.SchemeGroupVersion.WithKind("PodDisruptionBudget"): {vclusterStatusPDB.DeepCopy()},
+			},
+			ExpectedPhysicalState: map[schema.GroupVersionKind][]runtime.Object{
+				policyv1.SchemeGroupVersion.WithKind("PodDisruptionBudget"): {hostClusterStatusPDB.DeepCopy()},
+			},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := syncertesting.FakeStartSyncer(t, ctx, New)
+				_, err := syncer.(*pdbSyncer).Sync(syncCtx, synccontext.NewSyncEvent(hostClusterStatusPDB.DeepCopy(), vclusterStatusMissingPDB.DeepCopy()))
+				assert.NilError(t, err)
+			},
+		},
 	})
 }
@@ -1,9 +1,11 @@
 package poddisruptionbudgets
 
 import (
+	"github.com/loft-sh/vcluster/pkg/mappings"
 	"github.com/loft-sh/vcluster/pkg/syncer/synccontext"
 	"github.com/loft-sh/vcluster/pkg/util/translate"
 	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -20,3 +22,35 @@ func (s *pdbSyncer) translateUpdate(ctx *synccontext.SyncContext, pObj, vObj *po
 	pObj.Spec.MinAvailable = vObj.Spec.MinAvailable
 	pObj.Spec.Selector = translate.HostLabelSelector(ctx, vObj.Spec.Selector)
 }
+
+// translateUpdateBackwards syncs the status calculated by the host disruption controller back to the virtual
+// pod disruption budget, as the disruption controller within the virtual cluster is disabled.
+func (s *pdbSyncer) translateUpdateBackwards(ctx *synccontext.SyncContext, pObj, vObj *policyv1.PodDisruptionBudget) {
+	status := pObj.Status.DeepCopy()
+
+	// the host controller has observed the latest spec, which is always the latest virtual spec
+	if status.ObservedGeneration >= pObj.Generation {
+		status.ObservedGeneration = vObj.Generation
+	}
+	for i := range status.Conditions {
+		if status.Conditions[i].ObservedGeneration >= pObj.Generation {
+			status.Conditions[i].ObservedGeneration = vObj.Generation
+		}
+	}
+
+	// translate the host pod names of disrupted pods
+	if len(status.DisruptedPods) > 0 {
+		disruptedPods := map[string]metav1.Time{}
+		for pName, disruptedAt := range status.DisruptedPods {
+			vName := mappings.HostToVirtual(ctx, pName, pObj.Namespace, nil, mappings.Pods())
+			if vName.Name == "" {
+				continue
+			}
+
+			disruptedPods[vName.Name] = disruptedAt
+		}
+		status.DisruptedPods = disruptedPods
+	}
+
+	vObj.Status = *status
+}
@@ -98,6 +98,7 @@ func New(ctx *synccontext.RegisterContext) (syncertypes.Object, error) {
 		tolerations:           tolerations,
 
 		podSecurityStandard: ctx.Config.Policies.PodSecurityStandard,
+		secretBackend:       ctx.Config.Sync.ToHost.Secrets.Backend.Type,
 	}, nil
 }
 
@@ -115,6 +116,7 @@ type podSyncer struct {
 	tolerations           []*corev1.Toleration
 
 	podSecurityStandard string
+	secretBackend       string
 }
 
 var _ syncertypes.ControllerModifier = &podSyncer{}
@@ -178,6 +180,16 @@ func (s *podSyncer) SyncToHost(ctx *synccontext.SyncContext, event *synccontext.
 		}
 	}
 
+	// secrets can only be mounted as volumes if the csi or kms secret backend is used
+	if translatepods.UsesSecretVolumeBackend(s.secretBackend) {
+		err := translatepods.ValidateSecretReferences(event.Virtual, s.secretBackend)
+		if err != nil {
+			ctx.Log.Errorf("%s pod creation not allowed: %v", event.Virtual.Name, err)
+			s.EventRecorder().Eventf(event.Virtual, "Warning", "SyncError", `Pod %s is forbidden: %v`, event.Virtual.Name, err)
+			return ctrl.Result{}, nil
+		}
+	}
+
 	// translate the pod
 	pPod, err := s.translate(ctx, event.Virtual)
 	if err != nil {
@@ -290,7 +302,7 @@ func (s *podSyncer) Sync(ctx *synccontext.SyncContext, event *synccontext.SyncEv
 
 	// sync ephemeral containers
 	if syncEphemeralContainers(event.Virtual, event.Host) {
-		kubeIP, _, ptrServiceList, err := s.getK8sIPDNSIPServiceList(ctx, event.Virtual)
+		kubeIP, _, ptrServiceList, err := GetK8sIPDNSIPServiceList(ctx, s.serviceName, event.Virtual.Namespace)
 		if err != nil {
 			return ctrl.Result{}, err
 		}
@@ -13,7 +13,7 @@ import (
 )
 
 func (s *podSyncer) translate(ctx *synccontext.SyncContext, vPod *corev1.Pod) (*corev1.Pod, error) {
-	kubeIP, dnsIP, ptrServiceList, err := s.getK8sIPDNSIPServiceList(ctx, vPod)
+	kubeIP, dnsIP, ptrServiceList, err := GetK8sIPDNSIPServiceList(ctx, s.serviceName, vPod.Namespace)
 	if err != nil {
 		return nil, err
 	}
@@ -26,20 +26,22 @@ func (s *podSyncer) translate(ctx *synccontext.SyncContext, vPod *corev1.Pod) (*
 	return pPod, err
 }
 
-func (s *podSyncer) getK8sIPDNSIPServiceList(ctx *synccontext.SyncContext, vPod *corev1.Pod) (string, string, []*corev1.Service, error) {
-	kubeIP, err := s.findKubernetesIP(ctx)
+// GetK8sIPDNSIPServiceList returns the kubernetes service ip, the dns service ip and the virtual services
+// within the given namespace, which are needed to translate a pod spec for the host cluster.
+func GetK8sIPDNSIPServiceList(ctx *synccontext.SyncContext, serviceName, namespace string) (string, string, []*corev1.Service, error) {
+	kubeIP, err := findKubernetesIP(ctx, serviceName)
 	if err != nil {
 		return "", "", nil, err
 	}
 
-	dnsIP, err := s.findKubernetesDNSIP(ctx)
+	dnsIP, err := findKubernetesDNSIP(ctx)
 	if err != nil {
 		return "", "", nil, err
 	}
 
 	// get services for pod
 	serviceList := &corev1.ServiceList{}
-	err = ctx.VirtualClient.List(ctx, serviceList, client.InNamespace(vPod.Namespace))
+	err = ctx.VirtualClient.List(ctx, serviceList, client.InNamespace(namespace))
 	if err != nil {
 		return "", "", nil, err
 	}
@@ -52,10 +54,10 @@ func (s *podSyncer) getK8sIPDNSIPServiceList(ctx *synccontext.SyncContext, vPod
 	return kubeIP, dnsIP, ptrServiceList, nil
 }
 
-func (s *podSyncer) findKubernetesIP(ctx *synccontext.SyncContext) (string, error) {
+func findKubernetesIP(ctx *synccontext.SyncContext, serviceName string) (string, error) {
 	pService := &corev1.Service{}
 	err := ctx.CurrentNamespaceClient.Get(ctx, types.NamespacedName{
-		Name:      s.serviceName,
+		Name:      serviceName,
 		Namespace: ctx.CurrentNamespace,
 	}, pService)
 	if err != nil {
@@ -65,7 +67,7 @@ func (s *podSyncer) findKubernetesIP(ctx *synccontext.SyncContext) (string, erro
 	return pService.Spec.ClusterIP, nil
 }
 
-func (s *podSyncer) findKubernetesDNSIP(ctx *synccontext.SyncContext) (string, error) {
+func findKubernetesDNSIP(ctx *synccontext.SyncContext) (string, error) {
 	if specialservices.Default == nil {
 		return "", errors.New("specialservices default not initialized")
 	}
@@ -75,7 +77,7 @@ func (s *podSyncer) findKubernetesDNSIP(ctx *synccontext.SyncContext) (string, e
 
 	// first try to find the actual synced service, then fallback to a different if we have a suffix (only in the case of integrated coredns)
 	pClient, namespace := specialservices.Default.DNSNamespace(ctx)
-	ip := s.translateAndFindService(
+	ip := translateAndFindService(
 		ctx,
 		pClient,
 		namespace,
@@ -88,7 +90,7 @@ func (s *podSyncer) findKubernetesDNSIP(ctx *synccontext.SyncContext) (string, e
 	return ip, nil
 }
 
-func (s *podSyncer) translateAndFindService(ctx *synccontext.SyncContext, kubeClient client.Client, namespace, name string) string {
+func translateAndFindService(ctx *synccontext.SyncContext, kubeClient client.Client, namespace, name string) string {
 	pService := &corev1.Service{}
 	err := kubeClient.Get(ctx, types.NamespacedName{
 		Name:      name,
@@ -37,6 +37,13 @@ func (t *translator) Diff(ctx *synccontext.SyncContext, vPod, pPod *corev1.Pod)
 		updatedLabels = map[string]string{}
 	}
 
+	// set original images
+	if originalImages := originalImagesAnnotation(vPod, t.imageTranslator); originalImages != "" {
+		updatedAnnotations[OriginalImagesAnnotation] = originalImages
+	} else {
+		delete(updatedAnnotations, OriginalImagesAnnotation)
+	}
+
 	// set owner references
 	updatedAnnotations[VClusterLabelsAnnotation] = LabelsAnnotation(vPod)
 	if len(vPod.OwnerReferences) > 0 {
@@ -63,7 +70,7 @@ func (t *translator) Diff(ctx *synccontext.SyncContext, vPod, pPod *corev1.Pod)
 }
 
 func getExcludedAnnotations(pPod *corev1.Pod) []string {
-	annotations := []string{ClusterAutoScalerAnnotation, OwnerReferences, OwnerSetKind, NamespaceAnnotation, NameAnnotation, UIDAnnotation, ServiceAccountNameAnnotation, HostsRewrittenAnnotation, VClusterLabelsAnnotation}
+	annotations := []string{ClusterAutoScalerAnnotation, OwnerReferences, OwnerSetKind, NamespaceAnnotation, NameAnnotation, UIDAnnotation, ServiceAccountNameAnnotation, HostsRewrittenAnnotation, VClusterLabelsAnnotation, OriginalImagesAnnotation}
 	if pPod != nil {
 		for _, v := range pPod.Spec.Volumes {
 			if v.Projected != nil {
@@ -100,7 +107,7 @@ func (t *translator) calcSpecDiff(pObj, vObj *corev1.Pod) {
 	pObj.Spec.ActiveDeadlineSeconds = vObj.Spec.ActiveDeadlineSeconds
 
 	// is image different?
-	updatedContainer := calcContainerImageDiff(pObj.Spec.Containers, vObj.Spec.Containers, t.imageTranslator, nil)
+	updatedContainer := calcContainerImageDiff(vObj.Namespace, pObj.Spec.Containers, vObj.Spec.Containers, t.imageTranslator, nil)
 	if len(updatedContainer) != 0 {
 		pObj.Spec.Containers = updatedContainer
 	}
@@ -113,7 +120,7 @@ func (t *translator) calcSpecDiff(pObj, vObj *corev1.Pod) {
 		}
 	}
 
-	updatedContainer = calcContainerImageDiff(pObj.Spec.InitContainers, vObj.Spec.InitContainers, t.imageTranslator, skipContainers)
+	updatedContainer = calcContainerImageDiff(vObj.Namespace, pObj.Spec.InitContainers, vObj.Spec.InitContainers, t.imageTranslator, skipContainers)
 	if len(updatedContainer) != 0 {
 		pObj.Spec.InitContainers = updatedContainer
 	}
@@ -121,7 +128,7 @@ func (t *translator) calcSpecDiff(pObj, vObj *corev1.Pod) {
 	pObj.Spec.SchedulingGates = vObj.Spec.SchedulingGates
 }
 
-func calcContainerImageDiff(pContainers, vContainers []corev1.Container, translateImages ImageTranslator, skipContainers map[string]bool) []corev1.Container {
+func calcContainerImageDiff(namespace string, pContainers, vContainers []corev1.Container, translateImages ImageTranslator, skipContainers map[string]bool) []corev1.Container {
 	newContainers := []corev1.Container{}
 	changed := false
 	for _, p := range pContainers {
@@ -132,9 +139,9 @@ func calcContainerImageDiff(pContainers, vContainers []corev1.Container, transla
 
 		for _, v := range vContainers {
 			if p.Name == v.Name {
-				if p.Image != translateImages.Translate(v.Image) {
+				if p.Image != translateImages.Translate(namespace, v.Image) {
 					newContainer := *p.DeepCopy()
-					newContainer.Image = translateImages.Translate(v.Image)
+					newContainer.Image = translateImages.Translate(namespace, v.Image)
 					newContainers = append(newContainers, newContainer)
 					changed = true
 				} else {
@@ -1,24 +1,135 @@
 package translate
 
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/loft-sh/vcluster/config"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// OriginalImagesAnnotation holds the original images of all containers of a pod that were rewritten
+const OriginalImagesAnnotation = "vcluster.loft.sh/original-images"
+
 type ImageTranslator interface {
-	Translate(image string) string
+	Translate(namespace, image string) string
 }
 
 type imageTranslator struct {
 	translateImages map[string]string
+	rules           []*imageRule
+}
+
+type imageRule struct {
+	config.TranslateImageRule
+
+	regex *regexp.Regexp
 }
 
-func NewImageTranslator(translateImages map[string]string) (ImageTranslator, error) {
+func NewImageTranslator(translateImages map[string]string, rules []config.TranslateImageRule) (ImageTranslator, error) {
+	imageRules := make([]*imageRule, 0, len(rules))
+	for i, rule := range rules {
+		imageRule := &imageRule{TranslateImageRule: rule}
+		if rule.Regex != "" {
+			regex, err := regexp.Compile("^(?:" + rule.Regex + ")$")
+			if err != nil {
+				return nil, fmt.Errorf("compile regex of image rule %d: %w", i, err)
+			}
+
+			imageRule.regex = regex
+		}
+
+		imageRules = append(imageRules, imageRule)
+	}
+
 	return &imageTranslator{
 		translateImages: translateImages,
+		rules:           imageRules,
 	}, nil
 }
 
-func (i *imageTranslator) Translate(image string) string {
+func (i *imageTranslator) Translate(namespace, image string) string {
 	out, ok := i.translateImages[image]
 	if ok {
 		return out
 	}
 
+	normalizedImage := NormalizeImage(image)
+	for _, rule := range i.rules {
+		if len(rule.Namespaces) > 0 && !slices.Contains(rule.Namespaces, namespace) {
+			continue
+		}
+
+		out, ok := rule.translate(image)
+		if !ok && normalizedImage != image {
+			out, ok = rule.translate(normalizedImage)
+		}
+		if ok {
+			return out
+		}
+	}
+
 	return image
 }
+
+func (r *imageRule) translate(image string) (string, bool) {
+	if r.regex != nil {
+		if !r.regex.MatchString(image) {
+			return "", false
+		}
+
+		return r.regex.ReplaceAllString(image, r.Replacement), true
+	}
+
+	prefix, suffix, found := strings.Cut(r.Pattern, "*")
+	if !found {
+		return r.Replacement, image == r.Pattern
+	} else if len(image) < len(prefix)+len(suffix) || !strings.HasPrefix(image, prefix) || !strings.HasSuffix(image, suffix) {
+		return "", false
+	}
+
+	return strings.Replace(r.Replacement, "*", image[len(prefix):len(image)-len(suffix)], 1), true
+}
+
+// NormalizeImage returns the fully qualified form of images that are pulled from docker hub,
+// e.g. nginx:latest becomes docker.io/library/nginx:latest
+func NormalizeImage(image string) string {
+	domain, _, found := strings.Cut(image, "/")
+	if !found {
+		return "docker.io/library/" + image
+	} else if domain == "localhost" || strings.ContainsAny(domain, ".:") {
+		return image
+	}
+
+	return "docker.io/" + image
+}
+
+// originalImagesAnnotation returns the annotation value that holds the original images of all
+// containers of the virtual pod that are rewritten by the image translator
+func originalImagesAnnotation(vPod *corev1.Pod, translateImages ImageTranslator) string {
+	originalImages := map[string]string{}
+	for _, container := range vPod.Spec.InitContainers {
+		if translateImages.Translate(vPod.Namespace, container.Image) != container.Image {
+			originalImages[container.Name] = container.Image
+		}
+	}
+	for _, container := range vPod.Spec.Containers {
+		if translateImages.Translate(vPod.Namespace, container.Image) != container.Image {
+			originalImages[container.Name] = container.Image
+		}
+	}
+	for _, container := range vPod.Spec.EphemeralContainers {
+		if translateImages.Translate(vPod.Namespace, container.Image) != container.Image {
+			originalImages[container.Name] = container.Image
+		}
+	}
+	if len(originalImages) == 0 {
+		return ""
+	}
+
+	out, _ := json.Marshal(originalImages)
+	return string(out)
+}
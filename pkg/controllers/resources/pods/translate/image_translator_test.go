@@ -0,0 +1,134 @@
+package translate
+
+import (
+	"testing"
+
+	"github.com/loft-sh/vcluster/config"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestImageTranslator(t *testing.T) {
+	translateImages := map[string]string{
+		"nginx:1.25": "mirror.corp/nginx:1.25-patched",
+	}
+	rules := []config.TranslateImageRule{
+		{
+			Pattern:     "busybox:1.36",
+			Replacement: "busybox@sha256:abc",
+		},
+		{
+			Pattern:     "docker.io/*",
+			Replacement: "mirror.corp/dockerhub/*",
+			Namespaces:  []string{"team-a"},
+		},
+		{
+			Regex:       `ghcr\.io/(.+):v(.+)`,
+			Replacement: "mirror.corp/ghcr/$1:$2",
+		},
+		{
+			Pattern:     "quay.io/*:latest",
+			Replacement: "mirror.corp/quay/*:stable",
+		},
+	}
+
+	imageTranslator, err := NewImageTranslator(translateImages, rules)
+	assert.NilError(t, err)
+
+	testCases := []struct {
+		name      string
+		namespace string
+		image     string
+		expected  string
+	}{
+		{
+			name:     "exact match has precedence",
+			image:    "nginx:1.25",
+			expected: "mirror.corp/nginx:1.25-patched",
+		},
+		{
+			name:     "digest pinning",
+			image:    "busybox:1.36",
+			expected: "busybox@sha256:abc",
+		},
+		{
+			name:      "registry rule with normalized image",
+			namespace: "team-a",
+			image:     "nginx:1.26",
+			expected:  "mirror.corp/dockerhub/library/nginx:1.26",
+		},
+		{
+			name:      "registry rule with fully qualified image",
+			namespace: "team-a",
+			image:     "docker.io/bitnami/redis:7",
+			expected:  "mirror.corp/dockerhub/bitnami/redis:7",
+		},
+		{
+			name:      "registry rule in other namespace",
+			namespace: "team-b",
+			image:     "nginx:1.26",
+			expected:  "nginx:1.26",
+		},
+		{
+			name:     "regex rule",
+			image:    "ghcr.io/loft-sh/vcluster:v0.21.0",
+			expected: "mirror.corp/ghcr/loft-sh/vcluster:0.21.0",
+		},
+		{
+			name:     "pattern with suffix",
+			image:    "quay.io/prometheus/node-exporter:latest",
+			expected: "mirror.corp/quay/prometheus/node-exporter:stable",
+		},
+		{
+			name:     "no match",
+			image:    "registry.k8s.io/pause:3.9",
+			expected: "registry.k8s.io/pause:3.9",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, imageTranslator.Translate(testCase.namespace, testCase.image), testCase.expected)
+		})
+	}
+}
+
+func TestNormalizeImage(t *testing.T) {
+	assert.Equal(t, NormalizeImage("nginx"), "docker.io/library/nginx")
+	assert.Equal(t, NormalizeImage("bitnami/redis:7"), "docker.io/bitnami/redis:7")
+	assert.Equal(t, NormalizeImage("docker.io/library/nginx"), "docker.io/library/nginx")
+	assert.Equal(t, NormalizeImage("localhost/nginx"), "localhost/nginx")
+	assert.Equal(t, NormalizeImage("localhost:5000/nginx"), "localhost:5000/nginx")
+	assert.Equal(t, NormalizeImage("ghcr.io/loft-sh/vcluster"), "ghcr.io/loft-sh/vcluster")
+}
+
+func TestOriginalImagesAnnotation(t *testing.T) {
+	imageTranslator, err := NewImageTranslator(nil, []config.TranslateImageRule{
+		{
+			Pattern:     "docker.io/*",
+			Replacement: "mirror.corp/dockerhub/*",
+		},
+	})
+	assert.NilError(t, err)
+
+	vPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test",
+		},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "init", Image: "busybox"}},
+			Containers: []corev1.Container{
+				{Name: "nginx", Image: "nginx:1.26"},
+				{Name: "pause", Image: "registry.k8s.io/pause:3.9"},
+			},
+			EphemeralContainers: []corev1.EphemeralContainer{
+				{EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "debug", Image: "alpine"}},
+			},
+		},
+	}
+	assert.Equal(t, originalImagesAnnotation(vPod, imageTranslator), `{"debug":"alpine","init":"busybox","nginx":"nginx:1.26"}`)
+
+	vPod.Spec = corev1.PodSpec{Containers: []corev1.Container{{Name: "pause", Image: "registry.k8s.io/pause:3.9"}}}
+	assert.Equal(t, originalImagesAnnotation(vPod, imageTranslator), "")
+}
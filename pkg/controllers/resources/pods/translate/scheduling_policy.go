@@ -0,0 +1,273 @@
+package translate
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+
+	"github.com/loft-sh/vcluster/config"
+	corev1 "k8s.io/api/core/v1"
+)
+
+type schedulingPolicy struct {
+	enforce *podSchedulingPolicy
+	def     *podSchedulingPolicy
+}
+
+type podSchedulingPolicy struct {
+	nodeSelector              map[string]string
+	affinity                  *corev1.Affinity
+	topologySpreadConstraints []corev1.TopologySpreadConstraint
+	runtimeClassName          string
+	priorityClassName         string
+	requests                  corev1.ResourceList
+	limits                    corev1.ResourceList
+}
+
+func newSchedulingPolicy(policy config.SyncPodsSchedulingPolicy) (*schedulingPolicy, error) {
+	enforce, err := newPodSchedulingPolicy(policy.Enforce)
+	if err != nil {
+		return nil, fmt.Errorf("parse sync.toHost.pods.schedulingPolicy.enforce: %w", err)
+	}
+
+	def, err := newPodSchedulingPolicy(policy.Default)
+	if err != nil {
+		return nil, fmt.Errorf("parse sync.toHost.pods.schedulingPolicy.default: %w", err)
+	}
+
+	return &schedulingPolicy{
+		enforce: enforce,
+		def:     def,
+	}, nil
+}
+
+func newPodSchedulingPolicy(policy config.PodSchedulingPolicy) (*podSchedulingPolicy, error) {
+	podPolicy := &podSchedulingPolicy{
+		nodeSelector:      policy.NodeSelector,
+		runtimeClassName:  policy.RuntimeClassName,
+		priorityClassName: policy.PriorityClassName,
+	}
+	if len(policy.Affinity) > 0 {
+		podPolicy.affinity = &corev1.Affinity{}
+		err := convertSchedulingField(policy.Affinity, podPolicy.affinity)
+		if err != nil {
+			return nil, fmt.Errorf("affinity: %w", err)
+		}
+	}
+	if len(policy.TopologySpreadConstraints) > 0 {
+		err := convertSchedulingField(policy.TopologySpreadConstraints, &podPolicy.topologySpreadConstraints)
+		if err != nil {
+			return nil, fmt.Errorf("topologySpreadConstraints: %w", err)
+		}
+	}
+	if len(policy.Resources.Requests) > 0 {
+		err := convertSchedulingField(policy.Resources.Requests, &podPolicy.requests)
+		if err != nil {
+			return nil, fmt.Errorf("resources.requests: %w", err)
+		}
+	}
+	if len(policy.Resources.Limits) > 0 {
+		err := convertSchedulingField(policy.Resources.Limits, &podPolicy.limits)
+		if err != nil {
+			return nil, fmt.Errorf("resources.limits: %w", err)
+		}
+	}
+
+	return podPolicy, nil
+}
+
+func convertSchedulingField(from, to interface{}) error {
+	raw, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(raw, to)
+}
+
+// apply applies the default fields first, so that enforced fields are merged into the final values
+func (s *schedulingPolicy) apply(pPod *corev1.Pod) {
+	if s == nil {
+		return
+	}
+
+	s.def.applyDefault(pPod)
+	s.enforce.applyEnforce(pPod)
+
+	// an applied limit can be lower than the request of a container, which the host would reject
+	forEachContainer(pPod, func(container *corev1.Container) {
+		clampRequestsToLimits(&container.Resources)
+	})
+}
+
+func (p *podSchedulingPolicy) applyDefault(pPod *corev1.Pod) {
+	if len(p.nodeSelector) > 0 && len(pPod.Spec.NodeSelector) == 0 {
+		pPod.Spec.NodeSelector = maps.Clone(p.nodeSelector)
+	}
+	if p.affinity != nil && pPod.Spec.Affinity == nil {
+		pPod.Spec.Affinity = p.affinity.DeepCopy()
+	}
+	if len(p.topologySpreadConstraints) > 0 && len(pPod.Spec.TopologySpreadConstraints) == 0 {
+		pPod.Spec.TopologySpreadConstraints = copyTopologySpreadConstraints(p.topologySpreadConstraints)
+	}
+	if p.runtimeClassName != "" && (pPod.Spec.RuntimeClassName == nil || *pPod.Spec.RuntimeClassName == "") {
+		runtimeClassName := p.runtimeClassName
+		pPod.Spec.RuntimeClassName = &runtimeClassName
+	}
+	if p.priorityClassName != "" && pPod.Spec.PriorityClassName == "" {
+		setPriorityClassName(pPod, p.priorityClassName)
+	}
+
+	forEachContainer(pPod, func(container *corev1.Container) {
+		container.Resources.Requests = mergeResourceList(container.Resources.Requests, p.requests, false)
+		container.Resources.Limits = mergeResourceList(container.Resources.Limits, p.limits, false)
+	})
+}
+
+func (p *podSchedulingPolicy) applyEnforce(pPod *corev1.Pod) {
+	if len(p.nodeSelector) > 0 {
+		if pPod.Spec.NodeSelector == nil {
+			pPod.Spec.NodeSelector = map[string]string{}
+		}
+		for k, v := range p.nodeSelector {
+			pPod.Spec.NodeSelector[k] = v
+		}
+	}
+	if p.affinity != nil {
+		pPod.Spec.Affinity = mergeAffinity(pPod.Spec.Affinity, p.affinity)
+	}
+	if len(p.topologySpreadConstraints) > 0 {
+		pPod.Spec.TopologySpreadConstraints = copyTopologySpreadConstraints(p.topologySpreadConstraints)
+	}
+	if p.runtimeClassName != "" {
+		runtimeClassName := p.runtimeClassName
+		pPod.Spec.RuntimeClassName = &runtimeClassName
+	}
+	if p.priorityClassName != "" {
+		setPriorityClassName(pPod, p.priorityClassName)
+	}
+
+	forEachContainer(pPod, func(container *corev1.Container) {
+		container.Resources.Requests = mergeResourceList(container.Resources.Requests, p.requests, true)
+		container.Resources.Limits = mergeResourceList(container.Resources.Limits, p.limits, true)
+	})
+}
+
+// setPriorityClassName sets the priority class name and resets the priority, which is resolved by the host
+// cluster from the priority class and would be rejected if it does not match
+func setPriorityClassName(pPod *corev1.Pod, priorityClassName string) {
+	pPod.Spec.PriorityClassName = priorityClassName
+	pPod.Spec.Priority = nil
+	pPod.Spec.PreemptionPolicy = nil
+}
+
+// mergeAffinity merges the enforced affinity into the affinity of the pod. Required node affinity terms are
+// combined, so that a node needs to satisfy a term of the pod as well as a term of the enforced affinity.
+func mergeAffinity(affinity, enforced *corev1.Affinity) *corev1.Affinity {
+	if affinity == nil {
+		return enforced.DeepCopy()
+	}
+
+	affinity = affinity.DeepCopy()
+	if enforced.NodeAffinity != nil {
+		if affinity.NodeAffinity == nil {
+			affinity.NodeAffinity = &corev1.NodeAffinity{}
+		}
+
+		affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = mergeNodeSelector(affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution, enforced.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution)
+		for _, term := range enforced.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+			affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution, *term.DeepCopy())
+		}
+	}
+	if enforced.PodAffinity != nil {
+		if affinity.PodAffinity == nil {
+			affinity.PodAffinity = &corev1.PodAffinity{}
+		}
+
+		for _, term := range enforced.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+			affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution, *term.DeepCopy())
+		}
+		for _, term := range enforced.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+			affinity.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(affinity.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution, *term.DeepCopy())
+		}
+	}
+	if enforced.PodAntiAffinity != nil {
+		if affinity.PodAntiAffinity == nil {
+			affinity.PodAntiAffinity = &corev1.PodAntiAffinity{}
+		}
+
+		for _, term := range enforced.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+			affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution, *term.DeepCopy())
+		}
+		for _, term := range enforced.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+			affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution, *term.DeepCopy())
+		}
+	}
+
+	return affinity
+}
+
+// mergeNodeSelector combines the terms of both node selectors. As terms are ORed, every term of the
+// pod is combined with every enforced term.
+func mergeNodeSelector(nodeSelector, enforced *corev1.NodeSelector) *corev1.NodeSelector {
+	if enforced == nil || len(enforced.NodeSelectorTerms) == 0 {
+		return nodeSelector
+	} else if nodeSelector == nil || len(nodeSelector.NodeSelectorTerms) == 0 {
+		return enforced.DeepCopy()
+	}
+
+	merged := &corev1.NodeSelector{}
+	for _, term := range nodeSelector.NodeSelectorTerms {
+		for _, enforcedTerm := range enforced.NodeSelectorTerms {
+			mergedTerm := term.DeepCopy()
+			mergedTerm.MatchExpressions = append(mergedTerm.MatchExpressions, enforcedTerm.DeepCopy().MatchExpressions...)
+			mergedTerm.MatchFields = append(mergedTerm.MatchFields, enforcedTerm.DeepCopy().MatchFields...)
+			merged.NodeSelectorTerms = append(merged.NodeSelectorTerms, *mergedTerm)
+		}
+	}
+
+	return merged
+}
+
+func mergeResourceList(resources, policy corev1.ResourceList, overwrite bool) corev1.ResourceList {
+	for name, quantity := range policy {
+		if _, ok := resources[name]; ok && !overwrite {
+			continue
+		}
+
+		if resources == nil {
+			resources = corev1.ResourceList{}
+		}
+		resources[name] = quantity.DeepCopy()
+	}
+
+	return resources
+}
+
+// clampRequestsToLimits lowers all requests that are greater than the limit of the same resource to the limit
+func clampRequestsToLimits(resources *corev1.ResourceRequirements) {
+	for name, request := range resources.Requests {
+		limit, ok := resources.Limits[name]
+		if ok && request.Cmp(limit) > 0 {
+			resources.Requests[name] = limit.DeepCopy()
+		}
+	}
+}
+
+func forEachContainer(pPod *corev1.Pod, fn func(container *corev1.Container)) {
+	for i := range pPod.Spec.InitContainers {
+		fn(&pPod.Spec.InitContainers[i])
+	}
+	for i := range pPod.Spec.Containers {
+		fn(&pPod.Spec.Containers[i])
+	}
+}
+
+func copyTopologySpreadConstraints(constraints []corev1.TopologySpreadConstraint) []corev1.TopologySpreadConstraint {
+	out := make([]corev1.TopologySpreadConstraint, 0, len(constraints))
+	for _, constraint := range constraints {
+		out = append(out, *constraint.DeepCopy())
+	}
+
+	return out
+}
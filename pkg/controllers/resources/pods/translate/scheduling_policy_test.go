@@ -0,0 +1,214 @@
+package translate
+
+import (
+	"testing"
+
+	"github.com/loft-sh/vcluster/config"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+)
+
+func TestSchedulingPolicy(t *testing.T) {
+	nodePoolAffinity := map[string]interface{}{
+		"nodeAffinity": map[string]interface{}{
+			"requiredDuringSchedulingIgnoredDuringExecution": map[string]interface{}{
+				"nodeSelectorTerms": []interface{}{
+					map[string]interface{}{
+						"matchExpressions": []interface{}{
+							map[string]interface{}{"key": "pool", "operator": "In", "values": []interface{}{"tenant-a"}},
+						},
+					},
+				},
+			},
+		},
+	}
+	nodePoolRequirement := corev1.NodeSelectorRequirement{Key: "pool", Operator: corev1.NodeSelectorOpIn, Values: []string{"tenant-a"}}
+	zoneRequirement := corev1.NodeSelectorRequirement{Key: "zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"a"}}
+	archRequirement := corev1.NodeSelectorRequirement{Key: "arch", Operator: corev1.NodeSelectorOpIn, Values: []string{"arm64"}}
+
+	testCases := []struct {
+		name     string
+		policy   config.SyncPodsSchedulingPolicy
+		pod      corev1.PodSpec
+		expected corev1.PodSpec
+	}{
+		{
+			name: "empty policy",
+			pod: corev1.PodSpec{
+				NodeSelector: map[string]string{"a": "b"},
+				Containers:   []corev1.Container{{Name: "test"}},
+			},
+			expected: corev1.PodSpec{
+				NodeSelector: map[string]string{"a": "b"},
+				Containers:   []corev1.Container{{Name: "test"}},
+			},
+		},
+		{
+			name: "enforce",
+			policy: config.SyncPodsSchedulingPolicy{
+				Enforce: config.PodSchedulingPolicy{
+					NodeSelector:      map[string]string{"pool": "tenant-a"},
+					Affinity:          nodePoolAffinity,
+					RuntimeClassName:  "gvisor",
+					PriorityClassName: "tenant-a",
+					Resources: config.Resources{
+						Limits: map[string]interface{}{"memory": "1Gi"},
+					},
+				},
+			},
+			pod: corev1.PodSpec{
+				NodeSelector: map[string]string{"pool": "other", "a": "b"},
+				Affinity: &corev1.Affinity{
+					NodeAffinity: &corev1.NodeAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+							NodeSelectorTerms: []corev1.NodeSelectorTerm{
+								{MatchExpressions: []corev1.NodeSelectorRequirement{zoneRequirement}},
+								{MatchExpressions: []corev1.NodeSelectorRequirement{archRequirement}},
+							},
+						},
+					},
+				},
+				RuntimeClassName:  ptr.To("runc"),
+				PriorityClassName: "high",
+				Priority:          ptr.To(int32(1000)),
+				Containers: []corev1.Container{{
+					Name: "test",
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("2Gi")},
+					},
+				}},
+			},
+			expected: corev1.PodSpec{
+				NodeSelector: map[string]string{"pool": "tenant-a", "a": "b"},
+				Affinity: &corev1.Affinity{
+					NodeAffinity: &corev1.NodeAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+							NodeSelectorTerms: []corev1.NodeSelectorTerm{
+								{MatchExpressions: []corev1.NodeSelectorRequirement{zoneRequirement, nodePoolRequirement}},
+								{MatchExpressions: []corev1.NodeSelectorRequirement{archRequirement, nodePoolRequirement}},
+							},
+						},
+					},
+				},
+				RuntimeClassName:  ptr.To("gvisor"),
+				PriorityClassName: "tenant-a",
+				Containers: []corev1.Container{{
+					Name: "test",
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+					},
+				}},
+			},
+		},
+		{
+			name: "default",
+			policy: config.SyncPodsSchedulingPolicy{
+				Default: config.PodSchedulingPolicy{
+					NodeSelector: map[string]string{"pool": "tenant-a"},
+					Affinity:     nodePoolAffinity,
+					TopologySpreadConstraints: []map[string]interface{}{
+						{"maxSkew": 1, "topologyKey": "zone", "whenUnsatisfiable": "ScheduleAnyway"},
+					},
+					RuntimeClassName:  "gvisor",
+					PriorityClassName: "tenant-a",
+					Resources: config.Resources{
+						Requests: map[string]interface{}{"cpu": "100m", "memory": "128Mi"},
+					},
+				},
+			},
+			pod: corev1.PodSpec{
+				NodeSelector:      map[string]string{"a": "b"},
+				PriorityClassName: "high",
+				InitContainers: []corev1.Container{{
+					Name: "init",
+				}},
+				Containers: []corev1.Container{{
+					Name: "test",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+					},
+				}},
+			},
+			expected: corev1.PodSpec{
+				NodeSelector: map[string]string{"a": "b"},
+				Affinity: &corev1.Affinity{
+					NodeAffinity: &corev1.NodeAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+							NodeSelectorTerms: []corev1.NodeSelectorTerm{
+								{MatchExpressions: []corev1.NodeSelectorRequirement{nodePoolRequirement}},
+							},
+						},
+					},
+				},
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+					{MaxSkew: 1, TopologyKey: "zone", WhenUnsatisfiable: corev1.ScheduleAnyway},
+				},
+				RuntimeClassName:  ptr.To("gvisor"),
+				PriorityClassName: "high",
+				InitContainers: []corev1.Container{{
+					Name: "init",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m"), corev1.ResourceMemory: resource.MustParse("128Mi")},
+					},
+				}},
+				Containers: []corev1.Container{{
+					Name: "test",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("128Mi")},
+					},
+				}},
+			},
+		},
+		{
+			name: "enforce limits below requests",
+			policy: config.SyncPodsSchedulingPolicy{
+				Enforce: config.PodSchedulingPolicy{
+					Resources: config.Resources{
+						Limits: map[string]interface{}{"cpu": "500m", "memory": "1Gi"},
+					},
+				},
+			},
+			pod: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name: "test",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2"), corev1.ResourceMemory: resource.MustParse("512Mi")},
+					},
+				}},
+			},
+			expected: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name: "test",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("512Mi")},
+						Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+					},
+				}},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			policy, err := newSchedulingPolicy(testCase.policy)
+			assert.NilError(t, err)
+
+			pPod := &corev1.Pod{Spec: testCase.pod}
+			policy.apply(pPod)
+			assert.DeepEqual(t, pPod.Spec, testCase.expected)
+		})
+	}
+}
+
+func TestSchedulingPolicyInvalid(t *testing.T) {
+	_, err := newSchedulingPolicy(config.SyncPodsSchedulingPolicy{
+		Enforce: config.PodSchedulingPolicy{
+			Resources: config.Resources{
+				Limits: map[string]interface{}{"memory": "1 Gigabyte"},
+			},
+		},
+	})
+	assert.ErrorContains(t, err, "parse sync.toHost.pods.schedulingPolicy.enforce: resources.limits")
+}
@@ -0,0 +1,95 @@
+package translate
+
+import (
+	"fmt"
+
+	"github.com/loft-sh/vcluster/config"
+	"github.com/loft-sh/vcluster/pkg/mappings"
+	"github.com/loft-sh/vcluster/pkg/secretbackend"
+	"github.com/loft-sh/vcluster/pkg/syncer/synccontext"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+// UsesSecretVolumeBackend returns true if secret volumes are replaced with csi volumes for the given secret backend type
+func UsesSecretVolumeBackend(backendType string) bool {
+	return backendType == config.SecretBackendTypeCSI || backendType == config.SecretBackendTypeKMS
+}
+
+// ValidateSecretReferences makes sure the pod only references secrets through secret volumes, because
+// only these can be replaced with csi volumes without writing the plaintext secret data to the host cluster
+func ValidateSecretReferences(vPod *corev1.Pod, backendType string) error {
+	for i := range vPod.Spec.Volumes {
+		if vPod.Spec.Volumes[i].Projected == nil {
+			continue
+		}
+
+		for _, source := range vPod.Spec.Volumes[i].Projected.Sources {
+			if source.Secret != nil {
+				return fmt.Errorf("volume %s projects secret %s, which is not supported with secret backend %s", vPod.Spec.Volumes[i].Name, source.Secret.Name, backendType)
+			}
+		}
+	}
+
+	containers := append(append([]corev1.Container{}, vPod.Spec.InitContainers...), vPod.Spec.Containers...)
+	for i := range vPod.Spec.EphemeralContainers {
+		containers = append(containers, corev1.Container(vPod.Spec.EphemeralContainers[i].EphemeralContainerCommon))
+	}
+	for _, container := range containers {
+		for _, env := range container.Env {
+			if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+				return fmt.Errorf("container %s references secret %s in environment variable %s, which is not supported with secret backend %s", container.Name, env.ValueFrom.SecretKeyRef.Name, env.Name, backendType)
+			}
+		}
+		for _, from := range container.EnvFrom {
+			if from.SecretRef != nil {
+				return fmt.Errorf("container %s references secret %s in envFrom, which is not supported with secret backend %s", container.Name, from.SecretRef.Name, backendType)
+			}
+		}
+	}
+
+	return nil
+}
+
+// translateSecretVolume replaces the secret volume with a csi volume, that references the secret through its
+// volume attributes as defined by the secretbackend package
+func (t *translator) translateSecretVolume(ctx *synccontext.SyncContext, volume *corev1.Volume, vNamespace, pNamespace string) error {
+	backendVolume := &secretbackend.Volume{
+		Backend:         t.secretBackend.Type,
+		VCluster:        translate.VClusterName,
+		Endpoint:        t.secretBackendEndpoint,
+		HostNamespace:   pNamespace,
+		SecretName:      volume.Secret.SecretName,
+		SecretNamespace: vNamespace,
+		Items:           volume.Secret.Items,
+		DefaultMode:     volume.Secret.DefaultMode,
+		Optional:        ptr.Deref(volume.Secret.Optional, false),
+	}
+	if t.secretBackend.Type == config.SecretBackendTypeKMS {
+		backendVolume.HostSecretName = mappings.VirtualToHostName(ctx, volume.Secret.SecretName, vNamespace, mappings.Secrets())
+	}
+
+	backendAttributes, err := backendVolume.VolumeAttributes()
+	if err != nil {
+		return fmt.Errorf("volume %s: %w", volume.Name, err)
+	}
+
+	volumeAttributes := map[string]string{}
+	for k, v := range t.secretBackend.CSI.VolumeAttributes {
+		volumeAttributes[k] = v
+	}
+	for k, v := range backendAttributes {
+		volumeAttributes[k] = v
+	}
+	volumeAttributes[secretbackend.SecretProviderClassVolumeAttribute] = t.secretBackend.CSI.SecretProviderClass
+
+	volume.VolumeSource = corev1.VolumeSource{
+		CSI: &corev1.CSIVolumeSource{
+			Driver:           t.secretBackend.CSI.Driver,
+			ReadOnly:         ptr.To(true),
+			VolumeAttributes: volumeAttributes,
+		},
+	}
+	return nil
+}
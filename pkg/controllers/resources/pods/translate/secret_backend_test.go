@@ -0,0 +1,187 @@
+package translate
+
+import (
+	"testing"
+
+	"github.com/loft-sh/vcluster/config"
+	"github.com/loft-sh/vcluster/pkg/scheme"
+	"github.com/loft-sh/vcluster/pkg/secretbackend"
+	generictesting "github.com/loft-sh/vcluster/pkg/syncer/testing"
+	"github.com/loft-sh/vcluster/pkg/util/loghelper"
+	testingutil "github.com/loft-sh/vcluster/pkg/util/testing"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+)
+
+func TestSecretVolumeTranslation(t *testing.T) {
+	vPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pod-name",
+			Namespace: "test-ns",
+		},
+		Spec: corev1.PodSpec{
+			Volumes: []corev1.Volume{
+				{
+					Name: "credentials",
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{
+							SecretName: "my-secret",
+							Items: []corev1.KeyToPath{
+								{Key: "password", Path: "db/password"},
+							},
+							DefaultMode: ptr.To(int32(0400)),
+							Optional:    ptr.To(true),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	csi := config.SecretBackendCSI{
+		Driver:              "secrets-store.csi.k8s.io",
+		SecretProviderClass: "vcluster-secrets",
+		VolumeAttributes: map[string]string{
+			"usePodIdentity": "false",
+		},
+	}
+	expectedAttributes := map[string]string{
+		"usePodIdentity":                             "false",
+		"secretProviderClass":                        "vcluster-secrets",
+		secretbackend.BackendVolumeAttribute:         config.SecretBackendTypeCSI,
+		secretbackend.VClusterVolumeAttribute:        translate.VClusterName,
+		secretbackend.EndpointVolumeAttribute:        "https://vcluster.test.svc:443",
+		secretbackend.HostNamespaceVolumeAttribute:   "test",
+		secretbackend.SecretNameVolumeAttribute:      "my-secret",
+		secretbackend.SecretNamespaceVolumeAttribute: "test-ns",
+		secretbackend.SecretItemsVolumeAttribute:     `[{"key":"password","path":"db/password"}]`,
+		secretbackend.DefaultModeVolumeAttribute:     "256",
+		secretbackend.OptionalVolumeAttribute:        "true",
+	}
+	expectedKMSAttributes := map[string]string{}
+	for k, v := range expectedAttributes {
+		expectedKMSAttributes[k] = v
+	}
+	expectedKMSAttributes[secretbackend.BackendVolumeAttribute] = config.SecretBackendTypeKMS
+	expectedKMSAttributes[secretbackend.HostSecretNameVolumeAttribute] = translate.Default.HostName("my-secret", "test-ns")
+
+	testCases := []struct {
+		name       string
+		backend    config.SecretBackend
+		attributes map[string]string
+	}{
+		{
+			name:       "csi",
+			backend:    config.SecretBackend{Type: config.SecretBackendTypeCSI, CSI: csi},
+			attributes: expectedAttributes,
+		},
+		{
+			name:       "kms",
+			backend:    config.SecretBackend{Type: config.SecretBackendTypeKMS, CSI: csi},
+			attributes: expectedKMSAttributes,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			pClient := testingutil.NewFakeClient(scheme.Scheme)
+			vClient := testingutil.NewFakeClient(scheme.Scheme)
+			registerCtx := generictesting.NewFakeRegisterContext(generictesting.NewFakeConfig(), pClient, vClient)
+			tr := &translator{
+				eventRecorder:         record.NewFakeRecorder(10),
+				log:                   loghelper.New("pods-syncer-translator-test"),
+				pClient:               pClient,
+				secretBackend:         &testCase.backend,
+				secretBackendEndpoint: "https://vcluster.test.svc:443",
+			}
+
+			pPod := vPod.DeepCopy()
+			pPod.Namespace = "test"
+			err := tr.translateVolumes(registerCtx.ToSyncContext("pods-syncer-translator-test"), pPod, vPod)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, pPod.Spec.Volumes, []corev1.Volume{
+				{
+					Name: "credentials",
+					VolumeSource: corev1.VolumeSource{
+						CSI: &corev1.CSIVolumeSource{
+							Driver:           "secrets-store.csi.k8s.io",
+							ReadOnly:         ptr.To(true),
+							VolumeAttributes: testCase.attributes,
+						},
+					},
+				},
+			})
+
+			// the provider needs to be able to decode the attributes again
+			volume, err := secretbackend.ParseVolumeAttributes(pPod.Spec.Volumes[0].CSI.VolumeAttributes)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, volume.Items, vPod.Spec.Volumes[0].Secret.Items)
+			assert.Equal(t, *volume.DefaultMode, int32(0400))
+			assert.Equal(t, volume.Optional, true)
+		})
+	}
+}
+
+func TestValidateSecretReferences(t *testing.T) {
+	testCases := []struct {
+		name    string
+		spec    corev1.PodSpec
+		wantErr string
+	}{
+		{
+			name: "secret volume",
+			spec: corev1.PodSpec{
+				Volumes: []corev1.Volume{
+					{Name: "credentials", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "my-secret"}}},
+				},
+			},
+		},
+		{
+			name: "projected secret",
+			spec: corev1.PodSpec{
+				Volumes: []corev1.Volume{
+					{Name: "credentials", VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{
+						Sources: []corev1.VolumeProjection{{Secret: &corev1.SecretProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "my-secret"}}}},
+					}}},
+				},
+			},
+			wantErr: "volume credentials projects secret my-secret",
+		},
+		{
+			name: "secret env",
+			spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{
+					{Name: "init", Env: []corev1.EnvVar{{Name: "PASSWORD", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "my-secret"},
+						Key:                  "password",
+					}}}}},
+				},
+			},
+			wantErr: "container init references secret my-secret in environment variable PASSWORD",
+		},
+		{
+			name: "secret env from",
+			spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "app", EnvFrom: []corev1.EnvFromSource{{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "my-secret"}}}}},
+				},
+			},
+			wantErr: "container app references secret my-secret in envFrom",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := ValidateSecretReferences(&corev1.Pod{Spec: testCase.spec}, config.SecretBackendTypeCSI)
+			if testCase.wantErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.ErrorContains(t, err, testCase.wantErr)
+			}
+		})
+	}
+}
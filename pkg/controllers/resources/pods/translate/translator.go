@@ -10,6 +10,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/loft-sh/vcluster/config"
 	"github.com/loft-sh/vcluster/pkg/mappings"
 	"github.com/loft-sh/vcluster/pkg/syncer/synccontext"
 	"github.com/loft-sh/vcluster/pkg/util/loghelper"
@@ -21,6 +22,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
@@ -40,6 +42,12 @@ const (
 	ClusterAutoScalerDaemonSetAnnotation = "cluster-autoscaler.kubernetes.io/daemonset-pod"
 	ServiceAccountNameAnnotation         = "vcluster.loft.sh/service-account-name"
 	ServiceAccountTokenAnnotation        = "vcluster.loft.sh/token-"
+
+	// TokenBoundSecretAnnotation is set on pods without virtual counterpart, e.g. job pods, to bind the service account
+	// tokens to the given secret instead of the pod
+	TokenBoundSecretAnnotation = "vcluster.loft.sh/token-bound-secret"
+	// TokenBoundSecretUIDAnnotation is the uid of the secret in TokenBoundSecretAnnotation
+	TokenBoundSecretUIDAnnotation = "vcluster.loft.sh/token-bound-secret-uid"
 )
 
 var (
@@ -56,7 +64,12 @@ type Translator interface {
 }
 
 func NewTranslator(ctx *synccontext.RegisterContext, eventRecorder record.EventRecorder) (Translator, error) {
-	imageTranslator, err := NewImageTranslator(ctx.Config.Sync.ToHost.Pods.TranslateImage)
+	imageTranslator, err := NewImageTranslator(ctx.Config.Sync.ToHost.Pods.TranslateImage, ctx.Config.Sync.ToHost.Pods.TranslateImageRules)
+	if err != nil {
+		return nil, err
+	}
+
+	schedulingPolicy, err := newSchedulingPolicy(ctx.Config.Sync.ToHost.Pods.SchedulingPolicy)
 	if err != nil {
 		return nil, err
 	}
@@ -80,18 +93,27 @@ func NewTranslator(ctx *synccontext.RegisterContext, eventRecorder record.EventR
 		return nil, fmt.Errorf("parse init container resource requests: %w", err)
 	}
 
+	// secret volumes are only replaced if the csi or kms secret backend is used
+	var secretBackend *config.SecretBackend
+	if UsesSecretVolumeBackend(ctx.Config.Sync.ToHost.Secrets.Backend.Type) {
+		secretBackend = &ctx.Config.Sync.ToHost.Secrets.Backend
+	}
+
 	return &translator{
 		vClientConfig: ctx.VirtualManager.GetConfig(),
 		vClient:       ctx.VirtualManager.GetClient(),
 
-		pClient:         ctx.PhysicalManager.GetClient(),
-		imageTranslator: imageTranslator,
-		eventRecorder:   eventRecorder,
-		log:             loghelper.New("pods-syncer-translator"),
+		pClient:          ctx.PhysicalManager.GetClient(),
+		imageTranslator:  imageTranslator,
+		schedulingPolicy: schedulingPolicy,
+		eventRecorder:    eventRecorder,
+		log:              loghelper.New("pods-syncer-translator"),
 
 		defaultImageRegistry: ctx.Config.ControlPlane.Advanced.DefaultImageRegistry,
 
 		serviceAccountSecretsEnabled: ctx.Config.Sync.ToHost.Pods.UseSecretsForSATokens,
+		secretBackend:                secretBackend,
+		secretBackendEndpoint:        fmt.Sprintf("https://%s.%s.svc:443", ctx.Config.ControlPlaneService, ctx.Config.ControlPlaneNamespace),
 		clusterDomain:                ctx.Config.Networking.Advanced.ClusterDomain,
 		serviceAccount:               ctx.Config.ControlPlane.Advanced.WorkloadServiceAccount.Name,
 
@@ -112,12 +134,13 @@ func NewTranslator(ctx *synccontext.RegisterContext, eventRecorder record.EventR
 }
 
 type translator struct {
-	vClientConfig   *rest.Config
-	vClient         client.Client
-	pClient         client.Client
-	imageTranslator ImageTranslator
-	eventRecorder   record.EventRecorder
-	log             loghelper.Logger
+	vClientConfig    *rest.Config
+	vClient          client.Client
+	pClient          client.Client
+	imageTranslator  ImageTranslator
+	schedulingPolicy *schedulingPolicy
+	eventRecorder    record.EventRecorder
+	log              loghelper.Logger
 
 	defaultImageRegistry string
 
@@ -126,6 +149,8 @@ type translator struct {
 
 	serviceAccountsEnabled       bool
 	serviceAccountSecretsEnabled bool
+	secretBackend                *config.SecretBackend
+	secretBackendEndpoint        string
 	clusterDomain                string
 	serviceAccount               string
 	overrideHosts                bool
@@ -288,7 +313,7 @@ func (t *translator) Translate(ctx *synccontext.SyncContext, vPod *corev1.Pod, s
 		}
 		pPod.Spec.Containers[i].Env = envVar
 		pPod.Spec.Containers[i].EnvFrom = envFrom
-		pPod.Spec.Containers[i].Image = t.imageTranslator.Translate(pPod.Spec.Containers[i].Image)
+		pPod.Spec.Containers[i].Image = t.imageTranslator.Translate(vPod.Namespace, pPod.Spec.Containers[i].Image)
 	}
 
 	// translate init containers
@@ -299,7 +324,7 @@ func (t *translator) Translate(ctx *synccontext.SyncContext, vPod *corev1.Pod, s
 		}
 		pPod.Spec.InitContainers[i].Env = envVar
 		pPod.Spec.InitContainers[i].EnvFrom = envFrom
-		pPod.Spec.InitContainers[i].Image = t.imageTranslator.Translate(pPod.Spec.InitContainers[i].Image)
+		pPod.Spec.InitContainers[i].Image = t.imageTranslator.Translate(vPod.Namespace, pPod.Spec.InitContainers[i].Image)
 	}
 
 	// translate ephemeral containers
@@ -310,7 +335,12 @@ func (t *translator) Translate(ctx *synccontext.SyncContext, vPod *corev1.Pod, s
 		}
 		pPod.Spec.EphemeralContainers[i].Env = envVar
 		pPod.Spec.EphemeralContainers[i].EnvFrom = envFrom
-		pPod.Spec.EphemeralContainers[i].Image = t.imageTranslator.Translate(pPod.Spec.EphemeralContainers[i].Image)
+		pPod.Spec.EphemeralContainers[i].Image = t.imageTranslator.Translate(vPod.Namespace, pPod.Spec.EphemeralContainers[i].Image)
+	}
+
+	// record the original images
+	if originalImages := originalImagesAnnotation(vPod, t.imageTranslator); originalImages != "" {
+		pPod.Annotations[OriginalImagesAnnotation] = originalImages
 	}
 
 	// translate image pull secrets
@@ -347,6 +377,8 @@ func (t *translator) Translate(ctx *synccontext.SyncContext, vPod *corev1.Pod, s
 		}
 	}
 
+	// apply the host scheduling policy
+	t.schedulingPolicy.apply(pPod)
 	return pPod, nil
 }
 
@@ -378,7 +410,14 @@ func (t *translator) translateVolumes(ctx *synccontext.SyncContext, pPod *corev1
 			pPod.Spec.Volumes[i].ConfigMap.Name = mappings.VirtualToHostName(ctx, pPod.Spec.Volumes[i].ConfigMap.Name, vPod.Namespace, mappings.ConfigMaps())
 		}
 		if pPod.Spec.Volumes[i].Secret != nil {
-			pPod.Spec.Volumes[i].Secret.SecretName = mappings.VirtualToHostName(ctx, pPod.Spec.Volumes[i].Secret.SecretName, vPod.Namespace, mappings.Secrets())
+			if t.secretBackend != nil {
+				err := t.translateSecretVolume(ctx, &pPod.Spec.Volumes[i], vPod.Namespace, pPod.Namespace)
+				if err != nil {
+					return err
+				}
+			} else {
+				pPod.Spec.Volumes[i].Secret.SecretName = mappings.VirtualToHostName(ctx, pPod.Spec.Volumes[i].Secret.SecretName, vPod.Namespace, mappings.Secrets())
+			}
 		}
 		if pPod.Spec.Volumes[i].PersistentVolumeClaim != nil {
 			pPod.Spec.Volumes[i].PersistentVolumeClaim.ClaimName = mappings.VirtualToHostName(ctx, pPod.Spec.Volumes[i].PersistentVolumeClaim.ClaimName, vPod.Namespace, mappings.PersistentVolumeClaims())
@@ -492,16 +531,26 @@ func (t *translator) translateProjectedVolume(
 				audiences = []string{projectedVolume.Sources[i].ServiceAccountToken.Audience}
 			}
 
+			boundObjectRef := &authenticationv1.BoundObjectReference{
+				APIVersion: corev1.SchemeGroupVersion.String(),
+				Kind:       "Pod",
+				Name:       vPod.Name,
+				UID:        vPod.UID,
+			}
+			if vPod.Annotations[TokenBoundSecretAnnotation] != "" {
+				boundObjectRef = &authenticationv1.BoundObjectReference{
+					APIVersion: corev1.SchemeGroupVersion.String(),
+					Kind:       "Secret",
+					Name:       vPod.Annotations[TokenBoundSecretAnnotation],
+					UID:        types.UID(vPod.Annotations[TokenBoundSecretUIDAnnotation]),
+				}
+			}
+
 			expirationSeconds := int64(10 * 365 * 24 * 60 * 60)
 			token, err := vClient.CoreV1().ServiceAccounts(vPod.Namespace).CreateToken(ctx, serviceAccountName, &authenticationv1.TokenRequest{
 				Spec: authenticationv1.TokenRequestSpec{
-					Audiences: audiences,
-					BoundObjectRef: &authenticationv1.BoundObjectReference{
-						APIVersion: corev1.SchemeGroupVersion.String(),
-						Kind:       "Pod",
-						Name:       vPod.Name,
-						UID:        vPod.UID,
-					},
+					Audiences:         audiences,
+					BoundObjectRef:    boundObjectRef,
 					ExpirationSeconds: &expirationSeconds,
 				},
 			}, metav1.CreateOptions{})
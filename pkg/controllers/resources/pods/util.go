@@ -1,6 +1,7 @@
 package pods
 
 import (
+	"github.com/loft-sh/vcluster/config"
 	"github.com/loft-sh/vcluster/pkg/syncer/synccontext"
 	"github.com/loft-sh/vcluster/pkg/util/translate"
 
@@ -11,6 +12,16 @@ import (
 
 func SecretNamesFromPod(ctx *synccontext.SyncContext, pod *corev1.Pod) []string {
 	secrets := []string{}
+	if usesSecretVolumeBackend(ctx) {
+		// secret volumes are replaced with csi volumes and secret environment variables are
+		// not allowed, so only image pull secrets and volume secrets are needed
+		for i := range pod.Spec.ImagePullSecrets {
+			secrets = append(secrets, pod.Namespace+"/"+pod.Spec.ImagePullSecrets[i].Name)
+		}
+		secrets = append(secrets, SecretNamesFromVolumes(ctx, pod)...)
+		return translate.UniqueSlice(secrets)
+	}
+
 	for _, c := range pod.Spec.Containers {
 		secrets = append(secrets, SecretNamesFromContainer(pod.Namespace, &c)...)
 	}
@@ -30,12 +41,12 @@ func SecretNamesFromPod(ctx *synccontext.SyncContext, pod *corev1.Pod) []string
 func SecretNamesFromVolumes(ctx *synccontext.SyncContext, pod *corev1.Pod) []string {
 	secrets := []string{}
 	for i := range pod.Spec.Volumes {
-		if pod.Spec.Volumes[i].Secret != nil {
+		if pod.Spec.Volumes[i].Secret != nil && !usesSecretBackendCSI(ctx) {
 			secrets = append(secrets, pod.Namespace+"/"+pod.Spec.Volumes[i].Secret.SecretName)
 		}
 		if pod.Spec.Volumes[i].Projected != nil {
 			for j := range pod.Spec.Volumes[i].Projected.Sources {
-				if pod.Spec.Volumes[i].Projected.Sources[j].Secret != nil {
+				if pod.Spec.Volumes[i].Projected.Sources[j].Secret != nil && !usesSecretBackendCSI(ctx) {
 					secrets = append(secrets, pod.Namespace+"/"+pod.Spec.Volumes[i].Projected.Sources[j].Secret.Name)
 				}
 
@@ -106,3 +117,11 @@ func SecretNamesFromEphemeralContainer(namespace string, container *corev1.Ephem
 	}
 	return secrets
 }
+
+func usesSecretBackendCSI(ctx *synccontext.SyncContext) bool {
+	return ctx != nil && ctx.Config != nil && ctx.Config.Sync.ToHost.Secrets.Backend.Type == config.SecretBackendTypeCSI
+}
+
+func usesSecretVolumeBackend(ctx *synccontext.SyncContext) bool {
+	return ctx != nil && ctx.Config != nil && podtranslate.UsesSecretVolumeBackend(ctx.Config.Sync.ToHost.Secrets.Backend.Type)
+}
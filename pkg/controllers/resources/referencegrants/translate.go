@@ -0,0 +1,55 @@
+package referencegrants
+
+import (
+	"github.com/loft-sh/vcluster/pkg/mappings"
+	"github.com/loft-sh/vcluster/pkg/syncer/synccontext"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+func (s *referenceGrantSyncer) translate(ctx *synccontext.SyncContext, vObj *gatewayv1beta1.ReferenceGrant) *gatewayv1beta1.ReferenceGrant {
+	newReferenceGrant := translate.HostMetadata(ctx, vObj, s.VirtualToHost(ctx, types.NamespacedName{Name: vObj.GetName(), Namespace: vObj.GetNamespace()}, vObj))
+	newReferenceGrant.Spec = *translateSpec(ctx, vObj.Namespace, &vObj.Spec)
+	return newReferenceGrant
+}
+
+func (s *referenceGrantSyncer) translateUpdate(ctx *synccontext.SyncContext, pObj, vObj *gatewayv1beta1.ReferenceGrant) {
+	pObj.Annotations = translate.HostAnnotations(vObj, pObj)
+	pObj.Labels = translate.HostLabels(ctx, vObj, pObj)
+	pObj.Spec = *translateSpec(ctx, vObj.Namespace, &vObj.Spec)
+}
+
+func translateSpec(ctx *synccontext.SyncContext, namespace string, vSpec *gatewayv1beta1.ReferenceGrantSpec) *gatewayv1beta1.ReferenceGrantSpec {
+	retSpec := vSpec.DeepCopy()
+	for i, from := range retSpec.From {
+		// gateways are shared host objects, so their namespace stays the same
+		if from.Group == gatewayv1.GroupName && from.Kind == "Gateway" {
+			continue
+		}
+
+		retSpec.From[i].Namespace = gatewayv1beta1.Namespace(translate.Default.HostNamespace(string(from.Namespace)))
+	}
+
+	for i, to := range retSpec.To {
+		if to.Name == nil || *to.Name == "" {
+			continue
+		}
+
+		var pName string
+		switch {
+		case to.Group == "" && to.Kind == "Service":
+			pName = mappings.VirtualToHostName(ctx, string(*to.Name), namespace, mappings.Services())
+		case to.Group == "" && to.Kind == "Secret":
+			pName = mappings.VirtualToHostName(ctx, string(*to.Name), namespace, mappings.Secrets())
+		default:
+			pName = translate.Default.HostName(string(*to.Name), namespace)
+		}
+
+		name := gatewayv1beta1.ObjectName(pName)
+		retSpec.To[i].Name = &name
+	}
+
+	return retSpec
+}
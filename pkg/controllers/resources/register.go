@@ -9,8 +9,13 @@ import (
 	"github.com/loft-sh/vcluster/pkg/controllers/resources/csistoragecapacities"
 	"github.com/loft-sh/vcluster/pkg/controllers/resources/endpoints"
 	"github.com/loft-sh/vcluster/pkg/controllers/resources/events"
+	"github.com/loft-sh/vcluster/pkg/controllers/resources/gatewayclasses"
+	"github.com/loft-sh/vcluster/pkg/controllers/resources/gateways"
+	"github.com/loft-sh/vcluster/pkg/controllers/resources/grpcroutes"
+	"github.com/loft-sh/vcluster/pkg/controllers/resources/httproutes"
 	"github.com/loft-sh/vcluster/pkg/controllers/resources/ingressclasses"
 	"github.com/loft-sh/vcluster/pkg/controllers/resources/ingresses"
+	"github.com/loft-sh/vcluster/pkg/controllers/resources/jobs"
 	"github.com/loft-sh/vcluster/pkg/controllers/resources/namespaces"
 	"github.com/loft-sh/vcluster/pkg/controllers/resources/networkpolicies"
 	"github.com/loft-sh/vcluster/pkg/controllers/resources/nodes"
@@ -19,10 +24,13 @@ import (
 	"github.com/loft-sh/vcluster/pkg/controllers/resources/poddisruptionbudgets"
 	"github.com/loft-sh/vcluster/pkg/controllers/resources/pods"
 	"github.com/loft-sh/vcluster/pkg/controllers/resources/priorityclasses"
+	"github.com/loft-sh/vcluster/pkg/controllers/resources/referencegrants"
 	"github.com/loft-sh/vcluster/pkg/controllers/resources/secrets"
 	"github.com/loft-sh/vcluster/pkg/controllers/resources/serviceaccounts"
 	"github.com/loft-sh/vcluster/pkg/controllers/resources/services"
 	"github.com/loft-sh/vcluster/pkg/controllers/resources/storageclasses"
+	"github.com/loft-sh/vcluster/pkg/controllers/resources/tlsroutes"
+	"github.com/loft-sh/vcluster/pkg/controllers/resources/volumeattachments"
 	"github.com/loft-sh/vcluster/pkg/controllers/resources/volumesnapshots/volumesnapshotclasses"
 	"github.com/loft-sh/vcluster/pkg/controllers/resources/volumesnapshots/volumesnapshotcontents"
 	"github.com/loft-sh/vcluster/pkg/controllers/resources/volumesnapshots/volumesnapshots"
@@ -46,10 +54,17 @@ func getSyncers(ctx *synccontext.RegisterContext) []BuildController {
 		isEnabled(ctx.Config.Sync.ToHost.Secrets.Enabled, secrets.New),
 		isEnabled(ctx.Config.Sync.ToHost.Endpoints.Enabled, endpoints.New),
 		isEnabled(ctx.Config.Sync.ToHost.Pods.Enabled, pods.New),
+		isEnabled(ctx.Config.Sync.ToHost.Jobs.Enabled, jobs.New),
 		isEnabled(ctx.Config.Sync.FromHost.Events.Enabled, events.New),
 		isEnabled(ctx.Config.Sync.ToHost.PersistentVolumeClaims.Enabled, persistentvolumeclaims.New),
 		isEnabled(ctx.Config.Sync.ToHost.Ingresses.Enabled, ingresses.New),
 		isEnabled(ctx.Config.Sync.FromHost.IngressClasses.Enabled, ingressclasses.New),
+		isEnabled(ctx.Config.Sync.ToHost.Gateway.Enabled, httproutes.New),
+		isEnabled(ctx.Config.Sync.ToHost.Gateway.Enabled, grpcroutes.New),
+		isEnabled(ctx.Config.Sync.ToHost.Gateway.Enabled && ctx.Config.Sync.ToHost.Gateway.TLSRoutes.Enabled, tlsroutes.New),
+		isEnabled(ctx.Config.Sync.ToHost.Gateway.Enabled, referencegrants.New),
+		isEnabled(ctx.Config.Sync.FromHost.Gateways.Enabled, gatewayclasses.New),
+		isEnabled(ctx.Config.Sync.FromHost.Gateways.Enabled, gateways.New),
 		isEnabled(ctx.Config.Sync.ToHost.StorageClasses.Enabled, storageclasses.New),
 		isEnabled(ctx.Config.Sync.FromHost.StorageClasses.Enabled == "true", storageclasses.NewHostStorageClassSyncer),
 		isEnabled(ctx.Config.Sync.ToHost.PriorityClasses.Enabled, priorityclasses.New),
@@ -62,7 +77,8 @@ func getSyncers(ctx *synccontext.RegisterContext) []BuildController {
 		isEnabled(ctx.Config.Sync.FromHost.CSINodes.Enabled == "true", csinodes.New),
 		isEnabled(ctx.Config.Sync.FromHost.CSIDrivers.Enabled == "true", csidrivers.New),
 		isEnabled(ctx.Config.Sync.FromHost.CSIStorageCapacities.Enabled == "true", csistoragecapacities.New),
-		isEnabled(ctx.Config.Experimental.MultiNamespaceMode.Enabled, namespaces.New),
+		isEnabled(ctx.Config.Sync.FromHost.VolumeAttachments.Enabled, volumeattachments.New),
+		isEnabled(ctx.Config.Sync.ToHost.Namespaces.Enabled, namespaces.New),
 		persistentvolumes.New,
 		nodes.New,
 	}, ExtraControllers...)
@@ -0,0 +1,59 @@
+package secrets
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/loft-sh/vcluster/config"
+	"github.com/loft-sh/vcluster/pkg/secretbackend"
+	"github.com/loft-sh/vcluster/pkg/syncer/synccontext"
+	"k8s.io/apiserver/pkg/storage/value/encrypt/envelope/kmsv2"
+	kmsservice "k8s.io/kms/pkg/service"
+)
+
+const defaultKMSTimeout = 3 * time.Second
+
+// kmsEnvelope lazily loads the data key of the kms secret backend, because the data key secret can only be read
+// after the caches have been started
+type kmsEnvelope struct {
+	m sync.Mutex
+
+	service  kmsservice.Service
+	envelope *secretbackend.Envelope
+}
+
+func newKMSEnvelope(ctx *synccontext.RegisterContext, kms config.ControlPlaneEncryptionKMS) (*kmsEnvelope, error) {
+	timeout := defaultKMSTimeout
+	if kms.Timeout != "" {
+		var err error
+		timeout, err = time.ParseDuration(kms.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("parse kms timeout: %w", err)
+		}
+	}
+
+	service, err := kmsv2.NewGRPCService(ctx, kms.Endpoint, kms.Name, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("create kms service: %w", err)
+	}
+
+	return &kmsEnvelope{service: service}, nil
+}
+
+func (k *kmsEnvelope) get(ctx *synccontext.SyncContext) (*secretbackend.Envelope, error) {
+	k.m.Lock()
+	defer k.m.Unlock()
+
+	if k.envelope != nil {
+		return k.envelope, nil
+	}
+
+	envelope, err := secretbackend.NewEnvelope(ctx, k.service, ctx.CurrentNamespaceClient, ctx.CurrentNamespace, ctx.Config.Name+"-secret-backend-key")
+	if err != nil {
+		return nil, err
+	}
+
+	k.envelope = envelope
+	return envelope, nil
+}
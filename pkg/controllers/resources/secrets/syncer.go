@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/loft-sh/vcluster/config"
 	"github.com/loft-sh/vcluster/pkg/mappings"
 	"github.com/loft-sh/vcluster/pkg/patcher"
+	"github.com/loft-sh/vcluster/pkg/secretbackend"
 	"github.com/loft-sh/vcluster/pkg/syncer"
 	"github.com/loft-sh/vcluster/pkg/syncer/synccontext"
 	"github.com/loft-sh/vcluster/pkg/syncer/translator"
@@ -18,8 +20,10 @@ import (
 
 	"github.com/loft-sh/vcluster/pkg/constants"
 	"github.com/loft-sh/vcluster/pkg/controllers/resources/ingresses"
+	"github.com/loft-sh/vcluster/pkg/controllers/resources/jobs"
 	"github.com/loft-sh/vcluster/pkg/controllers/resources/pods"
 	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -40,12 +44,23 @@ func NewSyncer(ctx *synccontext.RegisterContext) (syncertypes.Object, error) {
 		return nil, err
 	}
 
+	// with the kms secret backend the secret data is encrypted before it is written to the host cluster
+	var envelope *kmsEnvelope
+	if ctx.Config.Sync.ToHost.Secrets.Backend.Type == config.SecretBackendTypeKMS {
+		envelope, err = newKMSEnvelope(ctx, ctx.Config.Sync.ToHost.Secrets.Backend.KMS)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &secretSyncer{
 		GenericTranslator: translator.NewGenericTranslator(ctx, "secret", &corev1.Secret{}, mapper),
 
 		includeIngresses: ctx.Config.Sync.ToHost.Ingresses.Enabled,
+		includeJobs:      ctx.Config.Sync.ToHost.Jobs.Enabled,
 
 		syncAllSecrets: ctx.Config.Sync.ToHost.Secrets.All,
+		envelope:       envelope,
 	}, nil
 }
 
@@ -53,8 +68,10 @@ type secretSyncer struct {
 	syncertypes.GenericTranslator
 
 	includeIngresses bool
+	includeJobs      bool
 
 	syncAllSecrets bool
+	envelope       *kmsEnvelope
 }
 
 var _ syncertypes.Syncer = &secretSyncer{}
@@ -75,6 +92,15 @@ func (s *secretSyncer) RegisterIndices(ctx *synccontext.RegisterContext) error {
 		}
 	}
 
+	if ctx.Config.Sync.ToHost.Jobs.Enabled {
+		err := ctx.VirtualManager.GetFieldIndexer().IndexField(ctx, &batchv1.Job{}, constants.IndexByJobSecret, func(rawObj client.Object) []string {
+			return pods.SecretNamesFromPod(ctx.ToSyncContext("secret-indexer"), jobs.TemplatePod(rawObj.(*batchv1.Job)))
+		})
+		if err != nil {
+			return err
+		}
+	}
+
 	err := ctx.VirtualManager.GetFieldIndexer().IndexField(ctx, &corev1.Pod{}, constants.IndexByPodSecret, func(rawObj client.Object) []string {
 		return pods.SecretNamesFromPod(ctx.ToSyncContext("secret-indexer"), rawObj.(*corev1.Pod))
 	})
@@ -94,6 +120,12 @@ func (s *secretSyncer) ModifyController(registerCtx *synccontext.RegisterContext
 		}))
 	}
 
+	if s.includeJobs {
+		builder = builder.Watches(&batchv1.Job{}, handler.EnqueueRequestsFromMapFunc(func(_ context.Context, object client.Object) []reconcile.Request {
+			return mapJobs(registerCtx.ToSyncContext("secret-syncer"), object)
+		}))
+	}
+
 	return builder.Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(func(_ context.Context, object client.Object) []reconcile.Request {
 		return mapPods(registerCtx.ToSyncContext("secret-syncer"), object)
 	})), nil
@@ -117,6 +149,17 @@ func (s *secretSyncer) SyncToHost(ctx *synccontext.SyncContext, event *syncconte
 	if newSecret.Type == corev1.SecretTypeServiceAccountToken {
 		newSecret.Type = corev1.SecretTypeOpaque
 	}
+	if s.envelope != nil && secretbackend.ShouldEncrypt(event.Virtual) {
+		envelope, err := s.envelope.get(ctx)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("get kms envelope: %w", err)
+		}
+
+		err = envelope.EncryptSecret(newSecret, event.Virtual.Data)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("encrypt secret: %w", err)
+		}
+	}
 
 	return syncer.CreateHostObject(ctx, event.Virtual, newSecret, s.EventRecorder())
 }
@@ -151,17 +194,33 @@ func (s *secretSyncer) Sync(ctx *synccontext.SyncContext, event *synccontext.Syn
 		}
 	}()
 
-	// check data
-	event.TargetObject().Data = event.SourceObject().Data
-
 	// check secret type
 	if event.Virtual.Type != event.Host.Type && event.Virtual.Type != corev1.SecretTypeServiceAccountToken {
 		event.TargetObject().Type = event.SourceObject().Type
 	}
 
 	// check annotations
-	event.Host.Annotations = translate.HostAnnotations(event.Virtual, event.Host)
+	event.Host.Annotations = translate.HostAnnotations(event.Virtual, event.Host, secretbackend.EncryptionAnnotations...)
 	event.Host.Labels = translate.HostLabels(ctx, event.Virtual, event.Host)
+
+	// check data
+	if s.envelope != nil && secretbackend.ShouldEncrypt(event.Virtual) {
+		// the host secret only holds encrypted data, so the virtual secret is always the source of truth
+		envelope, err := s.envelope.get(ctx)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("get kms envelope: %w", err)
+		}
+
+		if envelope.Changed(event.Host, event.Virtual.Data) {
+			err = envelope.EncryptSecret(event.Host, event.Virtual.Data)
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("encrypt secret: %w", err)
+			}
+		}
+	} else {
+		event.TargetObject().Data = event.SourceObject().Data
+	}
+
 	return ctrl.Result{}, nil
 }
 
@@ -200,6 +259,20 @@ func (s *secretSyncer) isSecretUsed(ctx *synccontext.SyncContext, vObj runtime.O
 		}
 	}
 
+	// check if we also sync jobs
+	if s.includeJobs {
+		jobList := &batchv1.JobList{}
+		err := ctx.VirtualClient.List(ctx, jobList, client.MatchingFields{constants.IndexByJobSecret: secret.Namespace + "/" + secret.Name})
+		if err != nil {
+			return false, err
+		}
+
+		isUsed = meta.LenList(jobList) > 0
+		if isUsed {
+			return true, nil
+		}
+	}
+
 	if s.syncAllSecrets {
 		return true, nil
 	}
@@ -213,21 +286,7 @@ func mapIngresses(ctx *synccontext.SyncContext, obj client.Object) []reconcile.R
 		return nil
 	}
 
-	requests := []reconcile.Request{}
-	names := ingresses.SecretNamesFromIngress(ctx, ingress)
-	for _, name := range names {
-		splitted := strings.Split(name, "/")
-		if len(splitted) == 2 {
-			requests = append(requests, reconcile.Request{
-				NamespacedName: types.NamespacedName{
-					Namespace: splitted[0],
-					Name:      splitted[1],
-				},
-			})
-		}
-	}
-
-	return requests
+	return namesToRequests(ingresses.SecretNamesFromIngress(ctx, ingress))
 }
 
 func mapPods(ctx *synccontext.SyncContext, obj client.Object) []reconcile.Request {
@@ -236,8 +295,20 @@ func mapPods(ctx *synccontext.SyncContext, obj client.Object) []reconcile.Reques
 		return nil
 	}
 
+	return namesToRequests(pods.SecretNamesFromPod(ctx, pod))
+}
+
+func mapJobs(ctx *synccontext.SyncContext, obj client.Object) []reconcile.Request {
+	job, ok := obj.(*batchv1.Job)
+	if !ok {
+		return nil
+	}
+
+	return namesToRequests(pods.SecretNamesFromPod(ctx, jobs.TemplatePod(job)))
+}
+
+func namesToRequests(names []string) []reconcile.Request {
 	requests := []reconcile.Request{}
-	names := pods.SecretNamesFromPod(ctx, pod)
 	for _, name := range names {
 		splitted := strings.Split(name, "/")
 		if len(splitted) == 2 {
@@ -1,8 +1,15 @@
 package secrets
 
 import (
+	"bytes"
+	"context"
 	"testing"
 
+	vclusterconfig "github.com/loft-sh/vcluster/config"
+	"github.com/loft-sh/vcluster/pkg/config"
+	"github.com/loft-sh/vcluster/pkg/constants"
+	"github.com/loft-sh/vcluster/pkg/secretbackend"
+
 	"github.com/loft-sh/vcluster/pkg/scheme"
 	"github.com/loft-sh/vcluster/pkg/syncer/synccontext"
 	generictesting "github.com/loft-sh/vcluster/pkg/syncer/testing"
@@ -15,6 +22,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	kmsservice "k8s.io/kms/pkg/service"
 )
 
 func newFakeSyncer(t *testing.T, ctx *synccontext.RegisterContext) (*synccontext.SyncContext, syncer.Object) {
@@ -154,6 +163,83 @@ func TestSync(t *testing.T) {
 	})
 }
 
+type fakeKMS struct{}
+
+func (fakeKMS) Encrypt(_ context.Context, _ string, data []byte) (*kmsservice.EncryptResponse, error) {
+	return &kmsservice.EncryptResponse{Ciphertext: append([]byte("kms:"), data...), KeyID: "key-1"}, nil
+}
+
+func (fakeKMS) Decrypt(_ context.Context, _ string, req *kmsservice.DecryptRequest) ([]byte, error) {
+	return bytes.TrimPrefix(req.Ciphertext, []byte("kms:")), nil
+}
+
+func (fakeKMS) Status(context.Context) (*kmsservice.StatusResponse, error) {
+	return &kmsservice.StatusResponse{Version: "v2", Healthz: "ok", KeyID: "key-1"}, nil
+}
+
+func TestSyncKMS(t *testing.T) {
+	vSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-secret",
+			Namespace: "test",
+			Annotations: map[string]string{
+				constants.SyncResourceAnnotation: "true",
+			},
+		},
+		Data: map[string][]byte{
+			"password": []byte("secret"),
+		},
+	}
+	pSecretName := types.NamespacedName{Name: translate.Default.HostName(vSecret.Name, vSecret.Namespace), Namespace: "test"}
+
+	generictesting.RunTests(t, []*generictesting.SyncTest{
+		{
+			Name: "Encrypt secret",
+			AdjustConfig: func(vConfig *config.VirtualClusterConfig) {
+				vConfig.Sync.ToHost.Secrets.Backend.Type = vclusterconfig.SecretBackendTypeKMS
+				vConfig.Sync.ToHost.Secrets.Backend.KMS.Name = "test"
+				vConfig.Sync.ToHost.Secrets.Backend.KMS.Endpoint = "unix:///tmp/kms.sock"
+			},
+			InitialVirtualState: []runtime.Object{vSecret.DeepCopy()},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncContext, syncer := newFakeSyncer(t, ctx)
+				syncer.(*secretSyncer).envelope.service = fakeKMS{}
+				_, err := syncer.(*secretSyncer).SyncToHost(syncContext, synccontext.NewSyncToHostEvent(vSecret.DeepCopy()))
+				assert.NilError(t, err)
+
+				pSecret := &corev1.Secret{}
+				err = ctx.PhysicalManager.GetClient().Get(ctx, pSecretName, pSecret)
+				assert.NilError(t, err)
+				assert.Assert(t, !bytes.Equal(pSecret.Data["password"], vSecret.Data["password"]))
+				data, err := secretbackend.DecryptSecret(ctx, fakeKMS{}, pSecret)
+				assert.NilError(t, err)
+				assert.DeepEqual(t, data, vSecret.Data)
+
+				// an unchanged secret should not be encrypted again
+				pSecret.ResourceVersion = generictesting.FakeClientResourceVersion
+				vSecret := vSecret.DeepCopy()
+				vSecret.ResourceVersion = generictesting.FakeClientResourceVersion
+				encrypted := pSecret.Data["password"]
+				_, err = syncer.(*secretSyncer).Sync(syncContext, synccontext.NewSyncEvent(pSecret.DeepCopy(), vSecret.DeepCopy()))
+				assert.NilError(t, err)
+				err = ctx.PhysicalManager.GetClient().Get(ctx, pSecretName, pSecret)
+				assert.NilError(t, err)
+				assert.DeepEqual(t, pSecret.Data["password"], encrypted)
+
+				// a changed secret should be encrypted again
+				vSecret.Data["password"] = []byte("changed")
+				_, err = syncer.(*secretSyncer).Sync(syncContext, synccontext.NewSyncEvent(pSecret.DeepCopy(), vSecret.DeepCopy()))
+				assert.NilError(t, err)
+				err = ctx.PhysicalManager.GetClient().Get(ctx, pSecretName, pSecret)
+				assert.NilError(t, err)
+				data, err = secretbackend.DecryptSecret(ctx, fakeKMS{}, pSecret)
+				assert.NilError(t, err)
+				assert.DeepEqual(t, data, vSecret.Data)
+			},
+		},
+	})
+}
+
 func TestMapping(t *testing.T) {
 	// test ingress
 	ingress := &networkingv1.Ingress{
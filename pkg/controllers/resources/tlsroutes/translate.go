@@ -0,0 +1,34 @@
+package tlsroutes
+
+import (
+	"github.com/loft-sh/vcluster/pkg/controllers/resources/gateways"
+	"github.com/loft-sh/vcluster/pkg/syncer/synccontext"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+func (s *tlsRouteSyncer) translate(ctx *synccontext.SyncContext, vObj *gatewayv1alpha2.TLSRoute) *gatewayv1alpha2.TLSRoute {
+	newRoute := translate.HostMetadata(ctx, vObj, s.VirtualToHost(ctx, types.NamespacedName{Name: vObj.GetName(), Namespace: vObj.GetNamespace()}, vObj))
+	newRoute.Spec = *translateSpec(ctx, vObj.Namespace, &vObj.Spec)
+	newRoute.Status = gatewayv1alpha2.TLSRouteStatus{}
+	return newRoute
+}
+
+func (s *tlsRouteSyncer) translateUpdate(ctx *synccontext.SyncContext, pObj, vObj *gatewayv1alpha2.TLSRoute) {
+	pObj.Annotations = translate.HostAnnotations(vObj, pObj)
+	pObj.Labels = translate.HostLabels(ctx, vObj, pObj)
+	pObj.Spec = *translateSpec(ctx, vObj.Namespace, &vObj.Spec)
+}
+
+func translateSpec(ctx *synccontext.SyncContext, namespace string, vSpec *gatewayv1alpha2.TLSRouteSpec) *gatewayv1alpha2.TLSRouteSpec {
+	retSpec := vSpec.DeepCopy()
+	retSpec.ParentRefs = gateways.TranslateParentRefs(ctx, namespace, retSpec.ParentRefs)
+	for i, rule := range retSpec.Rules {
+		for j, backendRef := range rule.BackendRefs {
+			retSpec.Rules[i].BackendRefs[j].BackendObjectReference = gateways.TranslateBackendObjectReference(ctx, namespace, backendRef.BackendObjectReference)
+		}
+	}
+
+	return retSpec
+}
@@ -0,0 +1,89 @@
+package volumeattachments
+
+import (
+	"fmt"
+
+	"github.com/loft-sh/vcluster/pkg/mappings"
+	"github.com/loft-sh/vcluster/pkg/patcher"
+	"github.com/loft-sh/vcluster/pkg/syncer"
+	"github.com/loft-sh/vcluster/pkg/syncer/synccontext"
+	syncertypes "github.com/loft-sh/vcluster/pkg/syncer/types"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func New(ctx *synccontext.RegisterContext) (syncertypes.Object, error) {
+	mapper, err := ctx.Mappings.ByGVK(mappings.VolumeAttachments())
+	if err != nil {
+		return nil, err
+	}
+
+	return &volumeAttachmentSyncer{
+		Mapper: mapper,
+	}, nil
+}
+
+type volumeAttachmentSyncer struct {
+	synccontext.Mapper
+}
+
+func (s *volumeAttachmentSyncer) Name() string {
+	return "volumeattachment"
+}
+
+func (s *volumeAttachmentSyncer) Resource() client.Object {
+	return &storagev1.VolumeAttachment{}
+}
+
+var _ syncertypes.Syncer = &volumeAttachmentSyncer{}
+
+func (s *volumeAttachmentSyncer) Syncer() syncertypes.Sync[client.Object] {
+	return syncer.ToGenericSyncer[*storagev1.VolumeAttachment](s)
+}
+
+func (s *volumeAttachmentSyncer) SyncToVirtual(ctx *synccontext.SyncContext, event *synccontext.SyncToVirtualEvent[*storagev1.VolumeAttachment]) (ctrl.Result, error) {
+	vObj := translate.CopyObjectWithName(event.Host, types.NamespacedName{Name: event.Host.Name}, false)
+	s.translateSpec(ctx, &vObj.Spec)
+	ctx.Log.Infof("create VolumeAttachment %s, because it does not exist in virtual cluster", vObj.Name)
+	return ctrl.Result{}, ctx.VirtualClient.Create(ctx, vObj)
+}
+
+func (s *volumeAttachmentSyncer) Sync(ctx *synccontext.SyncContext, event *synccontext.SyncEvent[*storagev1.VolumeAttachment]) (_ ctrl.Result, retErr error) {
+	patch, err := patcher.NewSyncerPatcher(ctx, event.Host, event.Virtual)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("new syncer patcher: %w", err)
+	}
+	defer func() {
+		if err := patch.Patch(ctx, event.Host, event.Virtual); err != nil {
+			retErr = utilerrors.NewAggregate([]error{retErr, err})
+		}
+	}()
+
+	event.Virtual.Annotations = event.Host.Annotations
+	event.Virtual.Labels = event.Host.Labels
+	event.Host.Spec.DeepCopyInto(&event.Virtual.Spec)
+	s.translateSpec(ctx, &event.Virtual.Spec)
+	event.Host.Status.DeepCopyInto(&event.Virtual.Status)
+	return ctrl.Result{}, nil
+}
+
+func (s *volumeAttachmentSyncer) SyncToHost(ctx *synccontext.SyncContext, event *synccontext.SyncToHostEvent[*storagev1.VolumeAttachment]) (ctrl.Result, error) {
+	ctx.Log.Infof("delete virtual VolumeAttachment %s, because host object is missing", event.Virtual.Name)
+	return ctrl.Result{}, ctx.VirtualClient.Delete(ctx, event.Virtual)
+}
+
+// translateSpec rewrites the host persistent volume name to the name of the persistent volume within the virtual cluster
+func (s *volumeAttachmentSyncer) translateSpec(ctx *synccontext.SyncContext, spec *storagev1.VolumeAttachmentSpec) {
+	if spec.Source.PersistentVolumeName == nil {
+		return
+	}
+
+	vName := mappings.HostToVirtual(ctx, *spec.Source.PersistentVolumeName, "", nil, mappings.PersistentVolumes())
+	if vName.Name != "" {
+		spec.Source.PersistentVolumeName = &vName.Name
+	}
+}
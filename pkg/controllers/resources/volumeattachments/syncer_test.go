@@ -0,0 +1,126 @@
+package volumeattachments
+
+import (
+	"testing"
+
+	"github.com/loft-sh/vcluster/pkg/config"
+	"github.com/loft-sh/vcluster/pkg/constants"
+	"github.com/loft-sh/vcluster/pkg/syncer/synccontext"
+	syncertesting "github.com/loft-sh/vcluster/pkg/syncer/testing"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+)
+
+func TestSync(t *testing.T) {
+	vPv := &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-volume",
+			Annotations: map[string]string{
+				constants.HostClusterPersistentVolumeAnnotation: "host-volume",
+			},
+		},
+	}
+
+	pObj := &storagev1.VolumeAttachment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "csi-123",
+		},
+		Spec: storagev1.VolumeAttachmentSpec{
+			Attacher: "ebs.csi.aws.com",
+			NodeName: "node-1",
+			Source: storagev1.VolumeAttachmentSource{
+				PersistentVolumeName: ptr.To("host-volume"),
+			},
+		},
+	}
+	vObj := pObj.DeepCopy()
+	vObj.Spec.Source.PersistentVolumeName = ptr.To(vPv.Name)
+
+	pObjUpdated := pObj.DeepCopy()
+	pObjUpdated.Status = storagev1.VolumeAttachmentStatus{
+		AttachError: &storagev1.VolumeError{
+			Message: "rpc error: volume is attached to another node",
+		},
+	}
+	vObjUpdated := vObj.DeepCopy()
+	vObjUpdated.Status = pObjUpdated.Status
+
+	pOtherObj := pObj.DeepCopy()
+	pOtherObj.Name = "csi-456"
+	pOtherObj.Spec.Source.PersistentVolumeName = ptr.To("other-volume")
+
+	adjustConfig := func(vConfig *config.VirtualClusterConfig) {
+		vConfig.Sync.FromHost.VolumeAttachments.Enabled = true
+	}
+
+	syncertesting.RunTests(t, []*syncertesting.SyncTest{
+		{
+			Name:                 "Sync Up",
+			AdjustConfig:         adjustConfig,
+			InitialVirtualState:  []runtime.Object{vPv},
+			InitialPhysicalState: []runtime.Object{pObj},
+			ExpectedVirtualState: map[schema.GroupVersionKind][]runtime.Object{
+				corev1.SchemeGroupVersion.WithKind("PersistentVolume"):    {vPv},
+				storagev1.SchemeGroupVersion.WithKind("VolumeAttachment"): {vObj},
+			},
+			ExpectedPhysicalState: map[schema.GroupVersionKind][]runtime.Object{
+				storagev1.SchemeGroupVersion.WithKind("VolumeAttachment"): {pObj},
+			},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := syncertesting.FakeStartSyncer(t, ctx, New)
+				_, err := syncer.(*volumeAttachmentSyncer).SyncToVirtual(syncCtx, synccontext.NewSyncToVirtualEvent(pObj))
+				assert.NilError(t, err)
+			},
+		},
+		{
+			Name:                 "Sync status",
+			AdjustConfig:         adjustConfig,
+			InitialVirtualState:  []runtime.Object{vPv, vObj},
+			InitialPhysicalState: []runtime.Object{pObjUpdated},
+			ExpectedVirtualState: map[schema.GroupVersionKind][]runtime.Object{
+				corev1.SchemeGroupVersion.WithKind("PersistentVolume"):    {vPv},
+				storagev1.SchemeGroupVersion.WithKind("VolumeAttachment"): {vObjUpdated},
+			},
+			ExpectedPhysicalState: map[schema.GroupVersionKind][]runtime.Object{
+				storagev1.SchemeGroupVersion.WithKind("VolumeAttachment"): {pObjUpdated},
+			},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := syncertesting.FakeStartSyncer(t, ctx, New)
+				_, err := syncer.(*volumeAttachmentSyncer).Sync(syncCtx, synccontext.NewSyncEvent(pObjUpdated, vObj))
+				assert.NilError(t, err)
+			},
+		},
+		{
+			Name:                 "Sync Down",
+			AdjustConfig:         adjustConfig,
+			InitialVirtualState:  []runtime.Object{vObj},
+			ExpectedVirtualState: map[schema.GroupVersionKind][]runtime.Object{},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := syncertesting.FakeStartSyncer(t, ctx, New)
+				_, err := syncer.(*volumeAttachmentSyncer).SyncToHost(syncCtx, synccontext.NewSyncToHostEvent(vObj))
+				assert.NilError(t, err)
+			},
+		},
+		{
+			Name:                 "Is managed",
+			AdjustConfig:         adjustConfig,
+			InitialVirtualState:  []runtime.Object{vPv},
+			InitialPhysicalState: []runtime.Object{pObj, pOtherObj},
+			Sync: func(ctx *synccontext.RegisterContext) {
+				syncCtx, syncer := syncertesting.FakeStartSyncer(t, ctx, New)
+				isManaged, err := syncer.(*volumeAttachmentSyncer).IsManaged(syncCtx, pObj)
+				assert.NilError(t, err)
+				assert.Assert(t, isManaged)
+
+				isManaged, err = syncer.(*volumeAttachmentSyncer).IsManaged(syncCtx, pOtherObj)
+				assert.NilError(t, err)
+				assert.Assert(t, !isManaged)
+			},
+		},
+	})
+}
@@ -0,0 +1,210 @@
+package credentials
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+// Credential is a client certificate that was issued for the virtual cluster
+type Credential struct {
+	// Serial is the decimal serial number of the certificate
+	Serial string `json:"serial"`
+
+	// Subject is the user of the certificate
+	Subject string `json:"subject"`
+
+	// Groups are the groups of the certificate
+	Groups []string `json:"groups,omitempty"`
+
+	// Namespaces are the namespaces the subject was granted access to via role bindings in the virtual cluster. These
+	// role bindings are not removed when the certificate expires or is revoked.
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// NotAfter is the time the certificate expires
+	NotAfter time.Time `json:"notAfter"`
+
+	// Revoked is the time the certificate was revoked
+	Revoked *metav1.Time `json:"revoked,omitempty"`
+}
+
+// SecretName returns the name of the host secret the issued credentials are tracked in
+func SecretName(vClusterName string) string {
+	return "vc-credentials-" + vClusterName
+}
+
+// Record tracks the issued client certificate and the namespaces it was granted access to in the credentials secret.
+// Expired credentials are removed unless they were granted access to namespaces.
+func Record(ctx context.Context, client kubernetes.Interface, vClusterName, namespace string, cert *x509.Certificate, namespaces []string) error {
+	credential := Credential{
+		Serial:     cert.SerialNumber.String(),
+		Subject:    cert.Subject.CommonName,
+		Groups:     cert.Subject.Organization,
+		Namespaces: namespaces,
+		NotAfter:   cert.NotAfter,
+	}
+
+	return update(ctx, client, vClusterName, namespace, func(secret *corev1.Secret) (bool, error) {
+		if _, ok := secret.Data[credential.Serial]; ok {
+			return false, nil
+		}
+
+		data, err := json.Marshal(credential)
+		if err != nil {
+			return false, err
+		}
+
+		secret.Data[credential.Serial] = data
+		return true, nil
+	})
+}
+
+// List returns the tracked credentials sorted by expiry
+func List(ctx context.Context, client kubernetes.Interface, vClusterName, namespace string) ([]Credential, error) {
+	secret, err := client.CoreV1().Secrets(namespace).Get(ctx, SecretName(vClusterName), metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("get credentials secret: %w", err)
+	}
+
+	return FromSecret(secret)
+}
+
+// Revoke revokes all credentials whose serial or subject matches the given value and returns them
+func Revoke(ctx context.Context, client kubernetes.Interface, vClusterName, namespace, serialOrSubject string) ([]Credential, error) {
+	revoked := []Credential{}
+	err := update(ctx, client, vClusterName, namespace, func(secret *corev1.Secret) (bool, error) {
+		revoked = []Credential{}
+		credentials, err := FromSecret(secret)
+		if err != nil {
+			return false, err
+		}
+
+		now := metav1.Now()
+		for _, credential := range credentials {
+			if credential.Serial != serialOrSubject && credential.Subject != serialOrSubject {
+				continue
+			} else if credential.Revoked == nil {
+				credential.Revoked = &now
+			}
+
+			data, err := json.Marshal(credential)
+			if err != nil {
+				return false, err
+			}
+
+			secret.Data[credential.Serial] = data
+			revoked = append(revoked, credential)
+		}
+
+		return len(revoked) > 0, nil
+	})
+	if err != nil {
+		return nil, err
+	} else if len(revoked) == 0 {
+		return nil, fmt.Errorf("couldn't find a credential with serial or subject %s", serialOrSubject)
+	}
+
+	return revoked, nil
+}
+
+// FromSecret parses the credentials of the credentials secret sorted by expiry
+func FromSecret(secret *corev1.Secret) ([]Credential, error) {
+	credentials := []Credential{}
+	for serial, data := range secret.Data {
+		credential := Credential{}
+		err := json.Unmarshal(data, &credential)
+		if err != nil {
+			return nil, fmt.Errorf("parse credential %s: %w", serial, err)
+		}
+
+		credentials = append(credentials, credential)
+	}
+
+	slices.SortFunc(credentials, func(a, b Credential) int {
+		if c := a.NotAfter.Compare(b.NotAfter); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.Serial, b.Serial)
+	})
+	return credentials, nil
+}
+
+// RevokedSerials returns the serials of the revoked credentials that have not expired yet
+func RevokedSerials(secret *corev1.Secret) (map[string]bool, error) {
+	credentials, err := FromSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	revoked := map[string]bool{}
+	for _, credential := range credentials {
+		if credential.Revoked != nil && credential.NotAfter.After(time.Now()) {
+			revoked[credential.Serial] = true
+		}
+	}
+
+	return revoked, nil
+}
+
+func update(ctx context.Context, client kubernetes.Interface, vClusterName, namespace string, mutate func(secret *corev1.Secret) (bool, error)) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		secret, err := client.CoreV1().Secrets(namespace).Get(ctx, SecretName(vClusterName), metav1.GetOptions{})
+		notFound := kerrors.IsNotFound(err)
+		if err != nil && !notFound {
+			return fmt.Errorf("get credentials secret: %w", err)
+		} else if notFound {
+			secret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      SecretName(vClusterName),
+					Namespace: namespace,
+				},
+			}
+		}
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+
+		changed, err := mutate(secret)
+		if err != nil {
+			return err
+		}
+
+		// expired credentials cannot be used anymore, but the role bindings of the namespaces they were granted
+		// access to are still in place and keep being listed
+		for serial, data := range secret.Data {
+			credential := Credential{}
+			if json.Unmarshal(data, &credential) == nil && credential.NotAfter.Before(time.Now()) && len(credential.Namespaces) == 0 {
+				delete(secret.Data, serial)
+				changed = true
+			}
+		}
+		if !changed {
+			return nil
+		} else if notFound {
+			_, err = client.CoreV1().Secrets(namespace).Create(ctx, secret, metav1.CreateOptions{})
+			if kerrors.IsAlreadyExists(err) {
+				return kerrors.NewConflict(corev1.Resource("secrets"), secret.Name, err)
+			}
+
+			return err
+		}
+
+		_, err = client.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{})
+		return err
+	})
+}
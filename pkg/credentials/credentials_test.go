@@ -0,0 +1,60 @@
+package credentials
+
+import (
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCredentials(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewSimpleClientset()
+	newCert := func(serial int64, user string, notAfter time.Time) *x509.Certificate {
+		return &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: user, Organization: []string{"dev"}},
+			NotAfter:     notAfter,
+		}
+	}
+
+	// record certificates
+	assert.NilError(t, Record(ctx, client, "vcluster", "test", newCert(1, "alice", time.Now().Add(time.Hour)), nil))
+	assert.NilError(t, Record(ctx, client, "vcluster", "test", newCert(2, "bob", time.Now().Add(2*time.Hour)), nil))
+	assert.NilError(t, Record(ctx, client, "vcluster", "test", newCert(3, "alice", time.Now().Add(3*time.Hour)), nil))
+	assert.NilError(t, Record(ctx, client, "vcluster", "test", newCert(1, "alice", time.Now().Add(time.Hour)), nil))
+	issued, err := List(ctx, client, "vcluster", "test")
+	assert.NilError(t, err)
+	assert.Equal(t, len(issued), 3)
+	assert.Equal(t, issued[0].Serial, "1")
+	assert.DeepEqual(t, issued[0].Groups, []string{"dev"})
+
+	// revoke by subject and serial
+	revoked, err := Revoke(ctx, client, "vcluster", "test", "alice")
+	assert.NilError(t, err)
+	assert.Equal(t, len(revoked), 2)
+	_, err = Revoke(ctx, client, "vcluster", "test", "2")
+	assert.NilError(t, err)
+	_, err = Revoke(ctx, client, "vcluster", "test", "carol")
+	assert.ErrorContains(t, err, "couldn't find a credential")
+	secret, err := client.CoreV1().Secrets("test").Get(ctx, SecretName("vcluster"), metav1.GetOptions{})
+	assert.NilError(t, err)
+	serials, err := RevokedSerials(secret)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, serials, map[string]bool{"1": true, "2": true, "3": true})
+
+	// expired certificates are removed unless their role bindings persist
+	assert.NilError(t, Record(ctx, client, "vcluster", "test", newCert(4, "carol", time.Now().Add(-time.Hour)), nil))
+	assert.NilError(t, Record(ctx, client, "vcluster", "test", newCert(5, "dave", time.Now().Add(-time.Hour)), []string{"ns1"}))
+	issued, err = List(ctx, client, "vcluster", "test")
+	assert.NilError(t, err)
+	assert.Equal(t, len(issued), 4)
+	assert.Equal(t, issued[0].Serial, "5")
+	assert.DeepEqual(t, issued[0].Namespaces, []string{"ns1"})
+}
@@ -33,6 +33,33 @@ spec:
       bind-address: 127.0.0.1
       enable-admission-plugins: NodeRestriction
       endpoint-reconciler-type: none
+      {{- if .Values.controlPlane.encryption.enabled }}
+      encryption-provider-config: /data/k0s/pki/encryption-config.yaml
+      {{- end }}
+      {{- with .Values.controlPlane.auth.oidc }}
+      {{- if .enabled }}
+      oidc-issuer-url: {{ printf "%q" .issuerURL }}
+      oidc-client-id: {{ printf "%q" .clientID }}
+      {{- if .ca }}
+      oidc-ca-file: /data/oidc/ca.crt
+      {{- end }}
+      {{- if .usernameClaim }}
+      oidc-username-claim: {{ printf "%q" .usernameClaim }}
+      {{- end }}
+      {{- if .usernamePrefix }}
+      oidc-username-prefix: {{ printf "%q" .usernamePrefix }}
+      {{- end }}
+      {{- if .groupsClaim }}
+      oidc-groups-claim: {{ printf "%q" .groupsClaim }}
+      {{- end }}
+      {{- if .groupsPrefix }}
+      oidc-groups-prefix: {{ printf "%q" .groupsPrefix }}
+      {{- end }}
+      {{- if .requiredClaims }}
+      oidc-required-claim: "{{ $sep := "" }}{{ range $claim, $value := .requiredClaims }}{{ $sep }}{{ $claim }}={{ $value }}{{ $sep = "," }}{{ end }}"
+      {{- end }}
+      {{- end }}
+      {{- end }}
   network:
     {{- if .Values.serviceCIDR }}
     serviceCIDR: {{ .Values.serviceCIDR }}
@@ -47,9 +74,9 @@ spec:
   controllerManager:
     extraArgs:
       {{- if not .Values.controlPlane.advanced.virtualScheduler.enabled }}
-      controllers: '*,-nodeipam,-nodelifecycle,-persistentvolume-binder,-attachdetach,-persistentvolume-expander,-cloud-node-lifecycle,-ttl'
+      controllers: '*,-nodeipam,-nodelifecycle,-persistentvolume-binder,-attachdetach,-persistentvolume-expander,-cloud-node-lifecycle,-ttl{{ if .Values.sync.toHost.jobs.enabled }},-job{{ end }}{{ if .Values.sync.toHost.podDisruptionBudgets.enabled }},-disruption{{ end }}'
       {{- else }}
-      controllers: '*,-nodeipam,-persistentvolume-binder,-attachdetach,-persistentvolume-expander,-cloud-node-lifecycle,-ttl'
+      controllers: '*,-nodeipam,-persistentvolume-binder,-attachdetach,-persistentvolume-expander,-cloud-node-lifecycle,-ttl{{ if .Values.sync.toHost.jobs.enabled }},-job{{ end }}{{ if .Values.sync.toHost.podDisruptionBudgets.enabled }},-disruption{{ end }}'
       node-monitor-grace-period: 1h
       node-monitor-period: 1h
       {{- end }}
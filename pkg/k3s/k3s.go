@@ -8,6 +8,7 @@ import (
 	"os/exec"
 	"strings"
 
+	"github.com/loft-sh/vcluster/pkg/authentication/oidc"
 	"github.com/loft-sh/vcluster/pkg/config"
 	"github.com/loft-sh/vcluster/pkg/etcd"
 	"github.com/loft-sh/vcluster/pkg/util/commandwriter"
@@ -40,14 +41,29 @@ func StartK3S(ctx context.Context, vConfig *config.VirtualClusterConfig, service
 		args = append(args, "--egress-selector-mode=disabled")
 		args = append(args, "--flannel-backend=none")
 		args = append(args, "--kube-apiserver-arg=bind-address=127.0.0.1")
+		if vConfig.ControlPlane.Encryption.Enabled {
+			args = append(args, "--kube-apiserver-arg=encryption-provider-config=/data/pki/encryption-config.yaml")
+		}
+		for _, arg := range oidc.APIServerArgs(vConfig.ControlPlane.Auth.OIDC) {
+			args = append(args, "--kube-apiserver-arg="+arg)
+		}
+		disabledControllers := ""
+		if vConfig.Sync.ToHost.Jobs.Enabled {
+			// jobs are run by the host cluster job controller
+			disabledControllers += ",-job"
+		}
+		if vConfig.Sync.ToHost.PodDisruptionBudgets.Enabled {
+			// pod disruption budget status is synced back from the host cluster
+			disabledControllers += ",-disruption"
+		}
 		if vConfig.ControlPlane.Advanced.VirtualScheduler.Enabled {
-			args = append(args, "--kube-controller-manager-arg=controllers=*,-nodeipam,-persistentvolume-binder,-attachdetach,-persistentvolume-expander,-cloud-node-lifecycle,-ttl")
+			args = append(args, "--kube-controller-manager-arg=controllers=*,-nodeipam,-persistentvolume-binder,-attachdetach,-persistentvolume-expander,-cloud-node-lifecycle,-ttl"+disabledControllers)
 			args = append(args, "--kube-apiserver-arg=endpoint-reconciler-type=none")
 			args = append(args, "--kube-controller-manager-arg=node-monitor-grace-period=1h")
 			args = append(args, "--kube-controller-manager-arg=node-monitor-period=1h")
 		} else {
 			args = append(args, "--disable-scheduler")
-			args = append(args, "--kube-controller-manager-arg=controllers=*,-nodeipam,-nodelifecycle,-persistentvolume-binder,-attachdetach,-persistentvolume-expander,-cloud-node-lifecycle,-ttl")
+			args = append(args, "--kube-controller-manager-arg=controllers=*,-nodeipam,-nodelifecycle,-persistentvolume-binder,-attachdetach,-persistentvolume-expander,-cloud-node-lifecycle,-ttl"+disabledControllers)
 			args = append(args, "--kube-apiserver-arg=endpoint-reconciler-type=none")
 		}
 		if vConfig.ControlPlane.BackingStore.Etcd.Deploy.Enabled {
@@ -7,10 +7,13 @@ import (
 	"fmt"
 	"net/http"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"time"
 
 	vclusterconfig "github.com/loft-sh/vcluster/config"
+	"github.com/loft-sh/vcluster/pkg/authentication/oidc"
+	"github.com/loft-sh/vcluster/pkg/certs"
 	"github.com/loft-sh/vcluster/pkg/config"
 	"github.com/loft-sh/vcluster/pkg/constants"
 	"github.com/loft-sh/vcluster/pkg/etcd"
@@ -42,22 +45,7 @@ func StartK8S(
 		}
 
 		// start embedded mode
-		go func() {
-			args := []string{}
-			args = append(args, "/usr/local/bin/kine")
-			args = append(args, "--endpoint="+dataSource)
-			args = append(args, "--ca-file="+vConfig.ControlPlane.BackingStore.Database.External.CaFile)
-			args = append(args, "--key-file="+vConfig.ControlPlane.BackingStore.Database.External.KeyFile)
-			args = append(args, "--cert-file="+vConfig.ControlPlane.BackingStore.Database.External.CertFile)
-			args = append(args, "--metrics-bind-address=0")
-			args = append(args, "--listen-address="+constants.K8sKineEndpoint)
-
-			// now start kine
-			err := RunCommand(ctx, args, "kine")
-			if err != nil {
-				klog.Fatal("could not run kine", err)
-			}
-		}()
+		StartKine(ctx, dataSource, constants.K8sKineEndpoint, vConfig.ControlPlane.BackingStore.Database.External)
 
 		etcdEndpoints = constants.K8sKineEndpoint
 	} else if vConfig.ControlPlane.BackingStore.Database.External.Enabled {
@@ -119,6 +107,12 @@ func StartK8S(
 				args = append(args, "--tls-private-key-file=/data/pki/apiserver.key")
 				args = append(args, "--watch-cache=false")
 				args = append(args, "--endpoint-reconciler-type=none")
+				if vConfig.ControlPlane.Encryption.Enabled {
+					args = append(args, "--encryption-provider-config=/data/pki/encryption-config.yaml")
+				}
+				for _, arg := range oidc.APIServerArgs(vConfig.ControlPlane.Auth.OIDC) {
+					args = append(args, "--"+arg)
+				}
 			}
 
 			// add extra args
@@ -156,15 +150,27 @@ func StartK8S(
 				args = append(args, "--bind-address=127.0.0.1")
 				args = append(args, "--client-ca-file="+vConfig.VirtualClusterKubeConfig().ClientCACert)
 				args = append(args, "--cluster-name=kubernetes")
-				args = append(args, "--cluster-signing-cert-file="+vConfig.VirtualClusterKubeConfig().ServerCACert)
-				args = append(args, "--cluster-signing-key-file="+vConfig.VirtualClusterKubeConfig().ServerCAKey)
+				if vConfig.ControlPlane.Certificates.CA.SecretName != "" {
+					// certificate signing requests are signed by the internal client CA as the cluster CA is managed externally and
+					// must not issue certificates for names requested from within the virtual cluster
+					args = append(args, "--cluster-signing-cert-file="+vConfig.VirtualClusterKubeConfig().ClientCACert)
+					args = append(args, "--cluster-signing-key-file="+strings.TrimSuffix(vConfig.VirtualClusterKubeConfig().ClientCACert, ".crt")+".key")
+				} else {
+					args = append(args, "--cluster-signing-cert-file="+vConfig.VirtualClusterKubeConfig().ServerCACert)
+					args = append(args, "--cluster-signing-key-file="+vConfig.VirtualClusterKubeConfig().ServerCAKey)
+				}
 				args = append(args, "--horizontal-pod-autoscaler-sync-period=60s")
 				args = append(args, "--kubeconfig=/data/pki/controller-manager.conf")
 				args = append(args, "--node-monitor-grace-period=180s")
 				args = append(args, "--node-monitor-period=30s")
 				args = append(args, "--pvclaimbinder-sync-period=60s")
 				args = append(args, "--requestheader-client-ca-file="+vConfig.VirtualClusterKubeConfig().RequestHeaderCACert)
-				args = append(args, "--root-ca-file="+vConfig.VirtualClusterKubeConfig().ServerCACert)
+				if vConfig.ControlPlane.Certificates.CertManager.Enabled {
+					// pods need to trust the cert-manager issued serving certificate as well
+					args = append(args, "--root-ca-file="+filepath.Join(filepath.Dir(vConfig.VirtualClusterKubeConfig().ServerCACert), certs.RootCACertName))
+				} else {
+					args = append(args, "--root-ca-file="+vConfig.VirtualClusterKubeConfig().ServerCACert)
+				}
 				args = append(args, "--service-account-private-key-file=/data/pki/sa.key")
 				args = append(args, "--use-service-account-credentials=true")
 				if vConfig.ControlPlane.StatefulSet.HighAvailability.Replicas > 1 {
@@ -172,12 +178,21 @@ func StartK8S(
 				} else {
 					args = append(args, "--leader-elect=false")
 				}
+				disabledControllers := ""
+				if vConfig.Sync.ToHost.Jobs.Enabled {
+					// jobs are run by the host cluster job controller
+					disabledControllers += ",-job"
+				}
+				if vConfig.Sync.ToHost.PodDisruptionBudgets.Enabled {
+					// pod disruption budget status is synced back from the host cluster
+					disabledControllers += ",-disruption"
+				}
 				if vConfig.ControlPlane.Advanced.VirtualScheduler.Enabled {
-					args = append(args, "--controllers=*,-nodeipam,-persistentvolume-binder,-attachdetach,-persistentvolume-expander,-cloud-node-lifecycle,-ttl")
+					args = append(args, "--controllers=*,-nodeipam,-persistentvolume-binder,-attachdetach,-persistentvolume-expander,-cloud-node-lifecycle,-ttl"+disabledControllers)
 					args = append(args, "--node-monitor-grace-period=1h")
 					args = append(args, "--node-monitor-period=1h")
 				} else {
-					args = append(args, "--controllers=*,-nodeipam,-nodelifecycle,-persistentvolume-binder,-attachdetach,-persistentvolume-expander,-cloud-node-lifecycle,-ttl")
+					args = append(args, "--controllers=*,-nodeipam,-nodelifecycle,-persistentvolume-binder,-attachdetach,-persistentvolume-expander,-cloud-node-lifecycle,-ttl"+disabledControllers)
 				}
 			}
 
@@ -223,6 +238,26 @@ func StartK8S(
 	return err
 }
 
+// StartKine starts kine in the background, which serves the given data source via the etcd api at the listen address
+func StartKine(ctx context.Context, dataSource, listenAddress string, database vclusterconfig.DatabaseKine) {
+	go func() {
+		args := []string{}
+		args = append(args, "/usr/local/bin/kine")
+		args = append(args, "--endpoint="+dataSource)
+		args = append(args, "--ca-file="+database.CaFile)
+		args = append(args, "--key-file="+database.KeyFile)
+		args = append(args, "--cert-file="+database.CertFile)
+		args = append(args, "--metrics-bind-address=0")
+		args = append(args, "--listen-address="+listenAddress)
+
+		// now start kine
+		err := RunCommand(ctx, args, "kine")
+		if err != nil {
+			klog.Fatal("could not run kine", err)
+		}
+	}()
+}
+
 func RunCommand(ctx context.Context, command []string, component string) error {
 	writer, err := commandwriter.NewCommandWriter(component, false)
 	if err != nil {
@@ -14,6 +14,7 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -56,6 +57,53 @@ func PauseVCluster(ctx context.Context, kubeClient *kubernetes.Clientset, name,
 	return nil
 }
 
+// StopControlPlane scales down the statefulset or deployment of the vcluster the same way as PauseVCluster, but keeps a
+// deployed etcd running, so that the backing store can still be accessed
+func StopControlPlane(ctx context.Context, kubeClient kubernetes.Interface, name, namespace string, log log.BaseLogger) error {
+	labelSelector := "app=vcluster,release=" + name
+	found, err := scaleDownStatefulSet(ctx, kubeClient, labelSelector, namespace, log)
+	if err != nil {
+		return err
+	} else if found {
+		return nil
+	}
+
+	found, err = scaleDownDeployment(ctx, kubeClient, labelSelector, namespace, log)
+	if err != nil {
+		return err
+	} else if !found {
+		return errors.Errorf("couldn't find vcluster %s in namespace %s", name, namespace)
+	}
+
+	return nil
+}
+
+// RestartedAtAnnotation is set on the pod template of the vcluster to trigger a rolling restart
+const RestartedAtAnnotation = "vcluster.loft.sh/restarted-at"
+
+// RolloutRestart restarts the pods of the vcluster one by one by changing the pod template of its statefulset or
+// deployment, so that highly available control planes and embedded etcd keep their quorum
+func RolloutRestart(ctx context.Context, kubeClient kubernetes.Interface, name, namespace string) error {
+	patch := []byte(fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{%q:%q}}}}}`, RestartedAtAnnotation, time.Now().UTC().Format(time.RFC3339)))
+	_, err := kubeClient.AppsV1().StatefulSets(namespace).Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	if err == nil {
+		return nil
+	} else if !kerrors.IsNotFound(err) {
+		return fmt.Errorf("restart statefulset %s/%s: %w", namespace, name, err)
+	}
+
+	_, err = kubeClient.AppsV1().Deployments(namespace).Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return fmt.Errorf("couldn't find vcluster %s in namespace %s", name, namespace)
+		}
+
+		return fmt.Errorf("restart deployment %s/%s: %w", namespace, name, err)
+	}
+
+	return nil
+}
+
 // DeletePods deletes all pods associated with a running vcluster
 func DeletePods(ctx context.Context, kubeClient *kubernetes.Clientset, labelSelector, namespace string, log log.BaseLogger) error {
 	list, err := kubeClient.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
@@ -69,14 +69,62 @@ func (n *mapper) GroupVersionKind() schema.GroupVersionKind {
 	return n.gvk
 }
 
-func (n *mapper) VirtualToHost(_ *synccontext.SyncContext, req types.NamespacedName, vObj client.Object) types.NamespacedName {
-	return types.NamespacedName{
+func (n *mapper) VirtualToHost(ctx *synccontext.SyncContext, req types.NamespacedName, vObj client.Object) types.NamespacedName {
+	pName := types.NamespacedName{
 		Namespace: translate.Default.HostNamespace(req.Namespace),
 		Name:      n.translateName(req.Name, req.Namespace, vObj),
 	}
+	if ctx == nil || ctx.PhysicalClient == nil || req.Namespace == "" {
+		return pName
+	}
+
+	if vObj == nil {
+		// look up the virtual object, as its host object might have been created with a different naming strategy
+		obj := n.obj.DeepCopyObject().(client.Object)
+		err := n.virtualClient.Get(ctx, req, obj)
+		if err != nil {
+			return pName
+		}
+
+		vObj = obj
+	}
+
+	recorded, ok := n.recordedHostName(ctx, vObj)
+	if ok {
+		return recorded
+	}
+
+	return pName
+}
+
+// recordedHostName returns the host name recorded in the sync status of the virtual object, if the host object
+// with that name was synced from the virtual object. The sync status is writable within the virtual cluster,
+// so without this check the virtual object could be mapped to any host object in the host namespace.
+func (n *mapper) recordedHostName(ctx *synccontext.SyncContext, vObj client.Object) (types.NamespacedName, bool) {
+	recorded, ok := translate.RecordedHostName(vObj)
+	if !ok || recorded.Namespace != translate.Default.HostNamespace(vObj.GetNamespace()) {
+		return types.NamespacedName{}, false
+	}
+
+	pObj := n.obj.DeepCopyObject().(client.Object)
+	err := ctx.PhysicalClient.Get(ctx, recorded, pObj)
+	if err != nil || !translate.IsHostObjectOf(pObj, vObj) {
+		return types.NamespacedName{}, false
+	}
+
+	return recorded, true
 }
 
 func (n *mapper) HostToVirtual(ctx *synccontext.SyncContext, req types.NamespacedName, pObj client.Object) types.NamespacedName {
+	if pObj == nil && ctx != nil && ctx.PhysicalClient != nil && req.Namespace != "" {
+		// look up the host object, as it might have been created with a different naming strategy
+		obj := n.obj.DeepCopyObject().(client.Object)
+		err := ctx.PhysicalClient.Get(ctx, req, obj)
+		if err == nil && obj.GetLabels()[translate.MarkerLabel] == translate.VClusterName {
+			pObj = obj
+		}
+	}
+
 	if pObj != nil {
 		pAnnotations := pObj.GetAnnotations()
 		if pAnnotations != nil && pAnnotations[translate.NameAnnotation] != "" {
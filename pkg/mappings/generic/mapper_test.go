@@ -0,0 +1,88 @@
+package generic_test
+
+import (
+	"testing"
+
+	"github.com/loft-sh/vcluster/pkg/mappings"
+	"github.com/loft-sh/vcluster/pkg/scheme"
+	syncertesting "github.com/loft-sh/vcluster/pkg/syncer/testing"
+	testingutil "github.com/loft-sh/vcluster/pkg/util/testing"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestRecordedHostName(t *testing.T) {
+	syncStatus := func(hostName string) map[string]string {
+		return map[string]string{translate.SyncStatusAnnotation: `{"phase":"Synced","hostName":"` + hostName + `","uid":"123"}`}
+	}
+
+	// a host object that was synced from default/renamed with an older naming strategy
+	synced := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Name:        "old-name",
+		Namespace:   syncertesting.DefaultTestTargetNamespace,
+		Labels:      map[string]string{translate.MarkerLabel: translate.VClusterName},
+		Annotations: map[string]string{translate.NameAnnotation: "renamed", translate.NamespaceAnnotation: "default"},
+	}}
+	// a host object of the vCluster itself, e.g. its certificates
+	certs := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Name:      "certs",
+		Namespace: syncertesting.DefaultTestTargetNamespace,
+	}}
+	pClient := testingutil.NewFakeClient(scheme.Scheme, synced, certs)
+	vClient := testingutil.NewFakeClient(scheme.Scheme)
+	registerCtx := syncertesting.NewFakeRegisterContext(syncertesting.NewFakeConfig(), pClient, vClient)
+	syncCtx := registerCtx.ToSyncContext("test")
+
+	mapper, err := registerCtx.Mappings.ByGVK(mappings.Secrets())
+	assert.NilError(t, err)
+
+	testCases := []struct {
+		name     string
+		vObj     *corev1.Secret
+		expected types.NamespacedName
+	}{
+		{
+			name: "recorded",
+			vObj: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+				Name:        "renamed",
+				Namespace:   "default",
+				UID:         "123",
+				Annotations: syncStatus(syncertesting.DefaultTestTargetNamespace + "/old-name"),
+			}},
+			expected: types.NamespacedName{Namespace: syncertesting.DefaultTestTargetNamespace, Name: "old-name"},
+		},
+		{
+			name: "forged vCluster object",
+			vObj: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+				Name:        "forged",
+				Namespace:   "default",
+				UID:         "123",
+				Annotations: syncStatus(syncertesting.DefaultTestTargetNamespace + "/certs"),
+			}},
+			expected: types.NamespacedName{Namespace: syncertesting.DefaultTestTargetNamespace, Name: translate.Default.HostName("forged", "default")},
+		},
+		{
+			name: "forged other object",
+			vObj: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+				Name:        "forged",
+				Namespace:   "other",
+				UID:         "123",
+				Annotations: syncStatus(syncertesting.DefaultTestTargetNamespace + "/old-name"),
+			}},
+			expected: types.NamespacedName{Namespace: syncertesting.DefaultTestTargetNamespace, Name: translate.Default.HostName("forged", "other")},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			pName := mapper.VirtualToHost(syncCtx, types.NamespacedName{Namespace: testCase.vObj.Namespace, Name: testCase.vObj.Name}, testCase.vObj)
+			assert.Equal(t, pName, testCase.expected)
+		})
+	}
+
+	// host objects map back through their own annotations
+	vName := mapper.HostToVirtual(syncCtx, types.NamespacedName{Namespace: syncertesting.DefaultTestTargetNamespace, Name: "old-name"}, nil)
+	assert.Equal(t, vName, types.NamespacedName{Namespace: "default", Name: "renamed"})
+}
@@ -6,6 +6,7 @@ import (
 
 	volumesnapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
 	"github.com/loft-sh/vcluster/pkg/syncer/synccontext"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	policyv1 "k8s.io/api/policy/v1"
@@ -14,6 +15,9 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
 func NewMappingsRegistry() synccontext.MappingsRegistry {
@@ -68,6 +72,10 @@ func CSIStorageCapacities() schema.GroupVersionKind {
 	return storagev1.SchemeGroupVersion.WithKind("CSIStorageCapacity")
 }
 
+func VolumeAttachments() schema.GroupVersionKind {
+	return storagev1.SchemeGroupVersion.WithKind("VolumeAttachment")
+}
+
 func VolumeSnapshotContents() schema.GroupVersionKind {
 	return volumesnapshotv1.SchemeGroupVersion.WithKind("VolumeSnapshotContent")
 }
@@ -84,6 +92,10 @@ func PodDisruptionBudgets() schema.GroupVersionKind {
 	return policyv1.SchemeGroupVersion.WithKind("PodDisruptionBudget")
 }
 
+func Jobs() schema.GroupVersionKind {
+	return batchv1.SchemeGroupVersion.WithKind("Job")
+}
+
 func VolumeSnapshots() schema.GroupVersionKind {
 	return volumesnapshotv1.SchemeGroupVersion.WithKind("VolumeSnapshot")
 }
@@ -148,6 +160,30 @@ func PriorityClasses() schema.GroupVersionKind {
 	return schedulingv1.SchemeGroupVersion.WithKind("PriorityClass")
 }
 
+func GatewayClasses() schema.GroupVersionKind {
+	return gatewayv1.SchemeGroupVersion.WithKind("GatewayClass")
+}
+
+func Gateways() schema.GroupVersionKind {
+	return gatewayv1.SchemeGroupVersion.WithKind("Gateway")
+}
+
+func HTTPRoutes() schema.GroupVersionKind {
+	return gatewayv1.SchemeGroupVersion.WithKind("HTTPRoute")
+}
+
+func GRPCRoutes() schema.GroupVersionKind {
+	return gatewayv1.SchemeGroupVersion.WithKind("GRPCRoute")
+}
+
+func TLSRoutes() schema.GroupVersionKind {
+	return gatewayv1alpha2.SchemeGroupVersion.WithKind("TLSRoute")
+}
+
+func ReferenceGrants() schema.GroupVersionKind {
+	return gatewayv1beta1.SchemeGroupVersion.WithKind("ReferenceGrant")
+}
+
 func VirtualToHostName(ctx *synccontext.SyncContext, vName, vNamespace string, gvk schema.GroupVersionKind) string {
 	return VirtualToHost(ctx, vName, vNamespace, gvk).Name
 }
@@ -0,0 +1,57 @@
+package resources
+
+import (
+	"slices"
+
+	"github.com/loft-sh/vcluster/pkg/syncer/synccontext"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func CreateGatewaysMapper(ctx *synccontext.RegisterContext) (synccontext.Mapper, error) {
+	if ctx.Config.Sync.FromHost.Gateways.Enabled {
+		_, _, err := translate.EnsureCRDFromPhysicalCluster(ctx.Context, ctx.PhysicalManager.GetConfig(), ctx.VirtualManager.GetConfig(), gatewayv1.SchemeGroupVersion.WithKind("Gateway"))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &gatewayMapper{
+		namespaces: ctx.Config.Sync.FromHost.Gateways.Namespaces,
+		selector:   labels.SelectorFromSet(ctx.Config.Sync.FromHost.Gateways.Selector),
+	}, nil
+}
+
+// gatewayMapper maps gateways one to one between host and virtual cluster. Gateways are shared
+// host objects that are only synced from the host into the virtual cluster, so a route within
+// the virtual cluster that references a gateway always references the host gateway with the
+// same namespace and name. Only gateways within the configured host namespaces that match the
+// configured selector are managed.
+type gatewayMapper struct {
+	namespaces []string
+	selector   labels.Selector
+}
+
+func (s *gatewayMapper) GroupVersionKind() schema.GroupVersionKind {
+	return gatewayv1.SchemeGroupVersion.WithKind("Gateway")
+}
+
+func (s *gatewayMapper) VirtualToHost(_ *synccontext.SyncContext, req types.NamespacedName, _ client.Object) types.NamespacedName {
+	return req
+}
+
+func (s *gatewayMapper) HostToVirtual(_ *synccontext.SyncContext, req types.NamespacedName, _ client.Object) types.NamespacedName {
+	return req
+}
+
+func (s *gatewayMapper) IsManaged(_ *synccontext.SyncContext, pObj client.Object) (bool, error) {
+	if !slices.Contains(s.namespaces, pObj.GetNamespace()) {
+		return false, nil
+	}
+
+	return s.selector.Matches(labels.Set(pObj.GetLabels())), nil
+}
@@ -0,0 +1,17 @@
+package resources
+
+import (
+	"github.com/loft-sh/vcluster/pkg/mappings/generic"
+	"github.com/loft-sh/vcluster/pkg/syncer/synccontext"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func CreateGRPCRoutesMapper(ctx *synccontext.RegisterContext) (synccontext.Mapper, error) {
+	_, _, err := translate.EnsureCRDFromPhysicalCluster(ctx.Context, ctx.PhysicalManager.GetConfig(), ctx.VirtualManager.GetConfig(), gatewayv1.SchemeGroupVersion.WithKind("GRPCRoute"))
+	if err != nil {
+		return nil, err
+	}
+
+	return generic.NewMapper(ctx, &gatewayv1.GRPCRoute{}, translate.Default.HostName)
+}
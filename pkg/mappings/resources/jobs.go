@@ -0,0 +1,12 @@
+package resources
+
+import (
+	"github.com/loft-sh/vcluster/pkg/mappings/generic"
+	"github.com/loft-sh/vcluster/pkg/syncer/synccontext"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	batchv1 "k8s.io/api/batch/v1"
+)
+
+func CreateJobsMapper(ctx *synccontext.RegisterContext) (synccontext.Mapper, error) {
+	return generic.NewMapper(ctx, &batchv1.Job{}, translate.Default.HostName)
+}
@@ -19,6 +19,7 @@ func getMappers(ctx *synccontext.RegisterContext) []BuildMapper {
 		isEnabled(ctx.Config.Sync.FromHost.CSINodes.Enabled == "true", CreateCSINodesMapper),
 		isEnabled(ctx.Config.Sync.FromHost.CSIDrivers.Enabled == "true", CreateCSIDriversMapper),
 		isEnabled(ctx.Config.Sync.FromHost.CSIStorageCapacities.Enabled == "true", CreateCSIStorageCapacitiesMapper),
+		isEnabled(ctx.Config.Sync.FromHost.VolumeAttachments.Enabled, CreateVolumeAttachmentsMapper),
 		CreateEndpointsMapper,
 		CreateEventsMapper,
 		CreateIngressClassesMapper,
@@ -37,6 +38,13 @@ func getMappers(ctx *synccontext.RegisterContext) []BuildMapper {
 		CreateVolumeSnapshotClassesMapper,
 		CreateVolumeSnapshotContentsMapper,
 		CreateVolumeSnapshotsMapper,
+		CreateGatewaysMapper,
+		isEnabled(ctx.Config.Sync.FromHost.Gateways.Enabled, CreateGatewayClassesMapper),
+		isEnabled(ctx.Config.Sync.ToHost.Gateway.Enabled, CreateHTTPRoutesMapper),
+		isEnabled(ctx.Config.Sync.ToHost.Gateway.Enabled, CreateGRPCRoutesMapper),
+		isEnabled(ctx.Config.Sync.ToHost.Gateway.Enabled && ctx.Config.Sync.ToHost.Gateway.TLSRoutes.Enabled, CreateTLSRoutesMapper),
+		isEnabled(ctx.Config.Sync.ToHost.Gateway.Enabled, CreateReferenceGrantsMapper),
+		isEnabled(ctx.Config.Sync.ToHost.Jobs.Enabled, CreateJobsMapper),
 	}, ExtraMappers...)
 }
 
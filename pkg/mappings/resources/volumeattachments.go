@@ -0,0 +1,41 @@
+package resources
+
+import (
+	"github.com/loft-sh/vcluster/pkg/mappings"
+	"github.com/loft-sh/vcluster/pkg/syncer/synccontext"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func CreateVolumeAttachmentsMapper(_ *synccontext.RegisterContext) (synccontext.Mapper, error) {
+	return &volumeAttachmentsMapper{}, nil
+}
+
+// volumeAttachmentsMapper keeps the name of volume attachments, but only manages host volume
+// attachments of persistent volumes that are also synced into the virtual cluster.
+type volumeAttachmentsMapper struct{}
+
+func (s *volumeAttachmentsMapper) GroupVersionKind() schema.GroupVersionKind {
+	return mappings.VolumeAttachments()
+}
+
+func (s *volumeAttachmentsMapper) VirtualToHost(_ *synccontext.SyncContext, req types.NamespacedName, _ client.Object) types.NamespacedName {
+	return req
+}
+
+func (s *volumeAttachmentsMapper) HostToVirtual(_ *synccontext.SyncContext, req types.NamespacedName, _ client.Object) types.NamespacedName {
+	return req
+}
+
+func (s *volumeAttachmentsMapper) IsManaged(ctx *synccontext.SyncContext, pObj client.Object) (bool, error) {
+	pVolumeAttachment, ok := pObj.(*storagev1.VolumeAttachment)
+	if !ok || pVolumeAttachment.Spec.Source.PersistentVolumeName == nil {
+		return false, nil
+	}
+
+	// the persistent volume is only found if it exists within the virtual cluster
+	vName := mappings.HostToVirtual(ctx, *pVolumeAttachment.Spec.Source.PersistentVolumeName, "", nil, mappings.PersistentVolumes())
+	return vName.Name != "", nil
+}
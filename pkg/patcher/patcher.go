@@ -4,9 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"reflect"
 
 	"github.com/loft-sh/vcluster/pkg/syncer/synccontext"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -65,6 +67,7 @@ type SyncerPatcher struct {
 
 // Patch will attempt to patch the given object, including its status.
 func (h *SyncerPatcher) Patch(ctx *synccontext.SyncContext, pObj, vObj client.Object) error {
+	keepSyncStatus(h.vPatcher.beforeObject, vObj)
 	err := h.vPatcher.Patch(ctx, vObj)
 	if err != nil {
 		return fmt.Errorf("patch virtual object: %w", err)
@@ -78,6 +81,25 @@ func (h *SyncerPatcher) Patch(ctx *synccontext.SyncContext, pObj, vObj client.Ob
 	return nil
 }
 
+// keepSyncStatus restores the sync status annotation, which is maintained by the syncer controller, if a syncer
+// replaced the annotations of the virtual object, e.g. with the ones of the host object
+func keepSyncStatus(beforeVObj, vObj client.Object) {
+	syncStatus, ok := beforeVObj.GetAnnotations()[translate.SyncStatusAnnotation]
+	if !ok {
+		return
+	} else if _, ok := vObj.GetAnnotations()[translate.SyncStatusAnnotation]; ok {
+		return
+	}
+
+	// the annotations might be shared with the host object, so we need to copy them
+	annotations := maps.Clone(vObj.GetAnnotations())
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[translate.SyncStatusAnnotation] = syncStatus
+	vObj.SetAnnotations(annotations)
+}
+
 // Patcher is a utility for ensuring the proper patching of objects.
 type Patcher struct {
 	client       client.Client
@@ -0,0 +1,40 @@
+package patcher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/loft-sh/vcluster/pkg/scheme"
+	"github.com/loft-sh/vcluster/pkg/syncer/synccontext"
+	testingutil "github.com/loft-sh/vcluster/pkg/util/testing"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	"gotest.tools/v3/assert"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestSyncerPatcherKeepsSyncStatus(t *testing.T) {
+	pObj := &networkingv1.IngressClass{ObjectMeta: metav1.ObjectMeta{Name: "nginx", Annotations: map[string]string{"host": "true"}}}
+	vObj := &networkingv1.IngressClass{ObjectMeta: metav1.ObjectMeta{Name: "nginx", Annotations: map[string]string{translate.SyncStatusAnnotation: `{"phase":"Synced"}`}}}
+	syncContext := &synccontext.SyncContext{
+		Context:        context.Background(),
+		PhysicalClient: testingutil.NewFakeClient(scheme.Scheme, pObj.DeepCopy()),
+		VirtualClient:  testingutil.NewFakeClient(scheme.Scheme, vObj.DeepCopy()),
+	}
+
+	patch, err := NewSyncerPatcher(syncContext, pObj, vObj)
+	assert.NilError(t, err)
+
+	// copy the annotations from the host object like the from host syncers do
+	vObj.Annotations = pObj.Annotations
+	assert.NilError(t, patch.Patch(syncContext, pObj, vObj))
+
+	vCurrent := &networkingv1.IngressClass{}
+	assert.NilError(t, syncContext.VirtualClient.Get(syncContext, types.NamespacedName{Name: "nginx"}, vCurrent))
+	assert.DeepEqual(t, vCurrent.Annotations, map[string]string{"host": "true", translate.SyncStatusAnnotation: `{"phase":"Synced"}`})
+
+	pCurrent := &networkingv1.IngressClass{}
+	assert.NilError(t, syncContext.PhysicalClient.Get(syncContext, types.NamespacedName{Name: "nginx"}, pCurrent))
+	assert.DeepEqual(t, pCurrent.Annotations, map[string]string{"host": "true"})
+}
@@ -2,6 +2,7 @@ package patches
 
 import (
 	"github.com/loft-sh/vcluster/config"
+	patchesexpression "github.com/loft-sh/vcluster/pkg/patches/expression"
 	"github.com/pkg/errors"
 	"github.com/vmware-labs/yaml-jsonpath/pkg/yamlpath"
 	yaml "gopkg.in/yaml.v3"
@@ -23,6 +24,8 @@ func ValidateAllConditions(obj *yaml.Node, match *yaml.Node, conditions []*confi
 func ValidateCondition(obj *yaml.Node, match *yaml.Node, condition *config.PatchCondition) (bool, error) {
 	if condition == nil {
 		return true, nil
+	} else if condition.Expression != "" {
+		return patchesexpression.EvaluateBool(condition.Expression, obj, match)
 	}
 
 	var matches []*yaml.Node
@@ -0,0 +1,142 @@
+package expression
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/ext"
+	"google.golang.org/protobuf/types/known/structpb"
+	yaml "gopkg.in/yaml.v3"
+)
+
+const (
+	// ObjectVariable is the name of the variable that holds the patched object within an expression
+	ObjectVariable = "object"
+
+	// ValueVariable is the name of the variable that holds the current value at the patch path within an expression
+	ValueVariable = "value"
+
+	// expressionCostLimit limits the runtime cost of a single expression evaluation
+	expressionCostLimit = 1000000
+)
+
+var (
+	expressionEnvOnce sync.Once
+	expressionEnv     *cel.Env
+	expressionEnvErr  error
+
+	// programs caches the compiled programs by expression
+	programs sync.Map
+)
+
+func getExpressionEnv() (*cel.Env, error) {
+	expressionEnvOnce.Do(func() {
+		expressionEnv, expressionEnvErr = cel.NewEnv(
+			cel.Variable(ObjectVariable, cel.DynType),
+			cel.Variable(ValueVariable, cel.DynType),
+			ext.Strings(),
+		)
+	})
+
+	return expressionEnv, expressionEnvErr
+}
+
+// Compile compiles the given CEL expression and returns an error if it is invalid
+func Compile(expression string) (cel.Program, error) {
+	if program, ok := programs.Load(expression); ok {
+		return program.(cel.Program), nil
+	}
+
+	env, err := getExpressionEnv()
+	if err != nil {
+		return nil, fmt.Errorf("create cel environment: %w", err)
+	}
+
+	compiled, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("compile expression %q: %w", expression, issues.Err())
+	}
+
+	program, err := env.Program(compiled, cel.CostLimit(expressionCostLimit))
+	if err != nil {
+		return nil, fmt.Errorf("create program for expression %q: %w", expression, err)
+	}
+
+	programs.Store(expression, program)
+	return program, nil
+}
+
+// Evaluate evaluates the CEL expression with the given object and the current value at the patch path
+// and returns the result as plain json compatible value
+func Evaluate(expression string, obj, value *yaml.Node) (interface{}, error) {
+	out, err := evaluate(expression, obj, value)
+	if err != nil {
+		return nil, err
+	}
+
+	native, err := out.ConvertToNative(reflect.TypeOf(&structpb.Value{}))
+	if err != nil {
+		return nil, fmt.Errorf("convert result of expression %q: %w", expression, err)
+	}
+
+	return native.(*structpb.Value).AsInterface(), nil
+}
+
+// EvaluateBool evaluates the CEL expression and returns an error if it doesn't evaluate to a boolean
+func EvaluateBool(expression string, obj, value *yaml.Node) (bool, error) {
+	out, err := evaluate(expression, obj, value)
+	if err != nil {
+		return false, err
+	}
+
+	result, ok := out.(types.Bool)
+	if !ok {
+		return false, fmt.Errorf("expression %q must evaluate to a boolean, but got %s", expression, out.Type().TypeName())
+	}
+
+	return bool(result), nil
+}
+
+func evaluate(expression string, obj, value *yaml.Node) (ref.Val, error) {
+	program, err := Compile(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	objValue, err := nodeToInterface(obj)
+	if err != nil {
+		return nil, fmt.Errorf("decode object: %w", err)
+	}
+	valueValue, err := nodeToInterface(value)
+	if err != nil {
+		return nil, fmt.Errorf("decode value: %w", err)
+	}
+
+	out, _, err := program.Eval(map[string]interface{}{
+		ObjectVariable: objValue,
+		ValueVariable:  valueValue,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("evaluate expression %q: %w", expression, err)
+	}
+
+	return out, nil
+}
+
+func nodeToInterface(node *yaml.Node) (interface{}, error) {
+	if node == nil {
+		return nil, nil
+	}
+
+	var out interface{}
+	err := node.Decode(&out)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
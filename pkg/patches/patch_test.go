@@ -396,6 +396,110 @@ test2: {}`,
 		//     - vcluster.loft.sh/label-suffix-x-cb4e76426f
 		//     - vcluster.loft.sh/label-suffix-x-bae4a2c2e5`,
 		// 	},
+		{
+			name: "replace with expression",
+			patch: &config.Patch{
+				Operation:  config.PatchTypeReplace,
+				Path:       "metadata.labels.name",
+				Expression: "'prefix-' + object.metadata.name",
+			},
+			obj1: `metadata:
+    name: test
+    labels:
+        name: abc`,
+			expected: `metadata:
+    name: test
+    labels:
+        name: prefix-test`,
+		},
+		{
+			name: "replace with expression using value",
+			patch: &config.Patch{
+				Operation:  config.PatchTypeReplace,
+				Path:       "spec.replicas",
+				Expression: "value * 2",
+			},
+			obj1: `spec:
+    replicas: 3`,
+			expected: `spec:
+    replicas: 6`,
+		},
+		{
+			name: "add with expression",
+			patch: &config.Patch{
+				Operation:  config.PatchTypeAdd,
+				Path:       "spec.tags",
+				Expression: "[object.metadata.name, object.metadata.name.upperAscii()]",
+			},
+			obj1: `metadata:
+    name: test`,
+			expected: `metadata:
+    name: test
+spec:
+    tags:
+        - test
+        - TEST`,
+		},
+		{
+			name: "condition expression",
+			patch: &config.Patch{
+				Operation: config.PatchTypeReplace,
+				Path:      "spec.replicas",
+				Value:     3,
+				Conditions: []*config.PatchCondition{
+					{
+						Expression: "object.spec.replicas > 3",
+					},
+				},
+			},
+			obj1: `spec:
+    replicas: 5`,
+			expected: `spec:
+    replicas: 3`,
+		},
+		{
+			name: "condition expression not matching",
+			patch: &config.Patch{
+				Operation: config.PatchTypeReplace,
+				Path:      "spec.replicas",
+				Value:     3,
+				Conditions: []*config.PatchCondition{
+					{
+						Expression: "value > 3",
+					},
+				},
+			},
+			obj1: `spec:
+    replicas: 2`,
+			expected: `spec:
+    replicas: 2`,
+		},
+		{
+			name: "condition expression not boolean",
+			patch: &config.Patch{
+				Operation: config.PatchTypeRemove,
+				Path:      "spec.replicas",
+				Conditions: []*config.PatchCondition{
+					{
+						Expression: "object.spec.replicas",
+					},
+				},
+			},
+			obj1: `spec:
+    replicas: 2`,
+			expectedErr: errors.New(`expression "object.spec.replicas" must evaluate to a boolean, but got int`),
+		},
+		{
+			name: "invalid expression",
+			patch: &config.Patch{
+				Operation:  config.PatchTypeReplace,
+				Path:       "spec.replicas",
+				Expression: "object.spec.replicas +",
+			},
+			obj1: `spec:
+    replicas: 2`,
+			expectedErr: errors.New(`compile expression "object.spec.replicas +"`),
+		},
 		{
 			name: "rewrite name should not panic when match is not scalar",
 			patch: &config.Patch{
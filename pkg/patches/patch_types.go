@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/loft-sh/vcluster/config"
+	patchesexpression "github.com/loft-sh/vcluster/pkg/patches/expression"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/pkg/errors"
@@ -106,11 +107,6 @@ func Add(obj1 *yaml.Node, patch *config.Patch) error {
 		return errors.Wrap(err, "find matches")
 	}
 
-	value, err := NewNode(patch.Value)
-	if err != nil {
-		return errors.Wrap(err, "new node from value")
-	}
-
 	if len(matches) == 0 {
 		validated, err := ValidateAllConditions(obj1, nil, patch.Conditions)
 		if err != nil {
@@ -119,6 +115,11 @@ func Add(obj1 *yaml.Node, patch *config.Patch) error {
 			return nil
 		}
 
+		value, err := patchValue(obj1, nil, patch)
+		if err != nil {
+			return err
+		}
+
 		err = createPath(obj1, patch.Path, value)
 		if err != nil {
 			return err
@@ -132,6 +133,11 @@ func Add(obj1 *yaml.Node, patch *config.Patch) error {
 				continue
 			}
 
+			value, err := patchValue(obj1, m, patch)
+			if err != nil {
+				return err
+			}
+
 			AddNode(obj1, m, value)
 		}
 	}
@@ -145,11 +151,6 @@ func Replace(obj1 *yaml.Node, patch *config.Patch) error {
 		return errors.Wrap(err, "find matches")
 	}
 
-	value, err := NewNode(patch.Value)
-	if err != nil {
-		return errors.Wrap(err, "new node from value")
-	}
-
 	for _, m := range matches {
 		validated, err := ValidateAllConditions(obj1, m, patch.Conditions)
 		if err != nil {
@@ -158,12 +159,37 @@ func Replace(obj1 *yaml.Node, patch *config.Patch) error {
 			continue
 		}
 
+		value, err := patchValue(obj1, m, patch)
+		if err != nil {
+			return err
+		}
+
 		ReplaceNode(obj1, m, value)
 	}
 
 	return nil
 }
 
+// patchValue returns the value of the patch, which is either computed from the expression
+// or taken as is from the value field
+func patchValue(obj1, match *yaml.Node, patch *config.Patch) (*yaml.Node, error) {
+	rawValue := patch.Value
+	if patch.Expression != "" {
+		var err error
+		rawValue, err = patchesexpression.Evaluate(patch.Expression, obj1, match)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	value, err := NewNode(rawValue)
+	if err != nil {
+		return nil, errors.Wrap(err, "new node from value")
+	}
+
+	return value, nil
+}
+
 func RewriteName(obj1 *yaml.Node, patch *config.Patch, resolver NameResolver) error {
 	matches, err := FindMatches(obj1, patch.Path)
 	if err != nil {
@@ -14,6 +14,9 @@ import (
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	apiregistrationv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
 	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
 var Scheme = runtime.NewScheme()
@@ -35,6 +38,11 @@ func init() {
 	// Register VolumeSnapshot CRDs
 	_ = volumesnapshotv1.AddToScheme(Scheme)
 
+	// Register Gateway API CRDs
+	_ = gatewayv1.AddToScheme(Scheme)
+	_ = gatewayv1beta1.AddToScheme(Scheme)
+	_ = gatewayv1alpha2.AddToScheme(Scheme)
+
 	// Register Loft CRDs
 	_ = agentstoragev1.AddToScheme(Scheme)
 	_ = agentclusterv1.AddToScheme(Scheme)
@@ -0,0 +1,265 @@
+package secretbackend
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	kmsservice "k8s.io/kms/pkg/service"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// EncryptedDataKeyAnnotation holds the data key that encrypted the secret values, encrypted by the kms plugin
+	EncryptedDataKeyAnnotation = "vcluster.loft.sh/encrypted-data-key"
+
+	// KeyIDAnnotation holds the id of the kms key that encrypted the data key
+	KeyIDAnnotation = "vcluster.loft.sh/kms-key-id"
+
+	// KMSAnnotationsAnnotation holds the annotations the kms plugin returned when encrypting the data key
+	KMSAnnotationsAnnotation = "vcluster.loft.sh/kms-annotations"
+
+	// DataHashAnnotation holds a keyed hash of the plaintext data to detect changes without decrypting the secret
+	DataHashAnnotation = "vcluster.loft.sh/data-hash"
+
+	dataKeySecretKey         = "key"
+	dataKeyAnnotationsSecret = "annotations"
+	dataKeySize              = 32
+)
+
+// EncryptionAnnotations are the annotations vCluster manages on encrypted host secrets
+var EncryptionAnnotations = []string{EncryptedDataKeyAnnotation, KeyIDAnnotation, KMSAnnotationsAnnotation, DataHashAnnotation}
+
+// Envelope encrypts secret data with the data key of the virtual cluster
+type Envelope struct {
+	aead             cipher.AEAD
+	dataKey          []byte
+	encryptedDataKey []byte
+	keyID            string
+	kmsAnnotations   map[string][]byte
+}
+
+// NewEnvelope returns the envelope of the virtual cluster. The data key is generated once, encrypted by the kms plugin
+// and stored within the given secret, so that host secrets stay readable across restarts of vCluster.
+func NewEnvelope(ctx context.Context, service kmsservice.Service, kubeClient client.Client, namespace, name string) (*Envelope, error) {
+	secret := &corev1.Secret{}
+	err := kubeClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, secret)
+	if err == nil {
+		kmsAnnotations, err := unmarshalKMSAnnotations(string(secret.Data[dataKeyAnnotationsSecret]))
+		if err != nil {
+			return nil, err
+		}
+
+		dataKey, err := service.Decrypt(ctx, string(uuid.NewUUID()), &kmsservice.DecryptRequest{
+			Ciphertext:  secret.Data[dataKeySecretKey],
+			KeyID:       secret.Annotations[KeyIDAnnotation],
+			Annotations: kmsAnnotations,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("decrypt data key: %w", err)
+		}
+
+		return newEnvelope(dataKey, secret.Data[dataKeySecretKey], secret.Annotations[KeyIDAnnotation], kmsAnnotations)
+	} else if !kerrors.IsNotFound(err) {
+		return nil, fmt.Errorf("get data key secret: %w", err)
+	}
+
+	dataKey := make([]byte, dataKeySize)
+	_, err = rand.Read(dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("generate data key: %w", err)
+	}
+
+	response, err := service.Encrypt(ctx, string(uuid.NewUUID()), dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt data key: %w", err)
+	}
+
+	kmsAnnotations, err := json.Marshal(response.Annotations)
+	if err != nil {
+		return nil, fmt.Errorf("marshal kms annotations: %w", err)
+	}
+
+	err = kubeClient.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			Annotations: map[string]string{KeyIDAnnotation: response.KeyID},
+		},
+		Data: map[string][]byte{
+			dataKeySecretKey:         response.Ciphertext,
+			dataKeyAnnotationsSecret: kmsAnnotations,
+		},
+	})
+	if kerrors.IsAlreadyExists(err) {
+		return NewEnvelope(ctx, service, kubeClient, namespace, name)
+	} else if err != nil {
+		return nil, fmt.Errorf("create data key secret: %w", err)
+	}
+
+	return newEnvelope(dataKey, response.Ciphertext, response.KeyID, response.Annotations)
+}
+
+func newEnvelope(dataKey, encryptedDataKey []byte, keyID string, kmsAnnotations map[string][]byte) (*Envelope, error) {
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Envelope{
+		aead:             aead,
+		dataKey:          dataKey,
+		encryptedDataKey: encryptedDataKey,
+		keyID:            keyID,
+		kmsAnnotations:   kmsAnnotations,
+	}, nil
+}
+
+// ShouldEncrypt returns false for secrets the kubelet needs to read itself, such as image pull secrets
+func ShouldEncrypt(secret *corev1.Secret) bool {
+	return secret.Type != corev1.SecretTypeDockerConfigJson && secret.Type != corev1.SecretTypeDockercfg
+}
+
+// Changed returns true if the host secret was not encrypted with the current data key or its plaintext data differs
+func (e *Envelope) Changed(hostSecret *corev1.Secret, data map[string][]byte) bool {
+	annotations := hostSecret.GetAnnotations()
+	return annotations[EncryptedDataKeyAnnotation] != base64.StdEncoding.EncodeToString(e.encryptedDataKey) ||
+		annotations[DataHashAnnotation] != e.hash(data)
+}
+
+// EncryptSecret sets the encrypted data on the host secret and adds the annotations a provider needs to decrypt it
+func (e *Envelope) EncryptSecret(hostSecret *corev1.Secret, data map[string][]byte) error {
+	kmsAnnotations, err := json.Marshal(e.kmsAnnotations)
+	if err != nil {
+		return fmt.Errorf("marshal kms annotations: %w", err)
+	}
+
+	encrypted := make(map[string][]byte, len(data))
+	for key, value := range data {
+		nonce := make([]byte, e.aead.NonceSize())
+		_, err := rand.Read(nonce)
+		if err != nil {
+			return fmt.Errorf("generate nonce: %w", err)
+		}
+
+		// the key is used as additional data, so values cannot be swapped between keys
+		encrypted[key] = e.aead.Seal(nonce, nonce, value, []byte(key))
+	}
+
+	if hostSecret.Annotations == nil {
+		hostSecret.Annotations = map[string]string{}
+	}
+	hostSecret.Annotations[EncryptedDataKeyAnnotation] = base64.StdEncoding.EncodeToString(e.encryptedDataKey)
+	hostSecret.Annotations[KeyIDAnnotation] = e.keyID
+	hostSecret.Annotations[KMSAnnotationsAnnotation] = string(kmsAnnotations)
+	hostSecret.Annotations[DataHashAnnotation] = e.hash(data)
+	hostSecret.Data = encrypted
+	hostSecret.StringData = nil
+	return nil
+}
+
+func (e *Envelope) hash(data map[string][]byte) string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	mac := hmac.New(sha256.New, e.dataKey)
+	for _, key := range keys {
+		_, _ = fmt.Fprintf(mac, "%d:%s%d:", len(key), key, len(data[key]))
+		_, _ = mac.Write(data[key])
+	}
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// DecryptSecret decrypts a host secret encrypted by vCluster through the kms plugin. This is used by csi providers
+// that implement the kms secret backend.
+func DecryptSecret(ctx context.Context, service kmsservice.Service, hostSecret *corev1.Secret) (map[string][]byte, error) {
+	annotations := hostSecret.GetAnnotations()
+	if annotations[EncryptedDataKeyAnnotation] == "" {
+		return nil, fmt.Errorf("secret %s/%s is not encrypted", hostSecret.Namespace, hostSecret.Name)
+	}
+
+	encryptedDataKey, err := base64.StdEncoding.DecodeString(annotations[EncryptedDataKeyAnnotation])
+	if err != nil {
+		return nil, fmt.Errorf("decode data key: %w", err)
+	}
+
+	kmsAnnotations, err := unmarshalKMSAnnotations(annotations[KMSAnnotationsAnnotation])
+	if err != nil {
+		return nil, err
+	}
+
+	dataKey, err := service.Decrypt(ctx, string(uuid.NewUUID()), &kmsservice.DecryptRequest{
+		Ciphertext:  encryptedDataKey,
+		KeyID:       annotations[KeyIDAnnotation],
+		Annotations: kmsAnnotations,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("decrypt data key: %w", err)
+	}
+
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(map[string][]byte, len(hostSecret.Data))
+	for key, value := range hostSecret.Data {
+		if len(value) < aead.NonceSize() {
+			return nil, fmt.Errorf("decrypt key %s: ciphertext too short", key)
+		}
+
+		plaintext, err := aead.Open(nil, value[:aead.NonceSize()], value[aead.NonceSize():], []byte(key))
+		if err != nil {
+			return nil, fmt.Errorf("decrypt key %s: %w", key, err)
+		}
+
+		data[key] = plaintext
+	}
+
+	return data, nil
+}
+
+func newAEAD(dataKey []byte) (cipher.AEAD, error) {
+	if len(dataKey) != dataKeySize {
+		return nil, errors.New("invalid data key size")
+	}
+
+	block, err := aes.NewCipher(dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("create cipher: %w", err)
+	}
+
+	return cipher.NewGCM(block)
+}
+
+func unmarshalKMSAnnotations(raw string) (map[string][]byte, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	kmsAnnotations := map[string][]byte{}
+	err := json.Unmarshal([]byte(raw), &kmsAnnotations)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal kms annotations: %w", err)
+	}
+
+	return kmsAnnotations, nil
+}
@@ -0,0 +1,66 @@
+package secretbackend
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/loft-sh/vcluster/pkg/scheme"
+	testingutil "github.com/loft-sh/vcluster/pkg/util/testing"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	kmsservice "k8s.io/kms/pkg/service"
+)
+
+type fakeKMS struct{}
+
+func (fakeKMS) Encrypt(_ context.Context, _ string, data []byte) (*kmsservice.EncryptResponse, error) {
+	return &kmsservice.EncryptResponse{Ciphertext: append([]byte("kms:"), data...), KeyID: "key-1"}, nil
+}
+
+func (fakeKMS) Decrypt(_ context.Context, _ string, req *kmsservice.DecryptRequest) ([]byte, error) {
+	if req.KeyID != "key-1" || !bytes.HasPrefix(req.Ciphertext, []byte("kms:")) {
+		return nil, errors.New("invalid ciphertext")
+	}
+
+	return bytes.TrimPrefix(req.Ciphertext, []byte("kms:")), nil
+}
+
+func (fakeKMS) Status(context.Context) (*kmsservice.StatusResponse, error) {
+	return &kmsservice.StatusResponse{Version: "v2", Healthz: "ok", KeyID: "key-1"}, nil
+}
+
+func TestEnvelope(t *testing.T) {
+	ctx := context.Background()
+	kubeClient := testingutil.NewFakeClient(scheme.Scheme)
+	envelope, err := NewEnvelope(ctx, fakeKMS{}, kubeClient, "test", "vcluster-secret-backend-key")
+	assert.NilError(t, err)
+
+	// the data key should be persisted and reused
+	reloaded, err := NewEnvelope(ctx, fakeKMS{}, kubeClient, "test", "vcluster-secret-backend-key")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, reloaded.dataKey, envelope.dataKey)
+
+	data := map[string][]byte{
+		"username": []byte("admin"),
+		"password": []byte("secret"),
+	}
+	hostSecret := &corev1.Secret{}
+	assert.Assert(t, envelope.Changed(hostSecret, data))
+	assert.NilError(t, envelope.EncryptSecret(hostSecret, data))
+	assert.Assert(t, !envelope.Changed(hostSecret, data))
+	assert.Assert(t, reloaded.Changed(hostSecret, map[string][]byte{"username": []byte("admin")}))
+	for key, value := range hostSecret.Data {
+		assert.Assert(t, !bytes.Contains(value, data[key]), "plaintext of %s found in host secret", key)
+	}
+
+	decrypted, err := DecryptSecret(ctx, fakeKMS{}, hostSecret)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, decrypted, data)
+
+	// values cannot be moved between keys
+	hostSecret.Data["username"], hostSecret.Data["password"] = hostSecret.Data["password"], hostSecret.Data["username"]
+	_, err = DecryptSecret(ctx, fakeKMS{}, hostSecret)
+	assert.ErrorContains(t, err, "decrypt key")
+}
@@ -0,0 +1,224 @@
+// Package secretbackend defines the contract between vCluster and the csi provider that mounts secrets
+// into host pods if sync.toHost.secrets.backend.type is csi or kms.
+//
+// vCluster replaces every secret volume of a synced pod with a read-only csi volume. The volume attributes
+// describe which secret should be mounted and how. A provider parses them with ParseVolumeAttributes,
+// fetches the secret data and writes the files returned by Volume.Files into the volume:
+//
+//   - With backend csi the secret only exists within the virtual cluster. The provider reads the secret
+//     SecretNamespace/SecretName from the virtual cluster api server at Endpoint.
+//   - With backend kms the secret is synced to HostNamespace/HostSecretName, but every value is encrypted
+//     with a per-vCluster data key, which itself is encrypted by the kms plugin. The provider reads the host
+//     secret and decrypts it through the same kms plugin with DecryptSecret.
+//
+// Providers should verify that HostNamespace matches the namespace of the pod the volume is mounted into.
+package secretbackend
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	BackendVolumeAttribute         = "vcluster.loft.sh/backend"
+	VClusterVolumeAttribute        = "vcluster.loft.sh/vcluster"
+	EndpointVolumeAttribute        = "vcluster.loft.sh/endpoint"
+	HostNamespaceVolumeAttribute   = "vcluster.loft.sh/host-namespace"
+	HostSecretNameVolumeAttribute  = "vcluster.loft.sh/host-secret-name"
+	SecretNameVolumeAttribute      = "vcluster.loft.sh/secret-name"
+	SecretNamespaceVolumeAttribute = "vcluster.loft.sh/secret-namespace"
+	SecretItemsVolumeAttribute     = "vcluster.loft.sh/secret-items"
+	DefaultModeVolumeAttribute     = "vcluster.loft.sh/default-mode"
+	OptionalVolumeAttribute        = "vcluster.loft.sh/optional"
+
+	// SecretProviderClassVolumeAttribute is the attribute the secrets store csi driver uses to select the provider
+	SecretProviderClassVolumeAttribute = "secretProviderClass"
+)
+
+// DefaultMode is the file mode that is used if neither the volume nor the item specify one, same as for secret volumes
+const DefaultMode int32 = 0644
+
+// Volume is a secret volume of a virtual pod as passed to the csi provider
+type Volume struct {
+	// Backend is the secret backend type, either csi or kms
+	Backend string
+
+	// VCluster is the name of the virtual cluster
+	VCluster string
+
+	// Endpoint is the address of the virtual cluster api server
+	Endpoint string
+
+	// HostNamespace is the namespace of the host pod
+	HostNamespace string
+
+	// HostSecretName is the name of the encrypted secret in the host namespace. Only set for backend kms.
+	HostSecretName string
+
+	// SecretName is the name of the virtual secret
+	SecretName string
+
+	// SecretNamespace is the namespace of the virtual secret
+	SecretNamespace string
+
+	// Items are the keys of the secret that should be projected. If empty, all keys are projected.
+	Items []corev1.KeyToPath
+
+	// DefaultMode is the mode of the files if the item does not specify one
+	DefaultMode *int32
+
+	// Optional specifies if the secret or its keys must exist
+	Optional bool
+}
+
+// File is a single file that should be written into the volume
+type File struct {
+	Path     string
+	Mode     int32
+	Contents []byte
+}
+
+// VolumeAttributes encodes the volume into csi volume attributes
+func (v *Volume) VolumeAttributes() (map[string]string, error) {
+	attributes := map[string]string{
+		BackendVolumeAttribute:         v.Backend,
+		VClusterVolumeAttribute:        v.VCluster,
+		EndpointVolumeAttribute:        v.Endpoint,
+		HostNamespaceVolumeAttribute:   v.HostNamespace,
+		SecretNameVolumeAttribute:      v.SecretName,
+		SecretNamespaceVolumeAttribute: v.SecretNamespace,
+		OptionalVolumeAttribute:        strconv.FormatBool(v.Optional),
+	}
+	if v.HostSecretName != "" {
+		attributes[HostSecretNameVolumeAttribute] = v.HostSecretName
+	}
+	if v.DefaultMode != nil {
+		attributes[DefaultModeVolumeAttribute] = strconv.FormatInt(int64(*v.DefaultMode), 10)
+	}
+	if len(v.Items) > 0 {
+		items, err := json.Marshal(v.Items)
+		if err != nil {
+			return nil, fmt.Errorf("marshal secret items: %w", err)
+		}
+
+		attributes[SecretItemsVolumeAttribute] = string(items)
+	}
+
+	return attributes, nil
+}
+
+// ParseVolumeAttributes decodes the csi volume attributes vCluster has set for a secret volume
+func ParseVolumeAttributes(attributes map[string]string) (*Volume, error) {
+	volume := &Volume{
+		Backend:         attributes[BackendVolumeAttribute],
+		VCluster:        attributes[VClusterVolumeAttribute],
+		Endpoint:        attributes[EndpointVolumeAttribute],
+		HostNamespace:   attributes[HostNamespaceVolumeAttribute],
+		HostSecretName:  attributes[HostSecretNameVolumeAttribute],
+		SecretName:      attributes[SecretNameVolumeAttribute],
+		SecretNamespace: attributes[SecretNamespaceVolumeAttribute],
+	}
+	if volume.SecretName == "" || volume.SecretNamespace == "" || volume.HostNamespace == "" {
+		return nil, fmt.Errorf("volume attributes %s, %s and %s are required", SecretNameVolumeAttribute, SecretNamespaceVolumeAttribute, HostNamespaceVolumeAttribute)
+	}
+
+	if attributes[OptionalVolumeAttribute] != "" {
+		optional, err := strconv.ParseBool(attributes[OptionalVolumeAttribute])
+		if err != nil {
+			return nil, fmt.Errorf("parse volume attribute %s: %w", OptionalVolumeAttribute, err)
+		}
+
+		volume.Optional = optional
+	}
+	if attributes[DefaultModeVolumeAttribute] != "" {
+		mode, err := strconv.ParseInt(attributes[DefaultModeVolumeAttribute], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("parse volume attribute %s: %w", DefaultModeVolumeAttribute, err)
+		}
+
+		volume.DefaultMode = ptr.To(int32(mode))
+	}
+	if attributes[SecretItemsVolumeAttribute] != "" {
+		err := json.Unmarshal([]byte(attributes[SecretItemsVolumeAttribute]), &volume.Items)
+		if err != nil {
+			return nil, fmt.Errorf("parse volume attribute %s: %w", SecretItemsVolumeAttribute, err)
+		}
+	}
+
+	return volume, nil
+}
+
+// Files returns the files that should be written into the volume for the given secret data. It follows the
+// semantics of secret volumes: if the secret was not found or a key is missing, an error is returned unless
+// the volume is optional.
+func (v *Volume) Files(data map[string][]byte, found bool) ([]File, error) {
+	if !found {
+		if v.Optional {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("secret %s/%s not found", v.SecretNamespace, v.SecretName)
+	}
+
+	defaultMode := DefaultMode
+	if v.DefaultMode != nil {
+		defaultMode = *v.DefaultMode
+	}
+
+	files := []File{}
+	if len(v.Items) == 0 {
+		keys := make([]string, 0, len(data))
+		for key := range data {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			files = append(files, File{Path: key, Mode: defaultMode, Contents: data[key]})
+		}
+
+		return files, nil
+	}
+
+	for _, item := range v.Items {
+		contents, ok := data[item.Key]
+		if !ok {
+			if v.Optional {
+				continue
+			}
+
+			return nil, fmt.Errorf("key %s does not exist in secret %s/%s", item.Key, v.SecretNamespace, v.SecretName)
+		} else if err := validatePath(item.Path); err != nil {
+			return nil, err
+		}
+
+		mode := defaultMode
+		if item.Mode != nil {
+			mode = *item.Mode
+		}
+
+		files = append(files, File{Path: item.Path, Mode: mode, Contents: contents})
+	}
+
+	return files, nil
+}
+
+func validatePath(filePath string) error {
+	if filePath == "" || path.IsAbs(filePath) {
+		return fmt.Errorf("invalid path %q: must be a non-empty relative path", filePath)
+	}
+	for _, element := range strings.Split(filePath, "/") {
+		if element == ".." {
+			return fmt.Errorf("invalid path %q: must not contain '..'", filePath)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package secretbackend
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestVolumeAttributes(t *testing.T) {
+	volume := &Volume{
+		Backend:         "kms",
+		VCluster:        "my-vcluster",
+		Endpoint:        "https://my-vcluster.vcluster-my-vcluster.svc:443",
+		HostNamespace:   "vcluster-my-vcluster",
+		HostSecretName:  "my-secret-x-default-x-my-vcluster",
+		SecretName:      "my-secret",
+		SecretNamespace: "default",
+		Items:           []corev1.KeyToPath{{Key: "password", Path: "db/password", Mode: ptr.To(int32(0400))}},
+		DefaultMode:     ptr.To(int32(0440)),
+		Optional:        true,
+	}
+
+	attributes, err := volume.VolumeAttributes()
+	assert.NilError(t, err)
+
+	parsed, err := ParseVolumeAttributes(attributes)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, parsed, volume)
+
+	_, err = ParseVolumeAttributes(map[string]string{SecretNameVolumeAttribute: "my-secret"})
+	assert.ErrorContains(t, err, "are required")
+}
+
+func TestVolumeFiles(t *testing.T) {
+	data := map[string][]byte{
+		"username": []byte("admin"),
+		"password": []byte("secret"),
+	}
+
+	testCases := []struct {
+		name    string
+		volume  Volume
+		data    map[string][]byte
+		found   bool
+		files   []File
+		wantErr string
+	}{
+		{
+			name:   "all keys with default mode",
+			volume: Volume{},
+			data:   data,
+			found:  true,
+			files: []File{
+				{Path: "password", Mode: DefaultMode, Contents: []byte("secret")},
+				{Path: "username", Mode: DefaultMode, Contents: []byte("admin")},
+			},
+		},
+		{
+			name: "items with modes",
+			volume: Volume{
+				DefaultMode: ptr.To(int32(0440)),
+				Items: []corev1.KeyToPath{
+					{Key: "password", Path: "db/password", Mode: ptr.To(int32(0400))},
+					{Key: "username", Path: "db/username"},
+				},
+			},
+			data:  data,
+			found: true,
+			files: []File{
+				{Path: "db/password", Mode: 0400, Contents: []byte("secret")},
+				{Path: "db/username", Mode: 0440, Contents: []byte("admin")},
+			},
+		},
+		{
+			name:    "missing secret",
+			volume:  Volume{SecretName: "my-secret", SecretNamespace: "default"},
+			wantErr: "secret default/my-secret not found",
+		},
+		{
+			name:   "missing optional secret",
+			volume: Volume{Optional: true},
+		},
+		{
+			name:    "missing key",
+			volume:  Volume{Items: []corev1.KeyToPath{{Key: "token", Path: "token"}}},
+			data:    data,
+			found:   true,
+			wantErr: "key token does not exist",
+		},
+		{
+			name:   "missing optional key",
+			volume: Volume{Optional: true, Items: []corev1.KeyToPath{{Key: "token", Path: "token"}}},
+			data:   data,
+			found:  true,
+			files:  []File{},
+		},
+		{
+			name:    "invalid path",
+			volume:  Volume{Items: []corev1.KeyToPath{{Key: "password", Path: "../password"}}},
+			data:    data,
+			found:   true,
+			wantErr: "must not contain '..'",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			files, err := testCase.volume.Files(testCase.data, testCase.found)
+			if testCase.wantErr != "" {
+				assert.ErrorContains(t, err, testCase.wantErr)
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.DeepEqual(t, files, testCase.files)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/loft-sh/vcluster/config"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+	koptions "k8s.io/apiserver/pkg/server/options"
+	"sigs.k8s.io/yaml"
+)
+
+// DefaultPolicyRules record who accessed which pod or node through the proxy or the fake kubelet and drop everything else
+var DefaultPolicyRules = []auditv1.PolicyRule{
+	{
+		Level: auditv1.LevelMetadata,
+		Resources: []auditv1.GroupResources{
+			{
+				Group:     "",
+				Resources: []string{"pods/exec", "pods/attach", "pods/portforward", "pods/log", "nodes/proxy"},
+			},
+		},
+	},
+	{
+		Level:           auditv1.LevelMetadata,
+		NonResourceURLs: []string{"/containerLogs/*", "/exec/*", "/attach/*", "/portForward/*", "/run/*"},
+	},
+	{
+		Level: auditv1.LevelNone,
+	},
+}
+
+// NewPolicy converts the given config into an audit policy
+func NewPolicy(policy config.ControlPlaneProxyAuditPolicy) (*auditv1.Policy, error) {
+	auditPolicy := &auditv1.Policy{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: auditv1.SchemeGroupVersion.String(),
+			Kind:       "Policy",
+		},
+		Rules: DefaultPolicyRules,
+	}
+	for _, stage := range policy.OmitStages {
+		auditPolicy.OmitStages = append(auditPolicy.OmitStages, auditv1.Stage(stage))
+	}
+	if len(policy.Rules) == 0 {
+		return auditPolicy, nil
+	}
+
+	raw, err := json.Marshal(policy.Rules)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	auditPolicy.Rules = []auditv1.PolicyRule{}
+	err = decoder.Decode(&auditPolicy.Rules)
+	if err != nil {
+		return nil, fmt.Errorf("decode rules: %w", err)
+	}
+
+	return auditPolicy, nil
+}
+
+// NewOptions creates the audit options for the given config. The policy and webhook configuration are
+// written to the given directory, as the audit backends can only be configured through files.
+func NewOptions(audit config.ControlPlaneProxyAudit, dir string) (*koptions.AuditOptions, error) {
+	if !audit.Enabled {
+		return nil, nil
+	}
+
+	policy, err := NewPolicy(audit.Policy)
+	if err != nil {
+		return nil, fmt.Errorf("create audit policy: %w", err)
+	}
+
+	rawPolicy, err := yaml.Marshal(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	options := koptions.NewAuditOptions()
+	options.PolicyFile = filepath.Join(dir, "policy.yaml")
+	err = os.WriteFile(options.PolicyFile, rawPolicy, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("write audit policy: %w", err)
+	}
+
+	options.LogOptions.Path = audit.Log.Path
+	options.LogOptions.MaxAge = audit.Log.MaxAge
+	options.LogOptions.MaxBackups = audit.Log.MaxBackups
+	options.LogOptions.MaxSize = audit.Log.MaxSize
+	if audit.Webhook.Config != "" {
+		options.WebhookOptions.ConfigFile = filepath.Join(dir, "webhook-kubeconfig.yaml")
+		err = os.WriteFile(options.WebhookOptions.ConfigFile, []byte(audit.Webhook.Config), 0600)
+		if err != nil {
+			return nil, fmt.Errorf("write audit webhook config: %w", err)
+		}
+	}
+
+	if errs := options.Validate(); len(errs) > 0 {
+		return nil, utilerrors.NewAggregate(errs)
+	}
+
+	return options, nil
+}
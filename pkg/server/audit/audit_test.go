@@ -0,0 +1,290 @@
+package audit_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/loft-sh/vcluster/config"
+	"github.com/loft-sh/vcluster/pkg/authorization/delegatingauthorizer"
+	"github.com/loft-sh/vcluster/pkg/constants"
+	"github.com/loft-sh/vcluster/pkg/scheme"
+	"github.com/loft-sh/vcluster/pkg/server/audit"
+	"github.com/loft-sh/vcluster/pkg/server/filters"
+	syncertesting "github.com/loft-sh/vcluster/pkg/syncer/testing"
+	testingutil "github.com/loft-sh/vcluster/pkg/util/testing"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/util/sets"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
+	genericapifilters "k8s.io/apiserver/pkg/endpoints/filters"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/server"
+	genericfilters "k8s.io/apiserver/pkg/server/filters"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestNewPolicy(t *testing.T) {
+	testCases := []struct {
+		name      string
+		policy    config.ControlPlaneProxyAuditPolicy
+		wantRules []auditv1.PolicyRule
+		wantOmit  []auditv1.Stage
+		wantErr   string
+	}{
+		{
+			name:      "default rules",
+			wantRules: audit.DefaultPolicyRules,
+		},
+		{
+			name: "custom rules",
+			policy: config.ControlPlaneProxyAuditPolicy{
+				Rules: []map[string]interface{}{
+					{"level": "RequestResponse", "resources": []interface{}{map[string]interface{}{"group": "", "resources": []interface{}{"secrets"}}}},
+					{"level": "None"},
+				},
+				OmitStages: []string{"RequestReceived"},
+			},
+			wantRules: []auditv1.PolicyRule{
+				{Level: auditv1.LevelRequestResponse, Resources: []auditv1.GroupResources{{Group: "", Resources: []string{"secrets"}}}},
+				{Level: auditv1.LevelNone},
+			},
+			wantOmit: []auditv1.Stage{auditv1.StageRequestReceived},
+		},
+		{
+			name: "unknown field",
+			policy: config.ControlPlaneProxyAuditPolicy{
+				Rules: []map[string]interface{}{{"level": "Metadata", "resource": "pods"}},
+			},
+			wantErr: `decode rules: json: unknown field "resource"`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			policy, err := audit.NewPolicy(testCase.policy)
+			if testCase.wantErr != "" {
+				assert.ErrorContains(t, err, testCase.wantErr)
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.Equal(t, policy.APIVersion, "audit.k8s.io/v1")
+			assert.Equal(t, policy.Kind, "Policy")
+			assert.DeepEqual(t, policy.Rules, testCase.wantRules)
+			assert.DeepEqual(t, policy.OmitStages, testCase.wantOmit)
+		})
+	}
+}
+
+func TestNewOptions(t *testing.T) {
+	testCases := []struct {
+		name         string
+		config       config.ControlPlaneProxyAudit
+		wantDisabled bool
+		wantErr      string
+		wantApplyErr string
+	}{
+		{
+			name:         "disabled",
+			config:       config.ControlPlaneProxyAudit{Log: config.ControlPlaneProxyAuditLog{Path: "-"}},
+			wantDisabled: true,
+		},
+		{
+			name:   "log",
+			config: config.ControlPlaneProxyAudit{Enabled: true, Log: config.ControlPlaneProxyAuditLog{Path: "-", MaxAge: 7}},
+		},
+		{
+			name:    "negative log max size",
+			config:  config.ControlPlaneProxyAudit{Enabled: true, Log: config.ControlPlaneProxyAuditLog{Path: "-", MaxSize: -1}},
+			wantErr: "--audit-log-maxsize -1 can't be a negative number",
+		},
+		{
+			name: "invalid policy",
+			config: config.ControlPlaneProxyAudit{
+				Enabled: true,
+				Log:     config.ControlPlaneProxyAuditLog{Path: "-"},
+				Policy:  config.ControlPlaneProxyAuditPolicy{Rules: []map[string]interface{}{{"level": []interface{}{"Metadata"}}}},
+			},
+			wantErr: "create audit policy: decode rules",
+		},
+		{
+			name: "webhook",
+			config: config.ControlPlaneProxyAudit{Enabled: true, Webhook: config.ControlPlaneProxyAuditWebhook{Config: `apiVersion: v1
+kind: Config
+clusters:
+- name: audit
+  cluster:
+    server: https://audit.example.com
+contexts:
+- name: audit
+  context:
+    cluster: audit
+current-context: audit
+`}},
+		},
+		{
+			name:         "invalid webhook config",
+			config:       config.ControlPlaneProxyAudit{Enabled: true, Webhook: config.ControlPlaneProxyAuditWebhook{Config: "clusters: {"}},
+			wantApplyErr: "initializing audit webhook",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			dir := t.TempDir()
+			options, err := audit.NewOptions(testCase.config, dir)
+			if testCase.wantErr != "" {
+				assert.ErrorContains(t, err, testCase.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			if testCase.wantDisabled {
+				assert.Assert(t, options == nil)
+				return
+			}
+
+			// the policy and webhook config are passed to the audit backends as files
+			assert.Equal(t, options.PolicyFile, filepath.Join(dir, "policy.yaml"))
+			_, err = os.Stat(options.PolicyFile)
+			assert.NilError(t, err)
+			assert.Equal(t, options.LogOptions.Path, testCase.config.Log.Path)
+			assert.Equal(t, options.LogOptions.MaxAge, testCase.config.Log.MaxAge)
+			if testCase.config.Webhook.Config != "" {
+				webhookConfig, err := os.ReadFile(options.WebhookOptions.ConfigFile)
+				assert.NilError(t, err)
+				assert.Equal(t, string(webhookConfig), testCase.config.Webhook.Config)
+			}
+
+			serverConfig := server.NewConfig(serializer.NewCodecFactory(scheme.Scheme))
+			err = options.ApplyTo(serverConfig)
+			if testCase.wantApplyErr != "" {
+				assert.ErrorContains(t, err, testCase.wantApplyErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Assert(t, serverConfig.AuditBackend != nil)
+			assert.Assert(t, serverConfig.AuditPolicyRuleEvaluator != nil)
+		})
+	}
+}
+
+func TestAuditedRequests(t *testing.T) {
+	// the host api server the requests are redirected to
+	hostPaths := []string{}
+	hostServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		hostPaths = append(hostPaths, req.URL.Path)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer hostServer.Close()
+
+	// audit events are written as json lines to the log file
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "audit.log")
+	options, err := audit.NewOptions(config.ControlPlaneProxyAudit{Enabled: true, Log: config.ControlPlaneProxyAuditLog{Path: logPath}}, filepath.Join(dir, "config"))
+	assert.NilError(t, err)
+	serverConfig := server.NewConfig(serializer.NewCodecFactory(scheme.Scheme))
+	assert.NilError(t, options.ApplyTo(serverConfig))
+	stopChan := make(chan struct{})
+	assert.NilError(t, serverConfig.AuditBackend.Run(stopChan))
+
+	// the fake kubelet resolves the node by the host name of the request
+	vClient := testingutil.NewFakeClient(scheme.Scheme, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}})
+	assert.NilError(t, vClient.IndexField(context.Background(), &corev1.Node{}, constants.IndexByHostName, func(object client.Object) []string {
+		return []string{object.GetName() + ".nodes.vcluster.com"}
+	}))
+	pClient := testingutil.NewFakeClient(scheme.Scheme)
+	registerCtx := syncertesting.NewFakeRegisterContext(syncertesting.NewFakeConfig(), pClient, vClient)
+	registerCtx.PhysicalManager = &hostManager{Manager: registerCtx.PhysicalManager, config: &rest.Config{Host: hostServer.URL}}
+
+	// build the handler chain like the server does
+	var h http.Handler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	h = filters.WithRedirect(h, registerCtx, vClient, nil, []delegatingauthorizer.GroupVersionResourceVerb{{
+		GroupVersionResource: corev1.SchemeGroupVersion.WithResource("pods"),
+		Verb:                 "*",
+		SubResource:          "exec",
+	}})
+	h = filters.WithFakeKubelet(h, registerCtx)
+	h = genericapifilters.WithAudit(h, serverConfig.AuditBackend, serverConfig.AuditPolicyRuleEvaluator, genericfilters.BasicLongRunningRequestCheck(sets.NewString("watch", "proxy"), sets.NewString("attach", "exec", "proxy", "log", "portforward")))
+	h = filters.WithNodeName(h, syncertesting.DefaultTestCurrentNamespace, false, vClient, pClient)
+	next := h
+	h = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		next.ServeHTTP(w, req.WithContext(request.WithUser(req.Context(), &user.DefaultInfo{Name: "alice"})))
+	})
+	h = genericapifilters.WithRequestInfo(h, &request.RequestInfoFactory{APIPrefixes: sets.NewString("api", "apis"), GrouplessAPIPrefixes: sets.NewString("api")})
+	h = genericapifilters.WithAuditInit(h)
+
+	requests := []struct {
+		host string
+		path string
+	}{
+		{host: "vcluster.com:443", path: "/api/v1/namespaces/default/pods/test/exec?command=ls&container=test"},
+		{host: "vcluster.com:443", path: "/api/v1/namespaces/default/configmaps"},
+		{host: "node1.nodes.vcluster.com:10250", path: "/exec/default/test/test?command=ls"},
+		{host: "node1.nodes.vcluster.com:10250", path: "/containerLogs/default/test/test"},
+		{host: "node1.nodes.vcluster.com:10250", path: "/portForward/default/test"},
+	}
+	for _, r := range requests {
+		req := httptest.NewRequest(http.MethodPost, r.path, nil)
+		req.Host = r.host
+		recorder := httptest.NewRecorder()
+		h.ServeHTTP(recorder, req)
+	}
+
+	// flush the audit events
+	close(stopChan)
+	serverConfig.AuditBackend.Shutdown()
+
+	hostPodName := translate.Default.HostName("test", "default")
+	assert.DeepEqual(t, hostPaths, []string{
+		"/api/v1/namespaces/test/pods/" + hostPodName + "/exec/",
+		"/api/v1/nodes/node1/proxy/exec/default/test/test",
+		"/api/v1/nodes/node1/proxy/containerLogs/default/test/test",
+		"/api/v1/nodes/node1/proxy/portForward/default/test",
+	})
+
+	// only the exec, log and port-forward requests are recorded by the default policy
+	rawEvents, err := os.ReadFile(logPath)
+	assert.NilError(t, err)
+	events := []auditv1.Event{}
+	for _, line := range strings.Split(strings.TrimSpace(string(rawEvents)), "\n") {
+		event := auditv1.Event{}
+		assert.NilError(t, json.Unmarshal([]byte(line), &event))
+		if event.Stage == auditv1.StageResponseComplete {
+			events = append(events, event)
+		}
+	}
+	assert.Equal(t, len(events), 4)
+	assert.Equal(t, events[0].Level, auditv1.LevelMetadata)
+	assert.Equal(t, events[0].User.Username, "alice")
+	assert.Equal(t, events[0].ObjectRef.Subresource, "exec")
+	assert.Equal(t, events[0].Annotations[filters.HostPodAuditAnnotation], "test/"+hostPodName)
+	for i, path := range []string{"/exec/default/test/test?command=ls", "/containerLogs/default/test/test", "/portForward/default/test"} {
+		assert.Equal(t, events[i+1].Level, auditv1.LevelMetadata)
+		assert.Equal(t, events[i+1].RequestURI, path)
+		assert.Equal(t, events[i+1].Annotations[filters.FakeKubeletNodeAuditAnnotation], "node1")
+	}
+}
+
+type hostManager struct {
+	ctrl.Manager
+
+	config *rest.Config
+}
+
+func (m *hostManager) GetConfig() *rest.Config {
+	return m.config
+}
@@ -11,6 +11,18 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
+// DefaultSANs returns the SANs that are always part of the serving certificate of the virtual api server
+func DefaultSANs(clusterDomain string) []string {
+	return []string{
+		"kubernetes.default.svc." + clusterDomain,
+		"kubernetes.default.svc",
+		"kubernetes.default",
+		"kubernetes",
+		"localhost",
+		"127.0.0.1",
+	}
+}
+
 func GenServingCerts(caCertFile, caKeyFile string, currentCert, currentKey []byte, clusterDomain string, SANs []string) ([]byte, []byte, bool, error) {
 	regen := false
 	commonName := "kube-apiserver"
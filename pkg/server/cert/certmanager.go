@@ -0,0 +1,137 @@
+package cert
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	vclusterconfig "github.com/loft-sh/vcluster/config"
+	"github.com/loft-sh/vcluster/pkg/certs"
+	"github.com/loft-sh/vcluster/pkg/lifecycle"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+// certManagerSyncer serves the certificate cert-manager issued for the virtual api server instead of generating one
+type certManagerSyncer struct {
+	*syncer
+
+	vClusterName string
+	certManager  vclusterconfig.ControlPlaneCertificatesCertManager
+
+	// certificatesDir holds the root CA bundle that is trusted by pods within the virtual cluster
+	certificatesDir       string
+	currentCA             []byte
+	controlPlaneClient    kubernetes.Interface
+	controlPlaneNamespace string
+}
+
+func (s *certManagerSyncer) RunOnce(ctx context.Context) error {
+	s.currentCertMutex.Lock()
+	defer s.currentCertMutex.Unlock()
+
+	extraSANs, err := s.getSANs(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = s.updateCertificate(ctx, extraSANs)
+	if err != nil {
+		return err
+	}
+
+	// wait until cert-manager has issued the certificate
+	klog.Infof("Waiting for cert-manager to issue certificate %s/%s", s.currentNamespace, certs.CertManagerCertificateName(s.vClusterName))
+	return wait.PollUntilContextTimeout(ctx, time.Second*2, time.Minute*5, true, func(ctx context.Context) (bool, error) {
+		return s.loadCertificate(ctx)
+	})
+}
+
+func (s *certManagerSyncer) Run(ctx context.Context, _ int) {
+	wait.JitterUntilWithContext(ctx, func(ctx context.Context) {
+		extraSANs, err := s.getSANs(ctx)
+		if err != nil {
+			klog.Infof("Error retrieving SANs: %v", err)
+			return
+		}
+
+		s.currentCertMutex.Lock()
+		defer s.currentCertMutex.Unlock()
+
+		if !reflect.DeepEqual(extraSANs, s.currentSANs) {
+			err = s.updateCertificate(ctx, extraSANs)
+			if err != nil {
+				klog.Infof("Error updating cert-manager certificate: %v", err)
+				return
+			}
+		}
+
+		changed, err := s.loadCertificate(ctx)
+		if err != nil {
+			klog.Infof("Error loading cert-manager certificate: %v", err)
+			return
+		} else if changed {
+			for _, l := range s.listeners {
+				l.Enqueue()
+			}
+
+			err = s.updateRootCA(ctx)
+			if err != nil {
+				klog.Infof("Error updating root CA: %v", err)
+			}
+		}
+	}, time.Second*2, 1.25, true)
+}
+
+// updateRootCA rewrites the root CA bundle if the issuing CA has changed. As the controller manager only reads the
+// bundle on startup, the vCluster is restarted afterward, so that pods receive the new bundle.
+func (s *certManagerSyncer) updateRootCA(ctx context.Context) error {
+	if len(s.currentCA) == 0 {
+		return nil
+	}
+
+	changed, err := certs.WriteRootCA(s.certificatesDir, s.currentCA)
+	if err != nil {
+		return err
+	} else if !changed {
+		return nil
+	}
+
+	klog.Infof("Issuing CA of certificate %s/%s has changed, restarting vCluster to publish the new root CA", s.currentNamespace, certs.CertManagerCertificateName(s.vClusterName))
+	return lifecycle.RolloutRestart(ctx, s.controlPlaneClient, s.vClusterName, s.controlPlaneNamespace)
+}
+
+func (s *certManagerSyncer) updateCertificate(ctx context.Context, extraSANs []string) error {
+	klog.Infof("Requesting serving cert from cert-manager for service ips: %v", extraSANs)
+	err := certs.EnsureCertManagerCertificate(ctx, s.currentNamespaceCient, s.vClusterName, s.currentNamespace, s.certManager, append(DefaultSANs(s.clusterDomain), extraSANs...))
+	if err != nil {
+		return err
+	}
+
+	s.currentSANs = extraSANs
+	return nil
+}
+
+// loadCertificate loads the issued certificate and returns true if it has changed
+func (s *certManagerSyncer) loadCertificate(ctx context.Context) (bool, error) {
+	cert, key, ca, err := certs.GetCertManagerCertificate(ctx, s.currentNamespaceCient, s.vClusterName, s.currentNamespace)
+	if err != nil {
+		return false, err
+	} else if cert == nil {
+		if len(s.currentCert) == 0 {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("certificate secret %s/%s was removed", s.currentNamespace, certs.CertManagerSecretName(s.vClusterName))
+	} else if bytes.Equal(cert, s.currentCert) && bytes.Equal(key, s.currentKey) && bytes.Equal(ca, s.currentCA) {
+		return false, nil
+	}
+
+	s.currentCert = cert
+	s.currentKey = key
+	s.currentCA = ca
+	return true, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"reflect"
 	"sort"
 	"sync"
@@ -34,6 +35,22 @@ type Syncer interface {
 }
 
 func NewSyncer(_ context.Context, currentNamespace string, currentNamespaceClient client.Client, options *config.VirtualClusterConfig) (Syncer, error) {
+	if options.ControlPlane.Certificates.CertManager.Enabled {
+		return &certManagerSyncer{
+			syncer:       newSyncer(currentNamespace, currentNamespaceClient, options),
+			vClusterName: options.Name,
+			certManager:  options.ControlPlane.Certificates.CertManager,
+
+			certificatesDir:       filepath.Dir(options.VirtualClusterKubeConfig().ServerCACert),
+			controlPlaneClient:    options.ControlPlaneClient,
+			controlPlaneNamespace: options.ControlPlaneNamespace,
+		}, nil
+	}
+
+	return newSyncer(currentNamespace, currentNamespaceClient, options), nil
+}
+
+func newSyncer(currentNamespace string, currentNamespaceClient client.Client, options *config.VirtualClusterConfig) *syncer {
 	return &syncer{
 		clusterDomain: options.Networking.Advanced.ClusterDomain,
 
@@ -48,7 +65,7 @@ func NewSyncer(_ context.Context, currentNamespace string, currentNamespaceClien
 		serviceName:           options.WorkloadService,
 		currentNamespace:      currentNamespace,
 		currentNamespaceCient: currentNamespaceClient,
-	}, nil
+	}
 }
 
 type syncer struct {
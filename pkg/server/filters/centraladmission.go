@@ -0,0 +1,62 @@
+package filters
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/loft-sh/vcluster/pkg/controllers/centraladmission"
+	requestpkg "github.com/loft-sh/vcluster/pkg/util/request"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apiserver/pkg/endpoints/request"
+)
+
+// WithCentralAdmission denies changes to the webhook configurations of policies.centralAdmission within the virtual
+// cluster. The webhooks themselves are called by the virtual api server.
+func WithCentralAdmission(h http.Handler, enabled bool) http.Handler {
+	if !enabled {
+		return h
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		info, ok := request.RequestInfoFrom(req.Context())
+		if !ok {
+			requestpkg.FailWithStatus(w, req, http.StatusInternalServerError, fmt.Errorf("request info is missing"))
+			return
+		}
+
+		if info.IsResourceRequest &&
+			info.APIGroup == admissionregistrationv1.GroupName &&
+			(info.Resource == "validatingwebhookconfigurations" || info.Resource == "mutatingwebhookconfigurations") {
+			if (info.Verb == "update" || info.Verb == "patch" || info.Verb == "delete") &&
+				strings.HasPrefix(info.Name, centraladmission.NamePrefix) {
+				requestpkg.FailWithStatus(w, req, http.StatusForbidden, fmt.Errorf("%s %s is managed by vCluster policies.centralAdmission and cannot be changed", info.Resource, info.Name))
+				return
+			} else if info.Verb == "deletecollection" && !excludesCentralAdmission(req.URL.Query().Get("labelSelector")) {
+				requestpkg.FailWithStatus(w, req, http.StatusForbidden, fmt.Errorf("deleting a collection of %s would delete the webhook configurations managed by vCluster policies.centralAdmission, exclude them with the label selector %s!=true", info.Resource, centraladmission.ManagedLabel))
+				return
+			}
+		}
+
+		h.ServeHTTP(w, req)
+	})
+}
+
+// excludesCentralAdmission returns true if the given label selector never matches the webhook
+// configurations managed by policies.centralAdmission
+func excludesCentralAdmission(labelSelector string) bool {
+	selector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return false
+	}
+
+	requirements, _ := selector.Requirements()
+	for _, requirement := range requirements {
+		if requirement.Key() == centraladmission.ManagedLabel && !requirement.Matches(labels.Set{centraladmission.ManagedLabel: "true"}) {
+			return true
+		}
+	}
+
+	return false
+}
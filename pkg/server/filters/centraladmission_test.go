@@ -0,0 +1,106 @@
+package filters
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/loft-sh/vcluster/pkg/controllers/centraladmission"
+	"gotest.tools/v3/assert"
+	"k8s.io/apiserver/pkg/endpoints/request"
+)
+
+func TestWithCentralAdmission(t *testing.T) {
+	h := WithCentralAdmission(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}), true)
+
+	testCases := []struct {
+		name          string
+		verb          string
+		resource      string
+		objectName    string
+		labelSelector string
+		wantCode      int
+	}{
+		{
+			name:       "update managed",
+			verb:       "update",
+			resource:   "validatingwebhookconfigurations",
+			objectName: centraladmission.NamePrefix + "policies",
+			wantCode:   http.StatusForbidden,
+		},
+		{
+			name:       "delete managed",
+			verb:       "delete",
+			resource:   "mutatingwebhookconfigurations",
+			objectName: centraladmission.NamePrefix + "policies",
+			wantCode:   http.StatusForbidden,
+		},
+		{
+			name:       "delete other",
+			verb:       "delete",
+			resource:   "validatingwebhookconfigurations",
+			objectName: "other",
+			wantCode:   http.StatusOK,
+		},
+		{
+			name:     "delete collection",
+			verb:     "deletecollection",
+			resource: "validatingwebhookconfigurations",
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:          "delete collection with other selector",
+			verb:          "deletecollection",
+			resource:      "mutatingwebhookconfigurations",
+			labelSelector: "app=test",
+			wantCode:      http.StatusForbidden,
+		},
+		{
+			name:          "delete collection excluding managed",
+			verb:          "deletecollection",
+			resource:      "validatingwebhookconfigurations",
+			labelSelector: centraladmission.ManagedLabel + "!=true",
+			wantCode:      http.StatusOK,
+		},
+		{
+			name:          "delete collection without managed label",
+			verb:          "deletecollection",
+			resource:      "mutatingwebhookconfigurations",
+			labelSelector: "!" + centraladmission.ManagedLabel,
+			wantCode:      http.StatusOK,
+		},
+		{
+			name:     "list",
+			verb:     "list",
+			resource: "validatingwebhookconfigurations",
+			wantCode: http.StatusOK,
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := request.WithRequestInfo(context.Background(), &request.RequestInfo{
+				IsResourceRequest: true,
+				Verb:              tt.verb,
+				APIGroup:          "admissionregistration.k8s.io",
+				APIVersion:        "v1",
+				Resource:          tt.resource,
+				Name:              tt.objectName,
+			})
+			path := "/apis/admissionregistration.k8s.io/v1/" + tt.resource
+			if tt.objectName != "" {
+				path += "/" + tt.objectName
+			}
+			if tt.labelSelector != "" {
+				path += "?labelSelector=" + url.QueryEscape(tt.labelSelector)
+			}
+			req := httptest.NewRequest(http.MethodDelete, path, nil).WithContext(ctx)
+			recorder := httptest.NewRecorder()
+			h.ServeHTTP(recorder, req)
+			assert.Equal(t, recorder.Code, tt.wantCode)
+		})
+	}
+}
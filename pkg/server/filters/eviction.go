@@ -0,0 +1,203 @@
+package filters
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/loft-sh/vcluster/pkg/authorization/delegatingauthorizer"
+	"github.com/loft-sh/vcluster/pkg/mappings"
+	"github.com/loft-sh/vcluster/pkg/scheme"
+	"github.com/loft-sh/vcluster/pkg/syncer/synccontext"
+	"github.com/loft-sh/vcluster/pkg/util/encoding"
+	requestpkg "github.com/loft-sh/vcluster/pkg/util/request"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metainternalversionscheme "k8s.io/apimachinery/pkg/apis/meta/internalversion/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/endpoints/handlers/negotiation"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PodEvictionResource is authorized against the virtual cluster by the delegating authorizer, because WithPodEviction
+// bypasses the virtual api server
+var PodEvictionResource = delegatingauthorizer.GroupVersionResourceVerb{
+	GroupVersionResource: corev1.SchemeGroupVersion.WithResource("pods"),
+	Verb:                 "create",
+	SubResource:          "eviction",
+}
+
+// WithPodEviction redirects pod evictions to the host cluster, so that they are checked against the synced
+// pod disruption budgets by the host eviction api instead of the virtual one, which has no status to check against.
+// Evictions are authorized against the virtual cluster by the delegating authorizer and passed through the admission
+// webhooks of the virtual cluster before they are sent to the host cluster.
+func WithPodEviction(handler http.Handler, registerCtx *synccontext.RegisterContext, uncachedLocalClient, uncachedVirtualClient client.Client, admit admission.Interface) http.Handler {
+	decoder := encoding.NewDecoder(scheme.Scheme, false)
+	s := serializer.NewCodecFactory(scheme.Scheme)
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		info, ok := request.RequestInfoFrom(req.Context())
+		if !ok {
+			requestpkg.FailWithStatus(w, req, http.StatusInternalServerError, fmt.Errorf("request info is missing"))
+			return
+		}
+
+		if info.APIVersion == corev1.SchemeGroupVersion.Version && info.APIGroup == corev1.SchemeGroupVersion.Group && info.Resource == "pods" && info.Subresource == "eviction" && info.Verb == "create" {
+			options := &metav1.CreateOptions{}
+			if err := metainternalversionscheme.ParameterCodec.DecodeParameters(req.URL.Query(), metav1.SchemeGroupVersion, options); err != nil {
+				responsewriters.ErrorNegotiated(err, s, corev1.SchemeGroupVersion, w, req)
+				return
+			}
+
+			if len(options.DryRun) == 0 {
+				// the request was authorized against the virtual cluster already, so we can redirect it to the host cluster
+				rawObj, err := io.ReadAll(req.Body)
+				if err != nil {
+					responsewriters.ErrorNegotiated(err, s, corev1.SchemeGroupVersion, w, req)
+					return
+				}
+
+				syncContext := registerCtx.ToSyncContext("pod-eviction")
+				syncContext.VirtualClient = uncachedVirtualClient
+				syncContext.PhysicalClient = uncachedLocalClient
+
+				handled, err := evictPod(syncContext, req, decoder, rawObj, info, options, admit)
+				if err != nil {
+					responsewriters.ErrorNegotiated(err, s, corev1.SchemeGroupVersion, w, req)
+					return
+				} else if handled {
+					responsewriters.WriteObjectNegotiated(s, negotiation.DefaultEndpointRestrictions, metav1.SchemeGroupVersion, w, req, http.StatusCreated, &metav1.Status{Status: metav1.StatusSuccess, Code: http.StatusCreated}, false)
+					return
+				}
+
+				// let the virtual cluster handle the eviction
+				req.Body = io.NopCloser(bytes.NewReader(rawObj))
+			}
+		}
+
+		handler.ServeHTTP(w, req)
+	})
+}
+
+func evictPod(ctx *synccontext.SyncContext, req *http.Request, decoder encoding.Decoder, rawObj []byte, info *request.RequestInfo, options *metav1.CreateOptions, admit admission.Interface) (bool, error) {
+	evictionGVK := policyv1.SchemeGroupVersion.WithKind("Eviction")
+	obj, err := decoder.Decode(rawObj, &evictionGVK)
+	if err != nil {
+		return false, err
+	}
+	if _, err := evictionDeleteOptions(obj); err != nil {
+		return false, err
+	}
+
+	// get the virtual pod
+	vPod := &corev1.Pod{}
+	err = ctx.VirtualClient.Get(req.Context(), types.NamespacedName{Namespace: info.Namespace, Name: info.Name}, vPod)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	// get the host pod, if the pod is not synced yet the virtual cluster can handle the eviction
+	pPod := &corev1.Pod{}
+	pPodName := mappings.VirtualToHost(ctx, vPod.Name, vPod.Namespace, mappings.Pods())
+	err = ctx.PhysicalClient.Get(req.Context(), pPodName, pPod)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	// the virtual api server is bypassed, so we need to call its admission webhooks
+	err = admitEviction(req, obj, info, options, admit, ctx.VirtualClient)
+	if err != nil {
+		return false, err
+	}
+
+	// preconditions refer to the virtual pod, so we need to exchange them
+	deleteOptions, err := evictionDeleteOptions(obj)
+	if err != nil {
+		return false, err
+	}
+	if deleteOptions != nil && deleteOptions.Preconditions != nil {
+		deleteOptions = deleteOptions.DeepCopy()
+		if deleteOptions.Preconditions.UID != nil {
+			if *deleteOptions.Preconditions.UID != vPod.UID {
+				return false, kerrors.NewConflict(corev1.Resource("pods"), vPod.Name, fmt.Errorf("precondition failed: UID in precondition: %v, UID in object meta: %v", *deleteOptions.Preconditions.UID, vPod.UID))
+			}
+
+			deleteOptions.Preconditions.UID = &pPod.UID
+		}
+
+		// resource versions differ between virtual and host cluster
+		deleteOptions.Preconditions.ResourceVersion = nil
+	}
+
+	klog.FromContext(ctx).V(1).Info("Evict pod in host cluster", "pod", pPodName.String(), "virtualPod", types.NamespacedName{Namespace: vPod.Namespace, Name: vPod.Name}.String())
+	err = ctx.PhysicalClient.SubResource("eviction").Create(req.Context(), pPod, &policyv1.Eviction{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pPod.Name,
+			Namespace: pPod.Namespace,
+		},
+		DeleteOptions: deleteOptions,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func admitEviction(req *http.Request, obj runtime.Object, info *request.RequestInfo, options *metav1.CreateOptions, admit admission.Interface, uncachedVirtualClient client.Client) error {
+	if admit == nil || !admit.Handles(admission.Create) {
+		return nil
+	}
+
+	userInfo, ok := request.UserFrom(req.Context())
+	if !ok {
+		return kerrors.NewInternalError(fmt.Errorf("user info is missing"))
+	}
+
+	attributes := admission.NewAttributesRecord(obj, nil, obj.GetObjectKind().GroupVersionKind(), info.Namespace, info.Name, corev1.SchemeGroupVersion.WithResource("pods"), "eviction", admission.Create, options, false, userInfo)
+	objectInterfaces := NewFakeObjectInterfaces(uncachedVirtualClient.Scheme(), uncachedVirtualClient.RESTMapper())
+	if mutatingAdmission, ok := admit.(admission.MutationInterface); ok {
+		err := mutatingAdmission.Admit(req.Context(), attributes, objectInterfaces)
+		if err != nil {
+			klog.Infof("Admission mutate failed for %s: %v", info.Path, err)
+			return err
+		}
+	}
+	if validatingAdmission, ok := admit.(admission.ValidationInterface); ok {
+		err := validatingAdmission.Validate(req.Context(), attributes, objectInterfaces)
+		if err != nil {
+			klog.Infof("Admission validate failed for %s: %v", info.Path, err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func evictionDeleteOptions(obj runtime.Object) (*metav1.DeleteOptions, error) {
+	switch eviction := obj.(type) {
+	case *policyv1.Eviction:
+		return eviction.DeleteOptions, nil
+	case *policyv1beta1.Eviction:
+		return eviction.DeleteOptions, nil
+	}
+
+	return nil, kerrors.NewBadRequest(fmt.Sprintf("expected eviction object, got %T", obj))
+}
@@ -0,0 +1,235 @@
+package filters
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/loft-sh/vcluster/pkg/authorization/delegatingauthorizer"
+	"github.com/loft-sh/vcluster/pkg/scheme"
+	syncertesting "github.com/loft-sh/vcluster/pkg/syncer/testing"
+	testingutil "github.com/loft-sh/vcluster/pkg/util/testing"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	"gotest.tools/v3/assert"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/authentication/user"
+	genericapifilters "k8s.io/apiserver/pkg/endpoints/filters"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestWithPodEviction(t *testing.T) {
+	translate.Default = translate.NewSingleNamespaceTranslator(syncertesting.DefaultTestTargetNamespace)
+	vPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+			UID:       "virtual-uid",
+		},
+	}
+	pPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      translate.Default.HostName("test", "default"),
+			Namespace: syncertesting.DefaultTestTargetNamespace,
+			UID:       "host-uid",
+		},
+	}
+	hostUID := types.UID("host-uid")
+
+	testCases := []struct {
+		name         string
+		body         string
+		query        string
+		unauthorized bool
+		unsynced     bool
+		admitErr     error
+		hostErr      error
+		wantCode     int
+		wantNext     bool
+		wantEviction *policyv1.Eviction
+		wantMessage  string
+	}{
+		{
+			name:     "evict synced pod",
+			body:     `{"apiVersion":"policy/v1","kind":"Eviction","metadata":{"name":"test","namespace":"default"}}`,
+			wantCode: http.StatusCreated,
+			wantEviction: &policyv1.Eviction{
+				ObjectMeta: metav1.ObjectMeta{Name: pPod.Name, Namespace: pPod.Namespace},
+			},
+		},
+		{
+			name:         "not authorized in virtual cluster",
+			body:         `{"apiVersion":"policy/v1","kind":"Eviction","metadata":{"name":"test","namespace":"default"}}`,
+			unauthorized: true,
+			wantCode:     http.StatusForbidden,
+		},
+		{
+			name:     "exchange precondition uid",
+			body:     `{"apiVersion":"policy/v1","kind":"Eviction","metadata":{"name":"test","namespace":"default"},"deleteOptions":{"preconditions":{"uid":"virtual-uid","resourceVersion":"1"}}}`,
+			wantCode: http.StatusCreated,
+			wantEviction: &policyv1.Eviction{
+				ObjectMeta:    metav1.ObjectMeta{Name: pPod.Name, Namespace: pPod.Namespace},
+				DeleteOptions: &metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &hostUID}},
+			},
+		},
+		{
+			name:        "precondition uid mismatch",
+			body:        `{"apiVersion":"policy/v1","kind":"Eviction","metadata":{"name":"test","namespace":"default"},"deleteOptions":{"preconditions":{"uid":"other-uid"}}}`,
+			wantCode:    http.StatusConflict,
+			wantMessage: "precondition failed",
+		},
+		{
+			name:     "pod not synced",
+			body:     `{"apiVersion":"policy/v1","kind":"Eviction","metadata":{"name":"test","namespace":"default"}}`,
+			unsynced: true,
+			wantCode: http.StatusOK,
+			wantNext: true,
+		},
+		{
+			name:     "dry run",
+			body:     `{"apiVersion":"policy/v1","kind":"Eviction","metadata":{"name":"test","namespace":"default"}}`,
+			query:    "?dryRun=All",
+			wantCode: http.StatusOK,
+			wantNext: true,
+		},
+		{
+			name:        "denied by admission webhook",
+			body:        `{"apiVersion":"policy/v1","kind":"Eviction","metadata":{"name":"test","namespace":"default"}}`,
+			admitErr:    kerrors.NewForbidden(corev1.Resource("pods"), "test", io.EOF),
+			wantCode:    http.StatusForbidden,
+			wantMessage: `pods \"test\" is forbidden`,
+		},
+		{
+			name:        "host pod disruption budget",
+			body:        `{"apiVersion":"policy/v1","kind":"Eviction","metadata":{"name":"test","namespace":"default"}}`,
+			hostErr:     kerrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0),
+			wantCode:    http.StatusTooManyRequests,
+			wantMessage: "would violate the pod's disruption budget",
+			wantEviction: &policyv1.Eviction{
+				ObjectMeta: metav1.ObjectMeta{Name: pPod.Name, Namespace: pPod.Namespace},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			pObjects := []runtime.Object{}
+			if !testCase.unsynced {
+				pObjects = append(pObjects, pPod.DeepCopy())
+			}
+			pClient := &fakeEvictionClient{Client: testingutil.NewFakeClient(scheme.Scheme, pObjects...), err: testCase.hostErr}
+			vClient := &fakeAuthorizationClient{FakeIndexClient: testingutil.NewFakeClient(scheme.Scheme, vPod.DeepCopy()), allowed: !testCase.unauthorized}
+			registerCtx := syncertesting.NewFakeRegisterContext(syncertesting.NewFakeConfig(), pClient.Client.(*testingutil.FakeIndexClient), vClient.FakeIndexClient)
+			admit := &fakeEvictionAdmission{err: testCase.admitErr}
+
+			nextBody := ""
+			next := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				body, _ := io.ReadAll(req.Body)
+				nextBody = string(body)
+				w.WriteHeader(http.StatusOK)
+			})
+			h := WithPodEviction(next, registerCtx, pClient, vClient, admit)
+			h = genericapifilters.WithAuthorization(h, delegatingauthorizer.New(vClient, []delegatingauthorizer.GroupVersionResourceVerb{PodEvictionResource}, nil), serializer.NewCodecFactory(scheme.Scheme))
+
+			info := &request.RequestInfo{IsResourceRequest: true, APIVersion: "v1", Namespace: "default", Resource: "pods", Subresource: "eviction", Name: "test", Verb: "create"}
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/namespaces/default/pods/test/eviction"+testCase.query, strings.NewReader(testCase.body))
+			req = req.WithContext(request.WithUser(request.WithRequestInfo(req.Context(), info), &user.DefaultInfo{Name: "alice"}))
+			recorder := httptest.NewRecorder()
+			h.ServeHTTP(recorder, req)
+			assert.Equal(t, recorder.Code, testCase.wantCode, recorder.Body.String())
+			assert.Assert(t, strings.Contains(recorder.Body.String(), testCase.wantMessage), recorder.Body.String())
+
+			// requests the filter does not handle are passed on with their body
+			if testCase.wantNext {
+				assert.Equal(t, nextBody, testCase.body)
+			} else {
+				assert.Equal(t, nextBody, "")
+			}
+
+			// admission webhooks are only called for evictions of synced pods
+			if testCase.unauthorized || testCase.unsynced || testCase.query != "" {
+				assert.Assert(t, admit.attributes == nil)
+			} else {
+				assert.Assert(t, admit.attributes != nil)
+				assert.Equal(t, admit.attributes.GetSubresource(), "eviction")
+				assert.Equal(t, admit.attributes.GetUserInfo().GetName(), "alice")
+			}
+
+			assert.DeepEqual(t, pClient.eviction, testCase.wantEviction)
+			err := pClient.Get(context.Background(), client.ObjectKeyFromObject(pPod), &corev1.Pod{})
+			if testCase.wantCode == http.StatusCreated {
+				assert.Assert(t, kerrors.IsNotFound(err))
+			} else if !testCase.unsynced {
+				assert.NilError(t, err)
+			}
+		})
+	}
+}
+
+type fakeEvictionAdmission struct {
+	err        error
+	attributes admission.Attributes
+}
+
+func (f *fakeEvictionAdmission) Handles(admission.Operation) bool {
+	return true
+}
+
+func (f *fakeEvictionAdmission) Validate(_ context.Context, a admission.Attributes, _ admission.ObjectInterfaces) error {
+	f.attributes = a
+	return f.err
+}
+
+// fakeAuthorizationClient answers subject access reviews of the delegating authorizer
+type fakeAuthorizationClient struct {
+	*testingutil.FakeIndexClient
+
+	allowed bool
+}
+
+func (f *fakeAuthorizationClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if accessReview, ok := obj.(*authorizationv1.SubjectAccessReview); ok {
+		accessReview.Status.Allowed = f.allowed
+		return nil
+	}
+
+	return f.FakeIndexClient.Create(ctx, obj, opts...)
+}
+
+// fakeEvictionClient records the eviction sent to the host cluster
+type fakeEvictionClient struct {
+	client.Client
+
+	err      error
+	eviction *policyv1.Eviction
+}
+
+func (f *fakeEvictionClient) SubResource(subResource string) client.SubResourceClient {
+	return &fakeEvictionSubResourceClient{SubResourceClient: f.Client.SubResource(subResource), client: f}
+}
+
+type fakeEvictionSubResourceClient struct {
+	client.SubResourceClient
+
+	client *fakeEvictionClient
+}
+
+func (f *fakeEvictionSubResourceClient) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	f.client.eviction = subResource.(*policyv1.Eviction)
+	if f.client.err != nil {
+		return f.client.err
+	}
+
+	return f.SubResourceClient.Create(ctx, obj, subResource, opts...)
+}
@@ -7,9 +7,13 @@ import (
 	"github.com/loft-sh/vcluster/pkg/syncer/synccontext"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apiserver/pkg/audit"
 	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
 )
 
+// FakeKubeletNodeAuditAnnotation is the audit annotation that holds the node a fake kubelet request was sent to
+const FakeKubeletNodeAuditAnnotation = "vcluster.loft.sh/fake-kubelet-node"
+
 func WithFakeKubelet(h http.Handler, registerCtx *synccontext.RegisterContext) http.Handler {
 	s := serializer.NewCodecFactory(scheme.Scheme)
 	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
@@ -22,6 +26,7 @@ func WithFakeKubelet(h http.Handler, registerCtx *synccontext.RegisterContext) h
 
 			// construct the actual path
 			req.URL.Path = "/api/v1/nodes/" + nodeName + "/proxy" + req.URL.Path
+			audit.AddAuditAnnotation(req.Context(), FakeKubeletNodeAuditAnnotation, nodeName)
 
 			// execute the request
 			_, err := handleNodeRequest(registerCtx, w, req)
@@ -17,12 +17,16 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/audit"
 	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
 	"k8s.io/apiserver/pkg/endpoints/request"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// HostPodAuditAnnotation is the audit annotation that holds the host pod a request was redirected to
+const HostPodAuditAnnotation = "vcluster.loft.sh/host-pod"
+
 func WithRedirect(h http.Handler, registerCtx *synccontext.RegisterContext, uncachedVirtualClient client.Client, admit admission.Interface, resources []delegatingauthorizer.GroupVersionResourceVerb) http.Handler {
 	s := serializer.NewCodecFactory(scheme.Scheme)
 	parameterCodec := runtime.NewParameterCodec(uncachedVirtualClient.Scheme())
@@ -58,6 +62,9 @@ func WithRedirect(h http.Handler, registerCtx *synccontext.RegisterContext, unca
 				pName := mappings.VirtualToHost(registerCtx.ToSyncContext("redirect"), splitted[6], info.Namespace, mappings.Pods())
 				splitted[4] = pName.Namespace
 				splitted[6] = pName.Name
+
+				// record the host pod the request is redirected to
+				audit.AddAuditAnnotation(req.Context(), HostPodAuditAnnotation, pName.String())
 				req.URL.Path = strings.Join(splitted, "/")
 
 				// we have to add a trailing slash here, because otherwise the
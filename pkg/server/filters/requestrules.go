@@ -0,0 +1,184 @@
+package filters
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/loft-sh/vcluster/config"
+	servertypes "github.com/loft-sh/vcluster/pkg/server/types"
+	requestpkg "github.com/loft-sh/vcluster/pkg/util/request"
+	"github.com/prometheus/client_golang/prometheus"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/klog/v2"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// defaultDenyRule is the rule name of requests that are denied because they don't match any allow rule
+const defaultDenyRule = "default-deny"
+
+// defaultAllowedPaths are non resource paths that are never denied by default, as health probes and
+// discovery need to work regardless of the configured allow rules
+var defaultAllowedPaths = []string{"/healthz", "/readyz", "/livez", "/version", "/api", "/apis", "/openapi"}
+
+var requestRuleHits = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "vcluster_proxy_request_rule_hits_total",
+	Help: "Number of requests that matched a request rule of the vCluster proxy.",
+}, []string{"rule", "action", "dry_run"})
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(requestRuleHits)
+}
+
+// WithRequestRules denies requests that match a deny rule or, if allow rules are configured, match none of them.
+// In dry run mode, these requests are only logged.
+func WithRequestRules(h http.Handler, requestRules config.ControlPlaneProxyRequestRules) http.Handler {
+	if len(requestRules.Deny) == 0 && len(requestRules.Allow) == 0 {
+		return h
+	}
+
+	dryRun := strconv.FormatBool(requestRules.DryRun)
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		info, ok := request.RequestInfoFrom(req.Context())
+		if !ok {
+			requestpkg.FailWithStatus(w, req, http.StatusInternalServerError, fmt.Errorf("request info is missing"))
+			return
+		}
+
+		userInfo, ok := request.UserFrom(req.Context())
+		if !ok {
+			requestpkg.FailWithStatus(w, req, http.StatusInternalServerError, fmt.Errorf("user info is missing"))
+			return
+		}
+
+		// impersonated requests are matched against the original user for excluded users
+		originalUser, ok := req.Context().Value(servertypes.OriginalUserKey).(user.Info)
+		if !ok {
+			originalUser = userInfo
+		}
+
+		denyRule := ""
+		for _, rule := range requestRules.Deny {
+			if matchesRequestRule(rule, info, userInfo, originalUser) {
+				denyRule = rule.Name
+				break
+			}
+		}
+		if denyRule == "" && len(requestRules.Allow) > 0 && !isDefaultAllowedPath(info) {
+			denyRule = defaultDenyRule
+			for _, rule := range requestRules.Allow {
+				if matchesRequestRule(rule, info, userInfo, originalUser) {
+					requestRuleHits.WithLabelValues(rule.Name, "allow", dryRun).Inc()
+					denyRule = ""
+					break
+				}
+			}
+		}
+		if denyRule == "" {
+			h.ServeHTTP(w, req)
+			return
+		}
+
+		requestRuleHits.WithLabelValues(denyRule, "deny", dryRun).Inc()
+		if requestRules.DryRun {
+			klog.Infof("Dry run: request %s %s of user %s would be denied by rule %q", req.Method, req.URL.Path, userInfo.GetName(), denyRule)
+			h.ServeHTTP(w, req)
+			return
+		}
+
+		requestpkg.FailWithStatus(w, req, http.StatusForbidden, fmt.Errorf("request denied by vCluster proxy rule %q", denyRule))
+	})
+}
+
+// isDefaultAllowedPath returns true for health and discovery requests, e.g. /readyz, /apis or /apis/apps/v1
+func isDefaultAllowedPath(info *request.RequestInfo) bool {
+	if info.IsResourceRequest {
+		return false
+	}
+
+	for _, p := range defaultAllowedPaths {
+		if info.Path == p || strings.HasPrefix(info.Path, p+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchesRequestRule(rule config.DenyRule, info *request.RequestInfo, userInfo, originalUser user.Info) bool {
+	if slices.Contains(rule.ExcludedUsers, originalUser.GetName()) && originalUser.GetName() == userInfo.GetName() {
+		return false
+	} else if len(rule.Users) > 0 && !slices.Contains(rule.Users, userInfo.GetName()) {
+		return false
+	} else if len(rule.Groups) > 0 && !slices.ContainsFunc(rule.Groups, func(group string) bool { return slices.Contains(userInfo.GetGroups(), group) }) {
+		return false
+	}
+
+	if len(rule.Namespaces) > 0 {
+		namespace := info.Namespace
+		if namespace == "" && info.Resource == "namespaces" && info.APIGroup == "" {
+			namespace = info.Name
+		}
+		if !slices.Contains(rule.Namespaces, namespace) {
+			return false
+		}
+	}
+
+	if len(rule.Rules) == 0 {
+		return true
+	} else if !info.IsResourceRequest {
+		return false
+	}
+
+	for _, r := range rule.Rules {
+		if matchesWildcard(r.APIGroups, info.APIGroup) &&
+			matchesWildcard(r.APIVersions, info.APIVersion) &&
+			matchesWildcard(r.Verbs, info.Verb) &&
+			matchesResource(r.Resources, info.Resource, info.Subresource) &&
+			matchesScope(r.Scope, info.Namespace) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchesWildcard returns true if the values are empty, contain a wildcard or the value
+func matchesWildcard(values []string, value string) bool {
+	return len(values) == 0 || slices.Contains(values, "*") || slices.Contains(values, value)
+}
+
+// matchesResource matches resources in the format of admission rules, e.g. pods, pods/exec, pods/* or */*
+func matchesResource(resources []string, resource, subresource string) bool {
+	if len(resources) == 0 {
+		return true
+	}
+
+	for _, r := range resources {
+		name, sub, _ := strings.Cut(r, "/")
+		if (name == "*" || name == resource) && (sub == "*" || sub == subresource) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchesScope(scope *string, namespace string) bool {
+	if scope == nil {
+		return true
+	}
+
+	switch admissionregistrationv1.ScopeType(*scope) {
+	case admissionregistrationv1.ClusterScope:
+		return namespace == ""
+	case admissionregistrationv1.NamespacedScope:
+		return namespace != ""
+	}
+
+	return true
+}
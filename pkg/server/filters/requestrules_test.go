@@ -0,0 +1,155 @@
+package filters
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/loft-sh/vcluster/config"
+	servertypes "github.com/loft-sh/vcluster/pkg/server/types"
+	"gotest.tools/v3/assert"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+)
+
+func TestWithRequestRules(t *testing.T) {
+	requestRules := config.ControlPlaneProxyRequestRules{
+		Deny: []config.DenyRule{{
+			Name:          "no-exec",
+			Namespaces:    []string{"prod"},
+			Rules:         []config.RuleWithVerbs{{APIGroups: []string{""}, Resources: []string{"pods/exec"}, Verbs: []string{"*"}}},
+			ExcludedUsers: []string{"admin"},
+		}},
+		Allow: []config.DenyRule{{
+			Name:   "devs",
+			Groups: []string{"dev"},
+		}, {
+			Name:  "admin",
+			Users: []string{"admin"},
+		}},
+	}
+
+	testCases := []struct {
+		name         string
+		user         string
+		groups       []string
+		originalUser string
+		namespace    string
+		subresource  string
+		dryRun       bool
+		wantCode     int
+	}{
+		{
+			name:     "allowed",
+			user:     "alice",
+			groups:   []string{"dev"},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:        "denied subresource",
+			user:        "alice",
+			groups:      []string{"dev"},
+			namespace:   "prod",
+			subresource: "exec",
+			wantCode:    http.StatusForbidden,
+		},
+		{
+			name:        "other namespace",
+			user:        "alice",
+			groups:      []string{"dev"},
+			namespace:   "dev",
+			subresource: "exec",
+			wantCode:    http.StatusOK,
+		},
+		{
+			name:        "excluded user",
+			user:        "admin",
+			namespace:   "prod",
+			subresource: "exec",
+			wantCode:    http.StatusOK,
+		},
+		{
+			name:         "impersonated excluded user",
+			user:         "admin",
+			originalUser: "alice",
+			namespace:    "prod",
+			subresource:  "exec",
+			wantCode:     http.StatusForbidden,
+		},
+		{
+			name:     "not allowed",
+			user:     "bob",
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "dry run",
+			user:     "bob",
+			dryRun:   true,
+			wantCode: http.StatusOK,
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := requestRules
+			rules.DryRun = tt.dryRun
+			h := WithRequestRules(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}), rules)
+
+			ctx := request.WithUser(context.Background(), &user.DefaultInfo{Name: tt.user, Groups: tt.groups})
+			if tt.originalUser != "" {
+				ctx = context.WithValue(ctx, servertypes.OriginalUserKey, user.Info(&user.DefaultInfo{Name: tt.originalUser}))
+			}
+			ctx = request.WithRequestInfo(ctx, &request.RequestInfo{
+				IsResourceRequest: true,
+				Verb:              "create",
+				APIVersion:        "v1",
+				Namespace:         tt.namespace,
+				Resource:          "pods",
+				Subresource:       tt.subresource,
+				Name:              "pod",
+			})
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/namespaces/"+tt.namespace+"/pods/pod/"+tt.subresource, nil).WithContext(ctx)
+			recorder := httptest.NewRecorder()
+			h.ServeHTTP(recorder, req)
+			assert.Equal(t, recorder.Code, tt.wantCode)
+		})
+	}
+}
+
+func TestWithRequestRulesNonResourceRequests(t *testing.T) {
+	h := WithRequestRules(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}), config.ControlPlaneProxyRequestRules{
+		Allow: []config.DenyRule{{
+			Name:  "pods",
+			Rules: []config.RuleWithVerbs{{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"*"}}},
+		}},
+	})
+
+	testCases := []struct {
+		path     string
+		wantCode int
+	}{
+		{path: "/readyz", wantCode: http.StatusOK},
+		{path: "/livez/ping", wantCode: http.StatusOK},
+		{path: "/apis", wantCode: http.StatusOK},
+		{path: "/apis/apps/v1", wantCode: http.StatusOK},
+		{path: "/metrics", wantCode: http.StatusForbidden},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.path, func(t *testing.T) {
+			ctx := request.WithUser(context.Background(), &user.DefaultInfo{Name: user.Anonymous})
+			ctx = request.WithRequestInfo(ctx, &request.RequestInfo{
+				IsResourceRequest: false,
+				Path:              tt.path,
+				Verb:              "get",
+			})
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil).WithContext(ctx)
+			recorder := httptest.NewRecorder()
+			h.ServeHTTP(recorder, req)
+			assert.Equal(t, recorder.Code, tt.wantCode)
+		})
+	}
+}
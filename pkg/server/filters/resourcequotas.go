@@ -0,0 +1,197 @@
+package filters
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/loft-sh/vcluster/pkg/scheme"
+	"github.com/loft-sh/vcluster/pkg/server/handler"
+	"github.com/loft-sh/vcluster/pkg/syncer/synccontext"
+	requestpkg "github.com/loft-sh/vcluster/pkg/util/request"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+	"k8s.io/apiserver/pkg/endpoints/request"
+)
+
+// WithHostResourceQuotas serves the resource quotas and limit ranges of the host namespace read-only within the configured
+// virtual namespace. The objects are never stored in the virtual cluster, so neither the virtual resource quota controller
+// nor the virtual admission plugins act on them and tenants cannot change them. The returned objects are rewritten into
+// the virtual namespace, while access is authorized against the virtual rbac by the server.
+func WithHostResourceQuotas(h http.Handler, registerCtx *synccontext.RegisterContext) http.Handler {
+	hostHandler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		h, err := handler.Handler("", registerCtx.PhysicalManager.GetConfig(), nil)
+		if err != nil {
+			requestpkg.FailWithStatus(w, req, http.StatusInternalServerError, err)
+			return
+		}
+
+		req.Header.Del("Authorization")
+		h.ServeHTTP(w, req)
+	})
+
+	return withHostResourceQuotas(h, hostHandler, registerCtx.Config.Sync.FromHost.ResourceQuotas.Namespace, registerCtx.Config.WorkloadTargetNamespace)
+}
+
+func withHostResourceQuotas(h, hostHandler http.Handler, virtualNamespace, hostNamespace string) http.Handler {
+	s := serializer.NewCodecFactory(scheme.Scheme)
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		info, ok := request.RequestInfoFrom(req.Context())
+		if !ok {
+			requestpkg.FailWithStatus(w, req, http.StatusInternalServerError, fmt.Errorf("request info is missing"))
+			return
+		}
+
+		if !isHostResourceQuotaRequest(info, virtualNamespace) {
+			h.ServeHTTP(w, req)
+			return
+		}
+
+		groupResource := schema.GroupResource{Resource: info.Resource}
+		if info.Verb != "get" && info.Verb != "list" && info.Verb != "watch" {
+			err := fmt.Errorf("%s in namespace %s are synced read-only from the host cluster", info.Resource, virtualNamespace)
+			responsewriters.ErrorNegotiated(kerrors.NewForbidden(groupResource, info.Name, err), s, corev1.SchemeGroupVersion, w, req)
+			return
+		}
+
+		// exchange the namespace, e.g. /api/v1/namespaces/<namespace>/resourcequotas or /api/v1/watch/namespaces/<namespace>/limitranges
+		splitted := strings.Split(req.URL.Path, "/")
+		for i := 0; i < len(splitted)-1; i++ {
+			if splitted[i] == "namespaces" && splitted[i+1] == virtualNamespace {
+				splitted[i+1] = hostNamespace
+				break
+			}
+		}
+
+		req.URL.Path = strings.Join(splitted, "/")
+
+		// the host objects are returned within the virtual namespace, which we can only rewrite in uncompressed json
+		req.Header.Set("Accept", "application/json")
+		req.Header.Del("Accept-Encoding")
+		if info.Verb == "watch" {
+			serveRewrittenWatch(w, req, hostHandler, virtualNamespace)
+			return
+		}
+
+		serveRewritten(w, req, hostHandler, virtualNamespace)
+	})
+}
+
+// serveRewritten buffers the host response of a get or list request and exchanges the namespace of the returned objects
+func serveRewritten(w http.ResponseWriter, req *http.Request, hostHandler http.Handler, virtualNamespace string) {
+	recorder := httptest.NewRecorder()
+	hostHandler.ServeHTTP(recorder, req)
+
+	body := recorder.Body.Bytes()
+	obj := map[string]interface{}{}
+	if json.Unmarshal(body, &obj) == nil {
+		rewriteNamespace(obj, virtualNamespace)
+		rewritten, err := json.Marshal(obj)
+		if err != nil {
+			requestpkg.FailWithStatus(w, req, http.StatusInternalServerError, err)
+			return
+		}
+
+		body = rewritten
+	}
+
+	for key, values := range recorder.Header() {
+		if key != "Content-Length" {
+			w.Header()[key] = values
+		}
+	}
+	w.WriteHeader(recorder.Code)
+	_, _ = w.Write(body)
+}
+
+// serveRewrittenWatch exchanges the namespace of the objects within the watch events streamed from the host
+func serveRewrittenWatch(w http.ResponseWriter, req *http.Request, hostHandler http.Handler, virtualNamespace string) {
+	reader, writer := io.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		flusher, _ := w.(http.Flusher)
+		decoder := json.NewDecoder(reader)
+		for {
+			event := map[string]interface{}{}
+			err := decoder.Decode(&event)
+			if err != nil {
+				_ = reader.CloseWithError(err)
+				return
+			}
+
+			if obj, ok := event["object"].(map[string]interface{}); ok {
+				rewriteNamespace(obj, virtualNamespace)
+			} else {
+				rewriteNamespace(event, virtualNamespace)
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				_ = reader.CloseWithError(err)
+				return
+			}
+
+			_, err = w.Write(append(data, '\n'))
+			if err != nil {
+				_ = reader.CloseWithError(err)
+				return
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+	}()
+
+	hostHandler.ServeHTTP(&pipeResponseWriter{ResponseWriter: w, writer: writer}, req)
+	_ = writer.Close()
+	<-done
+}
+
+// rewriteNamespace sets the namespace of the given object or the items of the given list
+func rewriteNamespace(obj map[string]interface{}, namespace string) {
+	if items, ok := obj["items"].([]interface{}); ok {
+		for _, item := range items {
+			if itemObj, ok := item.(map[string]interface{}); ok {
+				rewriteNamespace(itemObj, namespace)
+			}
+		}
+
+		return
+	}
+
+	metadata, ok := obj["metadata"].(map[string]interface{})
+	if !ok || obj["kind"] == "Status" {
+		return
+	}
+
+	metadata["namespace"] = namespace
+}
+
+// pipeResponseWriter passes the body of a response through a pipe
+type pipeResponseWriter struct {
+	http.ResponseWriter
+
+	writer *io.PipeWriter
+}
+
+func (p *pipeResponseWriter) Write(data []byte) (int, error) {
+	return p.writer.Write(data)
+}
+
+// Flush is a no-op, as every write is passed through the pipe immediately
+func (p *pipeResponseWriter) Flush() {}
+
+func isHostResourceQuotaRequest(info *request.RequestInfo, virtualNamespace string) bool {
+	return info.IsResourceRequest &&
+		info.APIGroup == corev1.SchemeGroupVersion.Group &&
+		info.APIVersion == corev1.SchemeGroupVersion.Version &&
+		info.Namespace == virtualNamespace &&
+		(info.Resource == "resourcequotas" || info.Resource == "limitranges")
+}
@@ -0,0 +1,115 @@
+package filters
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	"k8s.io/apiserver/pkg/endpoints/request"
+)
+
+func TestWithHostResourceQuotas(t *testing.T) {
+	testCases := []struct {
+		name     string
+		path     string
+		info     request.RequestInfo
+		wantCode int
+		wantPath string
+	}{
+		{
+			name:     "list host resource quotas",
+			path:     "/api/v1/namespaces/vcluster-quotas/resourcequotas",
+			info:     request.RequestInfo{IsResourceRequest: true, APIVersion: "v1", Namespace: "vcluster-quotas", Resource: "resourcequotas", Verb: "list"},
+			wantCode: http.StatusAccepted,
+			wantPath: "/api/v1/namespaces/vcluster-host/resourcequotas",
+		},
+		{
+			name:     "watch host limit ranges",
+			path:     "/api/v1/watch/namespaces/vcluster-quotas/limitranges",
+			info:     request.RequestInfo{IsResourceRequest: true, APIVersion: "v1", Namespace: "vcluster-quotas", Resource: "limitranges", Verb: "watch"},
+			wantCode: http.StatusAccepted,
+			wantPath: "/api/v1/watch/namespaces/vcluster-host/limitranges",
+		},
+		{
+			name:     "update host resource quota",
+			path:     "/api/v1/namespaces/vcluster-quotas/resourcequotas/quota",
+			info:     request.RequestInfo{IsResourceRequest: true, APIVersion: "v1", Namespace: "vcluster-quotas", Resource: "resourcequotas", Name: "quota", Verb: "update"},
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "delete host limit range",
+			path:     "/api/v1/namespaces/vcluster-quotas/limitranges/limits",
+			info:     request.RequestInfo{IsResourceRequest: true, APIVersion: "v1", Namespace: "vcluster-quotas", Resource: "limitranges", Name: "limits", Verb: "delete"},
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "tenant resource quota",
+			path:     "/api/v1/namespaces/default/resourcequotas",
+			info:     request.RequestInfo{IsResourceRequest: true, APIVersion: "v1", Namespace: "default", Resource: "resourcequotas", Verb: "create"},
+			wantCode: http.StatusOK,
+			wantPath: "/api/v1/namespaces/default/resourcequotas",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			path := ""
+			next := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				path = req.URL.Path
+				w.WriteHeader(http.StatusOK)
+			})
+			hostHandler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				path = req.URL.Path
+				w.WriteHeader(http.StatusAccepted)
+			})
+
+			info := testCase.info
+			req := httptest.NewRequest(http.MethodGet, testCase.path, nil)
+			req = req.WithContext(request.WithRequestInfo(req.Context(), &info))
+			recorder := httptest.NewRecorder()
+			withHostResourceQuotas(next, hostHandler, "vcluster-quotas", "vcluster-host").ServeHTTP(recorder, req)
+			assert.Equal(t, recorder.Code, testCase.wantCode)
+			assert.Equal(t, path, testCase.wantPath)
+		})
+	}
+}
+
+func TestWithHostResourceQuotasRewritesNamespace(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	hostHandler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, req.Header.Get("Accept"), "application/json")
+		w.Header().Set("Content-Type", "application/json")
+		if req.URL.Path == "/api/v1/watch/namespaces/vcluster-host/resourcequotas" {
+			_, _ = w.Write([]byte(`{"type":"ADDED","object":{"kind":"ResourceQuota","metadata":{"name":"quota","namespace":"vcluster-host"}}}`))
+			_, _ = w.Write([]byte(`{"type":"ERROR","object":{"kind":"Status","metadata":{},"code":410}}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"kind":"ResourceQuotaList","metadata":{"resourceVersion":"1"},"items":[{"metadata":{"name":"quota","namespace":"vcluster-host"}}]}`))
+	})
+	h := withHostResourceQuotas(next, hostHandler, "vcluster-quotas", "vcluster-host")
+
+	// list
+	info := &request.RequestInfo{IsResourceRequest: true, APIVersion: "v1", Namespace: "vcluster-quotas", Resource: "resourcequotas", Verb: "list"}
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/namespaces/vcluster-quotas/resourcequotas", nil)
+	req.Header.Set("Accept", "application/vnd.kubernetes.protobuf")
+	req = req.WithContext(request.WithRequestInfo(req.Context(), info))
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, req)
+	assert.Equal(t, recorder.Code, http.StatusOK)
+	assert.Equal(t, recorder.Body.String(), `{"items":[{"metadata":{"name":"quota","namespace":"vcluster-quotas"}}],"kind":"ResourceQuotaList","metadata":{"resourceVersion":"1"}}`)
+
+	// watch
+	info = &request.RequestInfo{IsResourceRequest: true, APIVersion: "v1", Namespace: "vcluster-quotas", Resource: "resourcequotas", Verb: "watch"}
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/watch/namespaces/vcluster-quotas/resourcequotas", nil)
+	req = req.WithContext(request.WithRequestInfo(req.Context(), info))
+	recorder = httptest.NewRecorder()
+	h.ServeHTTP(recorder, req)
+	assert.Equal(t, recorder.Code, http.StatusOK)
+	assert.Equal(t, recorder.Body.String(), `{"object":{"kind":"ResourceQuota","metadata":{"name":"quota","namespace":"vcluster-quotas"}},"type":"ADDED"}
+{"object":{"code":410,"kind":"Status","metadata":{}},"type":"ERROR"}
+`)
+}
@@ -6,15 +6,18 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strconv"
 	"time"
 
 	"github.com/loft-sh/vcluster/pkg/authentication/delegatingauthenticator"
+	"github.com/loft-sh/vcluster/pkg/authentication/revocation"
 	"github.com/loft-sh/vcluster/pkg/authorization/allowall"
 	"github.com/loft-sh/vcluster/pkg/authorization/delegatingauthorizer"
 	"github.com/loft-sh/vcluster/pkg/authorization/impersonationauthorizer"
 	"github.com/loft-sh/vcluster/pkg/authorization/kubeletauthorizer"
 	"github.com/loft-sh/vcluster/pkg/plugin"
+	"github.com/loft-sh/vcluster/pkg/server/audit"
 	"github.com/loft-sh/vcluster/pkg/server/cert"
 	"github.com/loft-sh/vcluster/pkg/server/filters"
 	"github.com/loft-sh/vcluster/pkg/server/handler"
@@ -57,12 +60,15 @@ type Server struct {
 	cachedVirtualClient    client.Client
 	currentNamespaceClient client.Client
 	certSyncer             cert.Syncer
+	vClusterName           string
 	handler                *http.ServeMux
 	currentNamespace       string
 	requestHeaderCaFile    string
 	clientCaFile           string
 	redirectResources      []delegatingauthorizer.GroupVersionResourceVerb
+	authorizedResources    []delegatingauthorizer.GroupVersionResourceVerb
 	fakeKubeletIPs         bool
+	auditOptions           *koptions.AuditOptions
 }
 
 // NewServer creates and installs a new Server.
@@ -95,10 +101,16 @@ func NewServer(ctx *synccontext.ControllerContext, requestHeaderCaFile, clientCa
 		return nil, errors.Wrap(err, "create cert syncer")
 	}
 
+	auditOptions, err := audit.NewOptions(ctx.Config.ControlPlane.Proxy.Audit, filepath.Join(os.TempDir(), "vcluster-audit"))
+	if err != nil {
+		return nil, errors.Wrap(err, "create audit options")
+	}
+
 	s := &Server{
 		uncachedVirtualClient: uncachedVirtualClient,
 		cachedVirtualClient:   ctx.VirtualManager.GetClient(),
 		certSyncer:            certSyncer,
+		vClusterName:          ctx.Config.Name,
 		handler:               http.NewServeMux(),
 
 		fakeKubeletIPs: ctx.Config.Networking.Advanced.ProxyKubelets.ByIP,
@@ -108,6 +120,7 @@ func NewServer(ctx *synccontext.ControllerContext, requestHeaderCaFile, clientCa
 
 		requestHeaderCaFile: requestHeaderCaFile,
 		clientCaFile:        clientCaFile,
+		auditOptions:        auditOptions,
 		redirectResources: []delegatingauthorizer.GroupVersionResourceVerb{
 			{
 				GroupVersionResource: corev1.SchemeGroupVersion.WithResource("nodes"),
@@ -137,6 +150,19 @@ func NewServer(ctx *synccontext.ControllerContext, requestHeaderCaFile, clientCa
 		},
 	}
 
+	// host resource quotas and limit ranges are served with the host credentials, so we need to check the
+	// virtual rbac ourselves
+	if ctx.Config.Sync.FromHost.ResourceQuotas.Enabled {
+		for _, resource := range []string{"resourcequotas", "limitranges"} {
+			for _, verb := range []string{"get", "list", "watch"} {
+				s.authorizedResources = append(s.authorizedResources, delegatingauthorizer.GroupVersionResourceVerb{
+					GroupVersionResource: corev1.SchemeGroupVersion.WithResource(resource),
+					Verb:                 verb,
+				})
+			}
+		}
+	}
+
 	// init plugins
 	admissionHandler, err := initAdmission(ctx, virtualConfig)
 	if err != nil {
@@ -153,6 +179,12 @@ func NewServer(ctx *synccontext.ControllerContext, requestHeaderCaFile, clientCa
 	h = filters.WithServiceCreateRedirect(h, registerCtx, uncachedLocalClient, uncachedVirtualClient)
 	h = filters.WithRedirect(h, registerCtx, uncachedVirtualClient, admissionHandler, s.redirectResources)
 	h = filters.WithMetricsProxy(h, registerCtx)
+	if ctx.Config.Sync.FromHost.ResourceQuotas.Enabled {
+		h = filters.WithHostResourceQuotas(h, registerCtx)
+	}
+	if ctx.Config.Sync.ToHost.PodDisruptionBudgets.Enabled {
+		h = filters.WithPodEviction(h, registerCtx, uncachedLocalClient, uncachedVirtualClient, admissionHandler)
+	}
 
 	// inject apis
 	if ctx.Config.Sync.FromHost.Nodes.Enabled && ctx.Config.Sync.FromHost.Nodes.SyncBackChanges {
@@ -160,6 +192,8 @@ func NewServer(ctx *synccontext.ControllerContext, requestHeaderCaFile, clientCa
 	}
 	h = filters.WithFakeKubelet(h, ctx.ToRegisterContext())
 	h = filters.WithK3sConnect(h)
+	h = filters.WithCentralAdmission(h, len(ctx.Config.Policies.CentralAdmission.ValidatingWebhooks) > 0 || len(ctx.Config.Policies.CentralAdmission.MutatingWebhooks) > 0)
+	h = filters.WithRequestRules(h, ctx.Config.ControlPlane.Proxy.RequestRules)
 
 	if os.Getenv("DEBUG") == "true" {
 		h = filters.WithPprof(h)
@@ -193,8 +227,10 @@ func (s *Server) ServeOnListenerTLS(address string, port int, stopChan <-chan st
 			Verb:                 "create",
 			SubResource:          "",
 		},
+		filters.PodEvictionResource,
 	}
 	redirectAuthResources = append(redirectAuthResources, s.redirectResources...)
+	redirectAuthResources = append(redirectAuthResources, s.authorizedResources...)
 	serverConfig.Authorization.Authorizer = union.New(
 		kubeletauthorizer.New(s.uncachedVirtualClient),
 		delegatingauthorizer.New(s.uncachedVirtualClient, redirectAuthResources, nil),
@@ -222,8 +258,23 @@ func (s *Server) ServeOnListenerTLS(address string, port int, stopChan <-chan st
 		return err
 	}
 
-	// make sure the tokens are correctly authenticated
-	serverConfig.Authentication.Authenticator = unionauthentication.NewFailOnError(delegatingauthenticator.New(s.uncachedVirtualClient), serverConfig.Authentication.Authenticator)
+	// make sure the tokens are correctly authenticated, tokens are reviewed by the virtual api server, which also
+	// validates OIDC id tokens if controlPlane.auth.oidc is enabled. Client certificates revoked via
+	// 'vcluster credentials revoke' are denied before any other authenticator is asked.
+	serverConfig.Authentication.Authenticator = unionauthentication.NewFailOnError(revocation.New(s.currentNamespaceClient, s.vClusterName, s.currentNamespace), delegatingauthenticator.New(s.uncachedVirtualClient), serverConfig.Authentication.Authenticator)
+
+	// configure audit logging
+	err = s.auditOptions.ApplyTo(serverConfig)
+	if err != nil {
+		return errors.Wrap(err, "apply audit options")
+	}
+	if serverConfig.AuditBackend != nil {
+		err = serverConfig.AuditBackend.Run(stopChan)
+		if err != nil {
+			return errors.Wrap(err, "run audit backend")
+		}
+		defer serverConfig.AuditBackend.Shutdown()
+	}
 
 	// create server
 	klog.Info("Starting tls proxy server at " + address + ":" + strconv.Itoa(port))
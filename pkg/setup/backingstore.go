@@ -0,0 +1,80 @@
+package setup
+
+import (
+	"context"
+	"fmt"
+
+	vclusterconfig "github.com/loft-sh/vcluster/config"
+	"github.com/loft-sh/vcluster/pkg/certs"
+	"github.com/loft-sh/vcluster/pkg/config"
+	"github.com/loft-sh/vcluster/pkg/constants"
+	"github.com/loft-sh/vcluster/pkg/k8s"
+	"github.com/loft-sh/vcluster/pkg/pro"
+	"k8s.io/client-go/kubernetes"
+)
+
+// StartBackingStore starts only the backing store of a stopped virtual cluster without its control plane, so that
+// it can be modified without the api server or syncer reading from or writing to it, e.g. to restore a snapshot.
+func StartBackingStore(ctx context.Context, kubeClient kubernetes.Interface, vConfig *config.VirtualClusterConfig) error {
+	backingStore := vConfig.ControlPlane.BackingStore
+	if backingStore.Etcd.Deploy.Enabled || backingStore.Etcd.Embedded.Enabled {
+		certificatesDir := "/data/pki"
+		if vConfig.Distro() == vclusterconfig.K0SDistro {
+			certificatesDir = "/data/k0s/pki"
+		}
+
+		// we need the etcd client certificates to connect to etcd
+		err := certs.DownloadCerts(ctx, kubeClient, vConfig.ControlPlaneNamespace, vConfig.Name, certificatesDir)
+		if err != nil {
+			return err
+		} else if backingStore.Etcd.Deploy.Enabled {
+			// the deployed etcd keeps running while the virtual cluster is stopped
+			return nil
+		}
+
+		// a single member can't be restored without the other members of the etcd cluster
+		if vConfig.ControlPlane.StatefulSet.HighAvailability.Replicas > 1 {
+			return fmt.Errorf("starting embedded etcd with %d replicas without the control plane is not supported", vConfig.ControlPlane.StatefulSet.HighAvailability.Replicas)
+		}
+
+		err = pro.StartEmbeddedEtcd(ctx, vConfig.Name, vConfig.ControlPlaneNamespace, certificatesDir, 1, "")
+		if err != nil {
+			return fmt.Errorf("start embedded etcd: %w", err)
+		}
+
+		return nil
+	}
+
+	// all other backing stores are served by kine
+	database := backingStore.Database.Embedded
+	if backingStore.Database.External.Enabled {
+		database = backingStore.Database.External
+	}
+
+	dataSource := database.DataSource
+	switch vConfig.Distro() {
+	case vclusterconfig.K8SDistro:
+		if dataSource == "" {
+			dataSource = "sqlite:///data/state.db?_journal=WAL&cache=shared&_busy_timeout=30000"
+		}
+
+		k8s.StartKine(ctx, dataSource, constants.K8sKineEndpoint, database)
+	case vclusterconfig.K3SDistro:
+		if dataSource == "" {
+			dataSource = "sqlite:///data/server/db/state.db?_journal=WAL&cache=shared&_busy_timeout=30000"
+		}
+
+		k8s.StartKine(ctx, dataSource, constants.K3sKineEndpoint, database)
+	case vclusterconfig.K0SDistro:
+		// without a data source k0s uses its own etcd
+		if dataSource == "" {
+			return fmt.Errorf("starting the k0s backing store without the control plane is only supported with a database data source")
+		}
+
+		k8s.StartKine(ctx, dataSource, constants.K0sKineEndpoint, database)
+	default:
+		return fmt.Errorf("unsupported distro %s", vConfig.Distro())
+	}
+
+	return nil
+}
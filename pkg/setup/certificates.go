@@ -0,0 +1,142 @@
+package setup
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	vclusterconfig "github.com/loft-sh/vcluster/config"
+	"github.com/loft-sh/vcluster/pkg/certs"
+	"github.com/loft-sh/vcluster/pkg/config"
+	"github.com/loft-sh/vcluster/pkg/credentials"
+	"github.com/loft-sh/vcluster/pkg/lifecycle"
+	"github.com/loft-sh/vcluster/pkg/server/cert"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RenewCertificates renews the certificates within the certs secret before they expire and optionally rotates the cluster CA.
+// As the control plane components only read their certificates on startup, the vCluster pods are restarted one by one
+// afterward, which also updates the kube config secret.
+func RenewCertificates(ctx context.Context, client kubernetes.Interface, name, namespace string, renewal vclusterconfig.ControlPlaneCertificatesRenewal) error {
+	renewBefore, err := time.ParseDuration(renewal.RenewBefore)
+	if err != nil {
+		return fmt.Errorf("parse renew before: %w", err)
+	}
+
+	caTrustPeriod := time.Duration(0)
+	if renewal.CATrustPeriod != "" {
+		caTrustPeriod, err = time.ParseDuration(renewal.CATrustPeriod)
+		if err != nil {
+			return fmt.Errorf("parse ca trust period: %w", err)
+		}
+	}
+
+	changed := []string{}
+	err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		secret, err := client.CoreV1().Secrets(namespace).Get(ctx, name+"-certs", metav1.GetOptions{})
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				return nil
+			}
+
+			return err
+		}
+
+		// check if the cluster ca expires as well
+		certificates, err := certs.ListCertificates(secret.Data)
+		if err != nil {
+			return err
+		}
+		rotateCA := false
+		for _, certificate := range certificates {
+			if certificate.Name == certs.CACertName && !certificate.Previous && time.Until(certificate.NotAfter) < renewBefore {
+				if len(secret.Data[certs.NextCAKeyName]) > 0 {
+					// the next CA replaces the current one once the trust period has passed
+					break
+				} else if len(secret.Data[certs.ClientCACertName]) > 0 {
+					klog.Warningf("Externally managed certificate authority %s expires at %s, please rotate it within the referenced CA secret", certificate.Name, certificate.NotAfter.Format(time.RFC3339))
+					break
+				} else if !renewal.RotateCA {
+					klog.Warningf("Certificate authority %s expires at %s, please rotate it via 'vcluster certs rotate --ca'", certificate.Name, certificate.NotAfter.Format(time.RFC3339))
+					break
+				}
+
+				rotateCA = true
+			}
+		}
+
+		changed, err = certs.RenewCertsSecret(secret, certs.RenewOptions{RenewBefore: renewBefore, RotateCA: rotateCA}, caTrustPeriod)
+		if err != nil {
+			return err
+		} else if len(changed) == 0 {
+			return nil
+		}
+
+		_, err = client.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("renew certificates: %w", err)
+	} else if len(changed) == 0 {
+		return nil
+	}
+
+	klog.Infof("Renewed certificates %s, restarting vCluster pods one by one", strings.Join(changed, ", "))
+	return lifecycle.RolloutRestart(ctx, client, name, namespace)
+}
+
+// EnsureCertManagerRootCA creates the cert-manager Certificate for the virtual api server with the SANs that are known
+// before the syncer starts and writes the root CA bundle for pods. The syncer adds the remaining SANs afterward.
+func EnsureCertManagerRootCA(ctx context.Context, certificatesDir string, options *config.VirtualClusterConfig) error {
+	workloadClient, err := client.New(options.WorkloadConfig, client.Options{})
+	if err != nil {
+		return fmt.Errorf("create workload client: %w", err)
+	}
+
+	svc, err := options.WorkloadClient.CoreV1().Services(options.WorkloadNamespace).Get(ctx, options.WorkloadService, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("get vcluster service %s/%s: %w", options.WorkloadNamespace, options.WorkloadService, err)
+	}
+
+	sans := append(cert.DefaultSANs(options.Networking.Advanced.ClusterDomain), svc.Name, svc.Name+"."+svc.Namespace)
+	if svc.Spec.ClusterIP != "" {
+		sans = append(sans, svc.Spec.ClusterIP)
+	}
+
+	return certs.EnsureCertManagerRootCA(ctx, workloadClient, options.Name, options.WorkloadNamespace, certificatesDir, options.ControlPlane.Certificates.CertManager, sans)
+}
+
+// RecordAdminCredential tracks the client certificate of the admin kube config in the credentials secret
+func RecordAdminCredential(ctx context.Context, client kubernetes.Interface, name, namespace, certificatesDir string) error {
+	adminConf, err := clientcmd.LoadFromFile(filepath.Join(certificatesDir, certs.AdminKubeConfigFileName))
+	if err != nil {
+		return fmt.Errorf("load %s: %w", certs.AdminKubeConfigFileName, err)
+	}
+
+	for _, authInfo := range adminConf.AuthInfos {
+		if len(authInfo.ClientCertificateData) == 0 {
+			continue
+		}
+
+		clientCerts, err := certutil.ParseCertsPEM(authInfo.ClientCertificateData)
+		if err != nil {
+			return fmt.Errorf("parse admin client certificate: %w", err)
+		}
+
+		err = credentials.Record(ctx, client, name, namespace, clientCerts[0], nil)
+		if err != nil {
+			return fmt.Errorf("record admin client certificate: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -10,15 +10,28 @@ import (
 	"github.com/loft-sh/vcluster/pkg/k3s"
 	"github.com/loft-sh/vcluster/pkg/util/translate"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 )
 
 const (
 	AnnotationDistro = "vcluster.loft.sh/distro"
 	AnnotationStore  = "vcluster.loft.sh/store"
+
+	// AnnotationSingleNamespaceTarget holds the host namespace all workloads were synced to, while the vCluster was running in
+	// single namespace mode. It is used to migrate existing workloads after multi namespace mode was enabled.
+	AnnotationSingleNamespaceTarget = "vcluster.loft.sh/single-namespace-target"
+
+	// AnnotationNamingStrategy holds the naming strategy that was used for host objects, as host objects
+	// would not be found anymore if the strategy changes
+	AnnotationNamingStrategy = "vcluster.loft.sh/naming-strategy"
 )
 
 func InitAndValidateConfig(ctx context.Context, vConfig *config.VirtualClusterConfig) error {
@@ -46,8 +59,11 @@ func InitAndValidateConfig(ctx context.Context, vConfig *config.VirtualClusterCo
 	}
 
 	// get workload target namespace
-	if vConfig.Experimental.MultiNamespaceMode.Enabled {
-		translate.Default = translate.NewMultiNamespaceTranslator(vConfig.WorkloadNamespace)
+	if vConfig.Sync.ToHost.Namespaces.Enabled {
+		translate.Default, err = translate.NewMultiNamespaceTranslator(vConfig.WorkloadNamespace, vConfig.Sync.ToHost.Namespaces.HostNameTemplate)
+		if err != nil {
+			return fmt.Errorf("validate sync.toHost.namespaces.hostNameTemplate: %w", err)
+		}
 	} else {
 		// ensure target namespace
 		vConfig.WorkloadTargetNamespace = vConfig.Experimental.SyncSettings.TargetNamespace
@@ -55,7 +71,37 @@ func InitAndValidateConfig(ctx context.Context, vConfig *config.VirtualClusterCo
 			vConfig.WorkloadTargetNamespace = vConfig.WorkloadNamespace
 		}
 
-		translate.Default = translate.NewSingleNamespaceTranslator(vConfig.WorkloadTargetNamespace)
+		namingStrategy, err := translate.NewNamingStrategy(vConfig.Sync.ToHost.Naming.Strategy, vConfig.Sync.ToHost.Naming.Template)
+		if err != nil {
+			return fmt.Errorf("validate sync.toHost.naming: %w", err)
+		}
+
+		translate.Default = translate.NewSingleNamespaceTranslatorWithNamingStrategy(vConfig.WorkloadTargetNamespace, namingStrategy)
+	}
+
+	if err := EnsureNamespaceModeChanges(
+		ctx,
+		vConfig.ControlPlaneClient,
+		vConfig.WorkloadClient,
+		vConfig.Name,
+		vConfig.ControlPlaneNamespace,
+		vConfig.WorkloadTargetNamespace,
+		vConfig.Sync.ToHost,
+	); err != nil {
+		return err
+	}
+
+	if !vConfig.Sync.ToHost.Namespaces.Enabled {
+		if err := EnsureNamingStrategyChanges(
+			ctx,
+			vConfig.ControlPlaneClient,
+			vConfig.Name,
+			vConfig.ControlPlaneNamespace,
+			vConfig.Sync.ToHost.Naming,
+			vConfig.Experimental.SyncSettings.DisableSyncStatus,
+		); err != nil {
+			return err
+		}
 	}
 
 	if err := EnsureBackingStoreChanges(
@@ -106,6 +152,227 @@ func EnsureBackingStoreChanges(ctx context.Context, client kubernetes.Interface,
 	return nil
 }
 
+// EnsureNamespaceModeChanges remembers the target namespace of vClusters in single namespace mode and migrates their workloads once
+// multi namespace mode is enabled. Synced workloads are deleted from the previous target namespace, so that the syncer recreates them
+// within their new host namespaces. Volumes of synced persistent volume claims are retained and bound to the recreated claims.
+func EnsureNamespaceModeChanges(ctx context.Context, client, workloadClient kubernetes.Interface, name, namespace, targetNamespace string, syncToHost vclusterconfig.SyncToHost) error {
+	secret, err := client.CoreV1().Secrets(namespace).Get(ctx, "vc-config-"+name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("get secret: %w", err)
+	}
+
+	previousTargetNamespace := secret.Annotations[AnnotationSingleNamespaceTarget]
+	if !syncToHost.Namespaces.Enabled {
+		if previousTargetNamespace == targetNamespace {
+			return nil
+		}
+
+		return updateSecretAnnotation(ctx, client, name, namespace, AnnotationSingleNamespaceTarget, targetNamespace)
+	} else if previousTargetNamespace == "" {
+		return nil
+	}
+
+	klog.Infof("Multi namespace mode was enabled, migrate synced workloads from namespace %s", previousTargetNamespace)
+	err = migrateSingleNamespaceWorkloads(ctx, workloadClient, previousTargetNamespace, syncToHost)
+	if err != nil {
+		return fmt.Errorf("migrate workloads from namespace %s: %w", previousTargetNamespace, err)
+	}
+
+	return updateSecretAnnotation(ctx, client, name, namespace, AnnotationSingleNamespaceTarget, "")
+}
+
+// EnsureNamingStrategyChanges records the naming strategy for host objects. Host objects that were synced before keep
+// their name when the strategy is changed, as the syncer looks them up by the host name recorded in the sync status of
+// the virtual object. Changes are refused if the sync status is disabled or if the vCluster was started before host names
+// were recorded.
+func EnsureNamingStrategyChanges(ctx context.Context, client kubernetes.Interface, name, namespace string, naming vclusterconfig.SyncToHostNaming, disableSyncStatus bool) error {
+	secret, err := client.CoreV1().Secrets(namespace).Get(ctx, "vc-config-"+name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("get secret: %w", err)
+	}
+
+	namingStrategy := namingStrategyAnnotationValue(naming)
+	previousNamingStrategy, ok := secret.Annotations[AnnotationNamingStrategy]
+	if !ok {
+		// vClusters that were started before always used the default naming strategy
+		_, existed := secret.Annotations[AnnotationDistro]
+		if existed && namingStrategy != translate.NamingStrategyDefault {
+			return fmt.Errorf("seems like you were using naming strategy %s before and now have switched to %s, please start vCluster once with the previous naming strategy before switching, so that the host names of existing objects are recorded", translate.NamingStrategyDefault, namingStrategy)
+		}
+
+		return updateSecretAnnotation(ctx, client, name, namespace, AnnotationNamingStrategy, namingStrategy)
+	} else if previousNamingStrategy == namingStrategy {
+		return nil
+	} else if disableSyncStatus {
+		return fmt.Errorf("seems like you were using naming strategy %s before and now have switched to %s, which requires the sync status to keep the names of existing host objects, please do not disable experimental.syncSettings.disableSyncStatus", previousNamingStrategy, namingStrategy)
+	}
+
+	klog.Infof("Naming strategy changed from %s to %s, existing host objects keep their names", previousNamingStrategy, namingStrategy)
+	return updateSecretAnnotation(ctx, client, name, namespace, AnnotationNamingStrategy, namingStrategy)
+}
+
+func namingStrategyAnnotationValue(naming vclusterconfig.SyncToHostNaming) string {
+	switch naming.Strategy {
+	case "", translate.NamingStrategyDefault:
+		return translate.NamingStrategyDefault
+	case translate.NamingStrategyTemplate:
+		return naming.Strategy + "(" + naming.Template + ")"
+	}
+
+	return naming.Strategy
+}
+
+type syncedResource struct {
+	enabled bool
+	list    func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error)
+	delete  func(ctx context.Context, name string, opts metav1.DeleteOptions) error
+}
+
+func migrateSingleNamespaceWorkloads(ctx context.Context, workloadClient kubernetes.Interface, targetNamespace string, syncToHost vclusterconfig.SyncToHost) error {
+	listOptions := metav1.ListOptions{LabelSelector: translate.MarkerLabel + "=" + translate.VClusterName}
+
+	// persistent volume claims cannot be moved between namespaces, so their volumes are handed over to the claims the
+	// syncer creates within the new host namespaces
+	if syncToHost.PersistentVolumeClaims.Enabled {
+		err := migrateSingleNamespaceVolumes(ctx, workloadClient, targetNamespace, listOptions)
+		if err != nil {
+			return err
+		}
+	}
+
+	coreV1 := workloadClient.CoreV1()
+	resources := map[string]syncedResource{
+		"pods": {enabled: true, delete: coreV1.Pods(targetNamespace).Delete, list: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return coreV1.Pods(targetNamespace).List(ctx, opts)
+		}},
+		"services": {enabled: true, delete: coreV1.Services(targetNamespace).Delete, list: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return coreV1.Services(targetNamespace).List(ctx, opts)
+		}},
+		"endpoints": {enabled: true, delete: coreV1.Endpoints(targetNamespace).Delete, list: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return coreV1.Endpoints(targetNamespace).List(ctx, opts)
+		}},
+		"configmaps": {enabled: true, delete: coreV1.ConfigMaps(targetNamespace).Delete, list: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return coreV1.ConfigMaps(targetNamespace).List(ctx, opts)
+		}},
+		"secrets": {enabled: true, delete: coreV1.Secrets(targetNamespace).Delete, list: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return coreV1.Secrets(targetNamespace).List(ctx, opts)
+		}},
+		"persistentvolumeclaims": {enabled: syncToHost.PersistentVolumeClaims.Enabled, delete: coreV1.PersistentVolumeClaims(targetNamespace).Delete, list: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return coreV1.PersistentVolumeClaims(targetNamespace).List(ctx, opts)
+		}},
+		"serviceaccounts": {enabled: syncToHost.ServiceAccounts.Enabled, delete: coreV1.ServiceAccounts(targetNamespace).Delete, list: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return coreV1.ServiceAccounts(targetNamespace).List(ctx, opts)
+		}},
+		"ingresses": {enabled: syncToHost.Ingresses.Enabled, delete: workloadClient.NetworkingV1().Ingresses(targetNamespace).Delete, list: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return workloadClient.NetworkingV1().Ingresses(targetNamespace).List(ctx, opts)
+		}},
+		"networkpolicies": {enabled: syncToHost.NetworkPolicies.Enabled, delete: workloadClient.NetworkingV1().NetworkPolicies(targetNamespace).Delete, list: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return workloadClient.NetworkingV1().NetworkPolicies(targetNamespace).List(ctx, opts)
+		}},
+		"poddisruptionbudgets": {enabled: syncToHost.PodDisruptionBudgets.Enabled, delete: workloadClient.PolicyV1().PodDisruptionBudgets(targetNamespace).Delete, list: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return workloadClient.PolicyV1().PodDisruptionBudgets(targetNamespace).List(ctx, opts)
+		}},
+		"jobs": {enabled: syncToHost.Jobs.Enabled, delete: workloadClient.BatchV1().Jobs(targetNamespace).Delete, list: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			return workloadClient.BatchV1().Jobs(targetNamespace).List(ctx, opts)
+		}},
+	}
+	for resource, syncedResource := range resources {
+		if !syncedResource.enabled {
+			continue
+		}
+
+		list, err := syncedResource.list(ctx, listOptions)
+		if err != nil {
+			return fmt.Errorf("list %s: %w", resource, err)
+		}
+
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return fmt.Errorf("extract %s: %w", resource, err)
+		}
+		for _, item := range items {
+			accessor, err := meta.Accessor(item)
+			if err != nil {
+				return err
+			}
+
+			err = syncedResource.delete(ctx, accessor.GetName(), metav1.DeleteOptions{PropagationPolicy: ptr.To(metav1.DeletePropagationBackground)})
+			if err != nil && !kerrors.IsNotFound(err) {
+				return fmt.Errorf("delete %s %s: %w", resource, accessor.GetName(), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// migrateSingleNamespaceVolumes retains the volumes of synced persistent volume claims and pre-binds them to the
+// claims the syncer creates within the new host namespaces, so that no data is lost when the old claims are deleted.
+func migrateSingleNamespaceVolumes(ctx context.Context, workloadClient kubernetes.Interface, targetNamespace string, listOptions metav1.ListOptions) error {
+	pvcs, err := workloadClient.CoreV1().PersistentVolumeClaims(targetNamespace).List(ctx, listOptions)
+	if err != nil {
+		return fmt.Errorf("list persistent volume claims: %w", err)
+	}
+
+	for _, pvc := range pvcs.Items {
+		vName, vNamespace := pvc.Annotations[translate.NameAnnotation], pvc.Annotations[translate.NamespaceAnnotation]
+		if pvc.Spec.VolumeName == "" || vName == "" || vNamespace == "" {
+			continue
+		}
+
+		err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+			pv, err := workloadClient.CoreV1().PersistentVolumes().Get(ctx, pvc.Spec.VolumeName, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+
+			if pv.Spec.PersistentVolumeReclaimPolicy != corev1.PersistentVolumeReclaimRetain {
+				klog.Infof("Change reclaim policy of persistent volume %s from %s to %s to keep its data during the migration", pv.Name, pv.Spec.PersistentVolumeReclaimPolicy, corev1.PersistentVolumeReclaimRetain)
+				pv.Spec.PersistentVolumeReclaimPolicy = corev1.PersistentVolumeReclaimRetain
+			}
+			pv.Spec.ClaimRef = &corev1.ObjectReference{
+				Kind:       "PersistentVolumeClaim",
+				APIVersion: "v1",
+				Namespace:  translate.Default.HostNamespace(vNamespace),
+				Name:       translate.Default.HostName(vName, vNamespace),
+			}
+
+			_, err = workloadClient.CoreV1().PersistentVolumes().Update(ctx, pv, metav1.UpdateOptions{})
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("migrate persistent volume %s of claim %s: %w", pvc.Spec.VolumeName, pvc.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// updateSecretAnnotation sets or removes a single annotation on the vCluster's config secret.
+func updateSecretAnnotation(ctx context.Context, client kubernetes.Interface, name, namespace, key, value string) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		secret, err := client.CoreV1().Secrets(namespace).Get(ctx, "vc-config-"+name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("get secret: %w", err)
+		}
+
+		if secret.Annotations == nil {
+			secret.Annotations = map[string]string{}
+		}
+		if value == "" {
+			delete(secret.Annotations, key)
+		} else {
+			secret.Annotations[key] = value
+		}
+
+		if _, err := client.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("update secret: %w", err)
+		}
+
+		return nil
+	})
+}
+
 // CheckUsingHeuristic checks for known file path indicating the existence of a previous distro.
 //
 // It checks for the existence of the default K3s token path or the K0s data directory.
@@ -200,7 +467,7 @@ func SetGlobalOwner(ctx context.Context, vConfig *config.VirtualClusterConfig) e
 		return nil
 	}
 
-	if vConfig.Experimental.MultiNamespaceMode.Enabled {
+	if vConfig.Sync.ToHost.Namespaces.Enabled {
 		klog.Warningf("Skip setting owner, because multi namespace mode is enabled")
 		return nil
 	}
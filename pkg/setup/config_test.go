@@ -0,0 +1,95 @@
+package setup
+
+import (
+	"context"
+	"testing"
+
+	vclusterconfig "github.com/loft-sh/vcluster/config"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestMigrateSingleNamespaceWorkloads(t *testing.T) {
+	ctx := context.Background()
+	translate.VClusterName = "my-vcluster"
+	defaultTranslator := translate.Default
+	defer func() { translate.Default = defaultTranslator }()
+	var err error
+	translate.Default, err = translate.NewMultiNamespaceTranslator("vcluster", "{{.vcluster}}-{{.namespace}}")
+	assert.NilError(t, err)
+
+	synced := func(name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "vcluster",
+			Labels:      map[string]string{translate.MarkerLabel: translate.VClusterName},
+			Annotations: map[string]string{translate.NameAnnotation: "data", translate.NamespaceAnnotation: "default"},
+		}
+	}
+	workloadClient := fake.NewSimpleClientset(
+		&corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: "pv-data"},
+			Spec: corev1.PersistentVolumeSpec{
+				PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimDelete,
+				ClaimRef:                      &corev1.ObjectReference{Namespace: "vcluster", Name: "data-x-default-x-my-vcluster", UID: "1234"},
+			},
+		},
+		&corev1.PersistentVolumeClaim{ObjectMeta: synced("data-x-default-x-my-vcluster"), Spec: corev1.PersistentVolumeClaimSpec{VolumeName: "pv-data"}},
+		&networkingv1.Ingress{ObjectMeta: synced("ingress-x-default-x-my-vcluster")},
+		&corev1.Service{ObjectMeta: synced("service-x-default-x-my-vcluster")},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "not-synced", Namespace: "vcluster"}},
+	)
+
+	syncToHost := vclusterconfig.SyncToHost{}
+	syncToHost.PersistentVolumeClaims.Enabled = true
+	syncToHost.Ingresses.Enabled = true
+	assert.NilError(t, migrateSingleNamespaceWorkloads(ctx, workloadClient, "vcluster", syncToHost))
+
+	// the volume is kept and bound to the claim within the new host namespace
+	pv, err := workloadClient.CoreV1().PersistentVolumes().Get(ctx, "pv-data", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, pv.Spec.PersistentVolumeReclaimPolicy, corev1.PersistentVolumeReclaimRetain)
+	assert.DeepEqual(t, pv.Spec.ClaimRef, &corev1.ObjectReference{Kind: "PersistentVolumeClaim", APIVersion: "v1", Namespace: "my-vcluster-default", Name: "data"})
+
+	// synced objects are removed, others are kept
+	_, err = workloadClient.CoreV1().PersistentVolumeClaims("vcluster").Get(ctx, "data-x-default-x-my-vcluster", metav1.GetOptions{})
+	assert.Assert(t, kerrors.IsNotFound(err))
+	_, err = workloadClient.NetworkingV1().Ingresses("vcluster").Get(ctx, "ingress-x-default-x-my-vcluster", metav1.GetOptions{})
+	assert.Assert(t, kerrors.IsNotFound(err))
+	_, err = workloadClient.CoreV1().Services("vcluster").Get(ctx, "service-x-default-x-my-vcluster", metav1.GetOptions{})
+	assert.Assert(t, kerrors.IsNotFound(err))
+	_, err = workloadClient.CoreV1().ConfigMaps("vcluster").Get(ctx, "not-synced", metav1.GetOptions{})
+	assert.NilError(t, err)
+}
+
+func TestEnsureNamingStrategyChanges(t *testing.T) {
+	ctx := context.Background()
+	secret := func(annotations map[string]string) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "vc-config-my-vcluster", Namespace: "vcluster", Annotations: annotations}}
+	}
+	hash := vclusterconfig.SyncToHostNaming{Strategy: translate.NamingStrategyHash}
+
+	// existing host objects keep their names, so the strategy can be changed
+	client := fake.NewSimpleClientset(secret(map[string]string{AnnotationDistro: "k8s", AnnotationNamingStrategy: translate.NamingStrategyDefault}))
+	assert.NilError(t, EnsureNamingStrategyChanges(ctx, client, "my-vcluster", "vcluster", hash, false))
+	updated, err := client.CoreV1().Secrets("vcluster").Get(ctx, "vc-config-my-vcluster", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, updated.Annotations[AnnotationNamingStrategy], translate.NamingStrategyHash)
+
+	// host names are only recorded within the sync status
+	client = fake.NewSimpleClientset(secret(map[string]string{AnnotationDistro: "k8s", AnnotationNamingStrategy: translate.NamingStrategyDefault}))
+	assert.ErrorContains(t, EnsureNamingStrategyChanges(ctx, client, "my-vcluster", "vcluster", hash, true), "disableSyncStatus")
+
+	// vClusters that have not recorded host names yet need to be started once with the default strategy
+	client = fake.NewSimpleClientset(secret(map[string]string{AnnotationDistro: "k8s"}))
+	assert.ErrorContains(t, EnsureNamingStrategyChanges(ctx, client, "my-vcluster", "vcluster", hash, false), "start vCluster once")
+
+	// new vClusters can choose any strategy
+	client = fake.NewSimpleClientset(secret(nil))
+	assert.NilError(t, EnsureNamingStrategyChanges(ctx, client, "my-vcluster", "vcluster", hash, false))
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"time"
 
+	"github.com/loft-sh/vcluster/pkg/certs"
 	"github.com/loft-sh/vcluster/pkg/config"
 	"github.com/loft-sh/vcluster/pkg/controllers/resources/nodes"
 	"github.com/loft-sh/vcluster/pkg/mappings"
@@ -17,6 +19,7 @@ import (
 	"github.com/loft-sh/vcluster/pkg/util/blockingcacheclient"
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/kubernetes"
@@ -28,6 +31,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
 // NewLocalManager is used to create a new local manager
@@ -106,7 +110,7 @@ func NewControllerContext(ctx context.Context, options *config.VirtualClusterCon
 func getLocalCacheOptions(options *config.VirtualClusterConfig) cache.Options {
 	// is multi namespace mode?
 	defaultNamespaces := make(map[string]cache.Config)
-	if !options.Experimental.MultiNamespaceMode.Enabled {
+	if !options.Sync.ToHost.Namespaces.Enabled {
 		defaultNamespaces[options.WorkloadTargetNamespace] = cache.Config{}
 	}
 	// do we need access to another namespace to export the kubeconfig ?
@@ -116,10 +120,28 @@ func getLocalCacheOptions(options *config.VirtualClusterConfig) cache.Options {
 		defaultNamespaces[options.ExportKubeConfig.Secret.Namespace] = cache.Config{}
 	}
 
+	// gateways are shared host objects that usually live in other namespaces
+	// than the vCluster, so we need to watch them in the configured namespaces
+	var byObject map[client.Object]cache.ByObject
+	if options.Sync.FromHost.Gateways.Enabled {
+		gatewayNamespaces := make(map[string]cache.Config, len(options.Sync.FromHost.Gateways.Namespaces))
+		for _, namespace := range options.Sync.FromHost.Gateways.Namespaces {
+			gatewayNamespaces[namespace] = cache.Config{}
+		}
+
+		byObject = map[client.Object]cache.ByObject{
+			&gatewayv1.Gateway{}: {
+				Namespaces: gatewayNamespaces,
+				Label:      labels.SelectorFromSet(options.Sync.FromHost.Gateways.Selector),
+			},
+		}
+	}
+
 	if len(defaultNamespaces) == 0 {
-		return cache.Options{DefaultNamespaces: nil}
+		return cache.Options{DefaultNamespaces: nil, ByObject: byObject}
 	}
-	return cache.Options{DefaultNamespaces: defaultNamespaces}
+
+	return cache.Options{DefaultNamespaces: defaultNamespaces, ByObject: byObject}
 }
 
 func startPlugins(ctx context.Context, virtualConfig *rest.Config, virtualRawConfig *clientcmdapi.Config, options *config.VirtualClusterConfig) error {
@@ -237,6 +259,16 @@ func CreateVClusterKubeConfig(config *clientcmdapi.Config, options *config.Virtu
 			cluster.CertificateAuthorityData = o
 		}
 
+		// the serving certificate is issued by cert-manager, so trust its CA as well
+		if options.ControlPlane.Certificates.CertManager.Enabled {
+			o, err := os.ReadFile(filepath.Join(filepath.Dir(options.VirtualClusterKubeConfig().ServerCACert), certs.RootCACertName))
+			if err != nil {
+				return nil, err
+			}
+
+			cluster.CertificateAuthorityData = o
+		}
+
 		if options.ExportKubeConfig.Server != "" {
 			cluster.Server = options.ExportKubeConfig.Server
 		} else {
@@ -351,7 +383,7 @@ func newCurrentNamespaceClient(ctx context.Context, localManager ctrl.Manager, o
 	// as the regular cache is scoped to the options.TargetNamespace and cannot return
 	// objects from the current namespace.
 	currentNamespaceCache := localManager.GetCache()
-	if !options.Experimental.MultiNamespaceMode.Enabled && options.WorkloadNamespace != options.WorkloadTargetNamespace {
+	if !options.Sync.ToHost.Namespaces.Enabled && options.WorkloadNamespace != options.WorkloadTargetNamespace {
 		currentNamespaceCache, err = cache.New(localManager.GetConfig(), cache.Options{
 			Scheme:            localManager.GetScheme(),
 			Mapper:            localManager.GetRESTMapper(),
@@ -127,6 +127,30 @@ func StartControllers(controllerContext *synccontext.ControllerContext, syncers
 		}
 	}
 
+	// rewrite encrypted resources once the encryption config has changed
+	if controllerContext.Config.ControlPlane.Encryption.Enabled {
+		go func() {
+			wait.Until(func() {
+				err := MigrateEncryptedResources(controllerContext.Context, controllerContext.Config.ControlPlaneClient, controllerContext.VirtualManager.GetClient(), controllerContext.VirtualManager.GetRESTMapper(), controllerContext.Config.Name, controllerContext.Config.ControlPlaneNamespace)
+				if err != nil {
+					klog.Errorf("Error rewriting encrypted resources: %v", err)
+				}
+			}, time.Minute, controllerContext.StopChan)
+		}()
+	}
+
+	// renew certificates before they expire
+	if controllerContext.Config.ControlPlane.Certificates.Renewal.Enabled {
+		go func() {
+			wait.Until(func() {
+				err := RenewCertificates(controllerContext.Context, controllerContext.Config.ControlPlaneClient, controllerContext.Config.Name, controllerContext.Config.ControlPlaneNamespace, controllerContext.Config.ControlPlane.Certificates.Renewal)
+				if err != nil {
+					klog.Errorf("Error renewing certificates: %v", err)
+				}
+			}, time.Hour, controllerContext.StopChan)
+		}()
+	}
+
 	// write the kube config to secret
 	go func() {
 		wait.Until(func() {
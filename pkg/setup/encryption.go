@@ -0,0 +1,108 @@
+package setup
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/loft-sh/vcluster/pkg/certs"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// MigrateEncryptedResources rewrites all resources covered by the encryption config after it has changed, so that they are
+// stored with the current encryption provider and key. The hash of the config is remembered on the certs secret, which allows
+// dropping old keys on the next start.
+func MigrateEncryptedResources(ctx context.Context, controlPlaneClient kubernetes.Interface, virtualClient client.Client, restMapper meta.RESTMapper, name, namespace string) error {
+	secret, err := controlPlaneClient.CoreV1().Secrets(namespace).Get(ctx, name+"-certs", metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("get certs secret: %w", err)
+	}
+
+	encryptionConfig, err := certs.ParseEncryptionConfig(secret.Data[certs.EncryptionConfigName])
+	if err != nil {
+		return err
+	} else if encryptionConfig == nil {
+		return nil
+	}
+
+	hash := certs.EncryptionConfigHash(encryptionConfig)
+	if secret.Annotations[certs.EncryptionMigratedAnnotation] == hash {
+		return nil
+	}
+
+	klog.Infof("Encryption config has changed, rewrite encrypted resources")
+	for _, resourceConfig := range encryptionConfig.Resources {
+		for _, resource := range resourceConfig.Resources {
+			err = rewriteResources(ctx, virtualClient, restMapper, resource)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		secret, err := controlPlaneClient.CoreV1().Secrets(namespace).Get(ctx, name+"-certs", metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		if secret.Annotations == nil {
+			secret.Annotations = map[string]string{}
+		}
+		secret.Annotations[certs.EncryptionMigratedAnnotation] = hash
+		_, err = controlPlaneClient.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("update certs secret: %w", err)
+	}
+
+	klog.Infof("Successfully rewrote encrypted resources")
+	return nil
+}
+
+func rewriteResources(ctx context.Context, virtualClient client.Client, restMapper meta.RESTMapper, resource string) error {
+	gvk, err := restMapper.KindFor(schema.ParseGroupResource(resource).WithVersion(""))
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			klog.Warningf("Skip rewriting %s, because the resource does not exist", resource)
+			return nil
+		}
+
+		return fmt.Errorf("find kind for %s: %w", resource, err)
+	}
+
+	continueToken := ""
+	for {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		err = virtualClient.List(ctx, list, client.Limit(500), client.Continue(continueToken))
+		if err != nil {
+			return fmt.Errorf("list %s: %w", resource, err)
+		}
+
+		// an update without changes is enough for the api server to store the object with the current provider
+		for i := range list.Items {
+			err = virtualClient.Update(ctx, &list.Items[i])
+			if err != nil && !kerrors.IsConflict(err) && !kerrors.IsNotFound(err) {
+				return fmt.Errorf("rewrite %s %s/%s: %w", resource, list.Items[i].GetNamespace(), list.Items[i].GetName(), err)
+			}
+		}
+
+		continueToken = list.GetContinue()
+		if continueToken == "" {
+			return nil
+		}
+	}
+}
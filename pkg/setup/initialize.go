@@ -12,6 +12,7 @@ import (
 	"time"
 
 	vclusterconfig "github.com/loft-sh/vcluster/config"
+	"github.com/loft-sh/vcluster/pkg/authentication/oidc"
 	"github.com/loft-sh/vcluster/pkg/certs"
 	"github.com/loft-sh/vcluster/pkg/config"
 	"github.com/loft-sh/vcluster/pkg/k0s"
@@ -74,6 +75,12 @@ func initialize(ctx context.Context, parentCtx context.Context, options *config.
 		}
 	}
 
+	// write the oidc ca, so that the virtual api server can verify the OpenID provider
+	err := oidc.WriteCA(options.ControlPlane.Auth.OIDC)
+	if err != nil {
+		return err
+	}
+
 	// check what distro are we running
 	switch distro {
 	case vclusterconfig.K0SDistro:
@@ -264,6 +271,26 @@ func GenerateCerts(ctx context.Context, currentNamespaceClient kubernetes.Interf
 		return fmt.Errorf("ensure certs: %w", err)
 	}
 
+	// track the admin client certificate, so that it can be revoked via vcluster credentials revoke
+	err = RecordAdminCredential(ctx, currentNamespaceClient, vClusterName, currentNamespace, certificatesDir)
+	if err != nil {
+		return err
+	}
+
+	// generate encryption config
+	err = certs.EnsureEncryptionConfig(ctx, currentNamespace, currentNamespaceClient, vClusterName, certificatesDir, options)
+	if err != nil {
+		return err
+	}
+
+	// request the serving certificate from cert-manager, so that pods can trust its CA
+	if options.ControlPlane.Certificates.CertManager.Enabled {
+		err = EnsureCertManagerRootCA(ctx, certificatesDir, options)
+		if err != nil {
+			return fmt.Errorf("ensure cert-manager certificate: %w", err)
+		}
+	}
+
 	return nil
 }
 
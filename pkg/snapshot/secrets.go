@@ -0,0 +1,125 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/loft-sh/vcluster/pkg/certs"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// CertsSecretName returns the name of the certificate secret of the given virtual cluster
+func CertsSecretName(vClusterName string) string {
+	return vClusterName + "-certs"
+}
+
+// K3sTokenSecretName returns the name of the k3s token secret of the given virtual cluster
+func K3sTokenSecretName(vClusterName string) string {
+	return "vc-k3s-" + vClusterName
+}
+
+// portableCertKeys are the entries of the certificate secret that are not bound to the name and namespace of
+// the virtual cluster. The restored backing store can't be read without them, as secrets are encrypted with
+// the encryption configuration and service account tokens are signed with the service account key.
+var portableCertKeys = []string{
+	certs.EncryptionConfigName,
+	certs.ServiceAccountPrivateKeyName,
+	certs.ServiceAccountPublicKeyName,
+}
+
+// MergePortableCerts returns the certificate secret data of a virtual cluster with the portable entries of the
+// snapshot applied. All other certificates are kept, as they are only valid for the virtual cluster they were issued for.
+func MergePortableCerts(current, snapshot map[string][]byte) map[string][]byte {
+	merged := maps.Clone(current)
+	for _, key := range portableCertKeys {
+		if value, ok := snapshot[key]; ok {
+			merged[key] = value
+		} else {
+			delete(merged, key)
+		}
+	}
+
+	return merged
+}
+
+// GetSecrets reads the secrets of the given virtual cluster that should be part of a snapshot.
+// Secrets that do not exist are skipped.
+func GetSecrets(ctx context.Context, kubeClient kubernetes.Interface, namespace, vClusterName string) (Secrets, error) {
+	var (
+		secrets Secrets
+		err     error
+	)
+
+	secrets.Certs, err = getSecretData(ctx, kubeClient, namespace, CertsSecretName(vClusterName))
+	if err != nil {
+		return Secrets{}, err
+	}
+
+	secrets.K3sToken, err = getSecretData(ctx, kubeClient, namespace, K3sTokenSecretName(vClusterName))
+	if err != nil {
+		return Secrets{}, err
+	}
+
+	return secrets, nil
+}
+
+// ApplySecrets creates or replaces the secrets of the given virtual cluster with the data from the snapshot.
+func ApplySecrets(ctx context.Context, kubeClient kubernetes.Interface, namespace, vClusterName string, secrets Secrets) error {
+	err := applySecretData(ctx, kubeClient, namespace, CertsSecretName(vClusterName), secrets.Certs)
+	if err != nil {
+		return err
+	}
+
+	return applySecretData(ctx, kubeClient, namespace, K3sTokenSecretName(vClusterName), secrets.K3sToken)
+}
+
+func getSecretData(ctx context.Context, kubeClient kubernetes.Interface, namespace, name string) (map[string][]byte, error) {
+	secret, err := kubeClient.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("get secret %s/%s: %w", namespace, name, err)
+	}
+
+	return secret.Data, nil
+}
+
+func applySecretData(ctx context.Context, kubeClient kubernetes.Interface, namespace, name string, data map[string][]byte) error {
+	if data == nil {
+		return nil
+	}
+
+	secret, err := kubeClient.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			return fmt.Errorf("get secret %s/%s: %w", namespace, name, err)
+		}
+
+		_, err = kubeClient.CoreV1().Secrets(namespace).Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Data: data,
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("create secret %s/%s: %w", namespace, name, err)
+		}
+
+		return nil
+	}
+
+	secret.Data = data
+	_, err = kubeClient.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("update secret %s/%s: %w", namespace, name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,213 @@
+package snapshot
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/loft-sh/vcluster/pkg/etcd"
+)
+
+const (
+	// Version is the version of the snapshot archive format
+	Version = "v1"
+
+	metadataFile = "snapshot.json"
+	secretsFile  = "secrets.json"
+	etcdDir      = "etcd"
+
+	// keyPrefix is the prefix of all keys that are part of a snapshot. This covers the
+	// kubernetes registry as well as the k3s bootstrap data.
+	keyPrefix = "/"
+)
+
+// Metadata describes a snapshot and is stored as first entry of the archive
+type Metadata struct {
+	// Version is the version of the archive format
+	Version string `json:"version"`
+
+	// Name is the name of the virtual cluster the snapshot was taken from
+	Name string `json:"name,omitempty"`
+
+	// Namespace is the host namespace of the virtual cluster the snapshot was taken from
+	Namespace string `json:"namespace,omitempty"`
+
+	// Distro is the kubernetes distro of the virtual cluster
+	Distro string `json:"distro,omitempty"`
+
+	// CreationTimestamp is the time the snapshot was taken
+	CreationTimestamp time.Time `json:"creationTimestamp"`
+}
+
+// Secrets holds the data of the host secrets a virtual cluster needs to be able to
+// read its backing store again
+type Secrets struct {
+	// Certs is the data of the certificate secret
+	Certs map[string][]byte `json:"certs,omitempty"`
+
+	// K3sToken is the data of the k3s token secret
+	K3sToken map[string][]byte `json:"k3sToken,omitempty"`
+}
+
+// Snapshot is the content of a restored snapshot that is not written to the backing store
+type Snapshot struct {
+	Metadata Metadata
+
+	// Secrets holds the host secret data stored in the snapshot
+	Secrets Secrets
+
+	// Keys is the number of restored keys
+	Keys int
+}
+
+// Create writes a gzipped tar archive of the complete backing store keyspace together with the given
+// secret data to w and returns the number of keys written.
+func Create(ctx context.Context, etcdClient etcd.Client, metadata Metadata, secrets Secrets, w io.Writer) (int, error) {
+	gzipWriter := gzip.NewWriter(w)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	metadata.Version = Version
+	if metadata.CreationTimestamp.IsZero() {
+		metadata.CreationTimestamp = time.Now()
+	}
+	err := writeJSON(tarWriter, metadataFile, metadata)
+	if err != nil {
+		return 0, err
+	}
+
+	err = writeJSON(tarWriter, secretsFile, secrets)
+	if err != nil {
+		return 0, err
+	}
+
+	values, err := etcdClient.List(ctx, keyPrefix, 0)
+	if err != nil {
+		return 0, fmt.Errorf("list keys: %w", err)
+	}
+	for _, value := range values {
+		err = writeFile(tarWriter, etcdDir+string(value.Key), value.Data)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	err = tarWriter.Close()
+	if err != nil {
+		return 0, err
+	}
+
+	return len(values), gzipWriter.Close()
+}
+
+// Restore reads a snapshot archive created by Create from r and writes all keys into the backing store.
+// Keys that exist in the backing store but are not part of the snapshot are deleted afterwards. The
+// secret data is returned and needs to be applied by the caller.
+func Restore(ctx context.Context, etcdClient etcd.Client, r io.Reader) (*Snapshot, error) {
+	gzipReader, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("read snapshot: %w", err)
+	}
+	defer gzipReader.Close()
+
+	// the metadata always has to come first, so we can be sure we are dealing with
+	// a valid snapshot before touching the backing store
+	tarReader := tar.NewReader(gzipReader)
+	header, err := tarReader.Next()
+	if err != nil {
+		return nil, fmt.Errorf("read snapshot: %w", err)
+	} else if header.Name != metadataFile {
+		return nil, fmt.Errorf("read snapshot: expected %s as first entry, got %s", metadataFile, header.Name)
+	}
+
+	snapshot := &Snapshot{}
+	err = json.NewDecoder(tarReader).Decode(&snapshot.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("decode snapshot metadata: %w", err)
+	} else if snapshot.Metadata.Version != Version {
+		return nil, fmt.Errorf("unsupported snapshot version %q, expected %q", snapshot.Metadata.Version, Version)
+	}
+
+	restoredKeys := map[string]bool{}
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("read snapshot: %w", err)
+		}
+
+		switch {
+		case header.Name == secretsFile:
+			err = json.NewDecoder(tarReader).Decode(&snapshot.Secrets)
+			if err != nil {
+				return nil, fmt.Errorf("decode snapshot secrets: %w", err)
+			}
+		case strings.HasPrefix(header.Name, etcdDir+keyPrefix):
+			data, err := io.ReadAll(tarReader)
+			if err != nil {
+				return nil, fmt.Errorf("read key %s: %w", header.Name, err)
+			}
+
+			key := strings.TrimPrefix(header.Name, etcdDir)
+			err = etcdClient.Put(ctx, key, data)
+			if err != nil {
+				return nil, fmt.Errorf("restore key %s: %w", key, err)
+			}
+
+			restoredKeys[key] = true
+		}
+	}
+
+	// remove everything that was created after the snapshot was taken
+	values, err := etcdClient.List(ctx, keyPrefix, 0)
+	if err != nil {
+		return nil, fmt.Errorf("list keys: %w", err)
+	}
+	for _, value := range values {
+		if restoredKeys[string(value.Key)] {
+			continue
+		}
+
+		err = etcdClient.Delete(ctx, string(value.Key), value.Revision)
+		if err != nil {
+			return nil, fmt.Errorf("delete key %s: %w", string(value.Key), err)
+		}
+	}
+
+	snapshot.Keys = len(restoredKeys)
+	return snapshot, nil
+}
+
+func writeJSON(tarWriter *tar.Writer, name string, obj interface{}) error {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("marshal %s: %w", name, err)
+	}
+
+	return writeFile(tarWriter, name, data)
+}
+
+func writeFile(tarWriter *tar.Writer, name string, data []byte) error {
+	err := tarWriter.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     0600,
+		Size:     int64(len(data)),
+		Typeflag: tar.TypeReg,
+	})
+	if err != nil {
+		return fmt.Errorf("write header %s: %w", name, err)
+	}
+
+	_, err = tarWriter.Write(data)
+	if err != nil {
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,293 @@
+package snapshot
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/loft-sh/vcluster/pkg/etcd"
+	"gotest.tools/v3/assert"
+)
+
+type fakeEtcdClient struct {
+	revision int64
+	values   map[string]etcd.Value
+}
+
+func newFakeEtcdClient(values map[string]string) *fakeEtcdClient {
+	c := &fakeEtcdClient{values: map[string]etcd.Value{}}
+	for key, value := range values {
+		_ = c.Put(context.Background(), key, []byte(value))
+	}
+	return c
+}
+
+func (c *fakeEtcdClient) data() map[string]string {
+	ret := map[string]string{}
+	for key, value := range c.values {
+		ret[key] = string(value.Data)
+	}
+	return ret
+}
+
+func (c *fakeEtcdClient) List(_ context.Context, key string, _ int) ([]etcd.Value, error) {
+	var ret []etcd.Value
+	for k, value := range c.values {
+		if strings.HasPrefix(k, key) {
+			ret = append(ret, value)
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return string(ret[i].Key) < string(ret[j].Key)
+	})
+	return ret, nil
+}
+
+func (c *fakeEtcdClient) Get(_ context.Context, key string) (etcd.Value, error) {
+	value, ok := c.values[key]
+	if !ok {
+		return etcd.Value{}, etcd.ErrNotFound
+	}
+	return value, nil
+}
+
+func (c *fakeEtcdClient) Put(_ context.Context, key string, value []byte) error {
+	c.revision++
+	c.values[key] = etcd.Value{Key: []byte(key), Data: value, Revision: c.revision}
+	return nil
+}
+
+func (c *fakeEtcdClient) Create(ctx context.Context, key string, value []byte) error {
+	return c.Put(ctx, key, value)
+}
+
+func (c *fakeEtcdClient) Update(ctx context.Context, key string, _ int64, value []byte) error {
+	return c.Put(ctx, key, value)
+}
+
+func (c *fakeEtcdClient) Delete(_ context.Context, key string, _ int64) error {
+	delete(c.values, key)
+	return nil
+}
+
+func (c *fakeEtcdClient) Compact(_ context.Context, revision int64) (int64, error) {
+	return revision, nil
+}
+
+func (c *fakeEtcdClient) Close() error {
+	return nil
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	source := newFakeEtcdClient(map[string]string{
+		"/registry/pods/default/nginx":        "pod",
+		"/registry/configmaps/default/config": "configmap",
+		"/bootstrap/abc":                      "bootstrap",
+	})
+	secrets := Secrets{
+		Certs: map[string][]byte{
+			"ca.crt": []byte("ca"),
+		},
+	}
+
+	buffer := &bytes.Buffer{}
+	keys, err := Create(context.Background(), source, Metadata{Name: "test", Distro: "k8s"}, secrets, buffer)
+	assert.NilError(t, err)
+	assert.Equal(t, keys, 3)
+
+	target := newFakeEtcdClient(map[string]string{
+		"/registry/pods/default/nginx":  "other-pod",
+		"/registry/pods/default/broken": "pod",
+	})
+	restored, err := Restore(context.Background(), target, buffer)
+	assert.NilError(t, err)
+	assert.Equal(t, restored.Keys, 3)
+	assert.Equal(t, restored.Metadata.Name, "test")
+	assert.Equal(t, restored.Metadata.Version, Version)
+	assert.DeepEqual(t, restored.Secrets, secrets)
+	assert.DeepEqual(t, target.data(), source.data())
+}
+
+func TestMergePortableCerts(t *testing.T) {
+	current := map[string][]byte{
+		"ca.crt":                   []byte("new-ca"),
+		"apiserver.crt":            []byte("new-apiserver"),
+		"sa.key":                   []byte("new-sa-key"),
+		"sa.pub":                   []byte("new-sa-pub"),
+		"encryption-config.yaml":   []byte("new-encryption"),
+		"front-proxy-ca.crt":       []byte("new-front-proxy-ca"),
+		"apiserver-kubelet-client": []byte("new-client"),
+	}
+	snapshot := map[string][]byte{
+		"ca.crt":        []byte("old-ca"),
+		"apiserver.crt": []byte("old-apiserver"),
+		"sa.key":        []byte("old-sa-key"),
+		"sa.pub":        []byte("old-sa-pub"),
+	}
+
+	merged := MergePortableCerts(current, snapshot)
+	assert.DeepEqual(t, merged, map[string][]byte{
+		"ca.crt":                   []byte("new-ca"),
+		"apiserver.crt":            []byte("new-apiserver"),
+		"sa.key":                   []byte("old-sa-key"),
+		"sa.pub":                   []byte("old-sa-pub"),
+		"front-proxy-ca.crt":       []byte("new-front-proxy-ca"),
+		"apiserver-kubelet-client": []byte("new-client"),
+	})
+	assert.Equal(t, string(current["sa.key"]), "new-sa-key")
+
+	snapshot["encryption-config.yaml"] = []byte("old-encryption")
+	merged = MergePortableCerts(current, snapshot)
+	assert.Equal(t, string(merged["encryption-config.yaml"]), "old-encryption")
+}
+
+func TestRestoreInvalidSnapshot(t *testing.T) {
+	target := newFakeEtcdClient(map[string]string{
+		"/registry/pods/default/nginx": "pod",
+	})
+
+	_, err := Restore(context.Background(), target, strings.NewReader("not a snapshot"))
+	assert.ErrorContains(t, err, "read snapshot")
+	assert.DeepEqual(t, target.data(), map[string]string{
+		"/registry/pods/default/nginx": "pod",
+	})
+}
+
+func TestFileStorage(t *testing.T) {
+	location := filepath.Join(t.TempDir(), "snapshots", "test.tar.gz")
+	storage, err := NewStorage("file://"+location, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, storage.Target(), location)
+
+	testStorage(t, storage)
+}
+
+func TestS3Storage(t *testing.T) {
+	// a minimal S3 stand-in that stores objects in memory
+	var (
+		lock    sync.Mutex
+		objects = map[string][]byte{}
+		parts   = map[string]map[int][]byte{}
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		query := r.URL.Query()
+		switch {
+		case r.Method == http.MethodPost && query.Has("uploads"):
+			parts[r.URL.Path] = map[int][]byte{}
+			_, _ = fmt.Fprintf(w, "<InitiateMultipartUploadResult><UploadId>upload</UploadId></InitiateMultipartUploadResult>")
+		case r.Method == http.MethodPut && query.Has("partNumber"):
+			partNumber, _ := strconv.Atoi(query.Get("partNumber"))
+			parts[r.URL.Path][partNumber] = readS3Body(t, r)
+			w.Header().Set("ETag", `"etag"`)
+		case r.Method == http.MethodPost && query.Has("uploadId"):
+			data := []byte{}
+			for i := 1; i <= len(parts[r.URL.Path]); i++ {
+				data = append(data, parts[r.URL.Path][i]...)
+			}
+			objects[r.URL.Path] = data
+			_, _ = fmt.Fprintf(w, "<CompleteMultipartUploadResult><Bucket>snapshots</Bucket><ETag>etag</ETag></CompleteMultipartUploadResult>")
+		case r.Method == http.MethodPut:
+			objects[r.URL.Path] = readS3Body(t, r)
+			w.Header().Set("ETag", `"etag"`)
+		case r.Method == http.MethodGet || r.Method == http.MethodHead:
+			data, ok := objects[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("ETag", `"etag"`)
+			w.Header().Set("Content-Type", "application/gzip")
+			http.ServeContent(w, r, "", time.Unix(1700000000, 0), bytes.NewReader(data))
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	defer server.Close()
+
+	storage, err := NewStorage("s3://snapshots/vcluster/test.tar.gz", &Options{
+		S3: S3Options{
+			Endpoint:        server.URL,
+			Region:          "us-east-1",
+			AccessKeyID:     "access",
+			SecretAccessKey: "secret",
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, storage.Target(), "s3://snapshots/vcluster/test.tar.gz")
+
+	testStorage(t, storage)
+	_, ok := objects["/snapshots/vcluster/test.tar.gz"]
+	assert.Assert(t, ok)
+}
+
+// readS3Body reads the request body and decodes the aws-chunked encoding used for
+// streaming signatures
+func readS3Body(t *testing.T, r *http.Request) []byte {
+	reader := bufio.NewReader(r.Body)
+	if !strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+		data, err := io.ReadAll(reader)
+		assert.NilError(t, err)
+		return data
+	}
+
+	data := []byte{}
+	for {
+		line, err := reader.ReadString('\n')
+		assert.NilError(t, err)
+		size, _, _ := strings.Cut(strings.TrimSpace(line), ";")
+		chunkSize, err := strconv.ParseInt(size, 16, 64)
+		assert.NilError(t, err)
+		if chunkSize == 0 {
+			return data
+		}
+
+		chunk := make([]byte, chunkSize+2)
+		_, err = io.ReadFull(reader, chunk)
+		assert.NilError(t, err)
+		data = append(data, chunk[:chunkSize]...)
+	}
+}
+
+func testStorage(t *testing.T, storage Storage) {
+	source := newFakeEtcdClient(map[string]string{
+		"/registry/pods/default/nginx": "pod",
+	})
+
+	buffer := &bytes.Buffer{}
+	_, err := Create(context.Background(), source, Metadata{Name: "test"}, Secrets{}, buffer)
+	assert.NilError(t, err)
+
+	err = storage.Write(context.Background(), buffer)
+	assert.NilError(t, err)
+
+	reader, err := storage.Read(context.Background())
+	assert.NilError(t, err)
+	defer reader.Close()
+
+	target := newFakeEtcdClient(nil)
+	_, err = Restore(context.Background(), target, reader)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, target.data(), source.data())
+}
+
+func TestNewStorage(t *testing.T) {
+	_, err := NewStorage("s3://bucket", nil)
+	assert.ErrorContains(t, err, "invalid s3 location")
+
+	_, err = NewStorage("", nil)
+	assert.ErrorContains(t, err, "empty")
+}
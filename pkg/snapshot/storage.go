@@ -0,0 +1,199 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+const (
+	s3Scheme   = "s3://"
+	fileScheme = "file://"
+
+	// DefaultS3Endpoint is the endpoint used if no S3 endpoint is configured
+	DefaultS3Endpoint = "s3.amazonaws.com"
+)
+
+// Storage reads and writes a single snapshot archive
+type Storage interface {
+	// Target returns a human readable description of where the snapshot is stored
+	Target() string
+
+	// Write stores the snapshot read from r
+	Write(ctx context.Context, r io.Reader) error
+
+	// Read returns a reader for the stored snapshot
+	Read(ctx context.Context) (io.ReadCloser, error)
+}
+
+// Options are the options to create a snapshot storage
+type Options struct {
+	S3 S3Options
+}
+
+// S3Options are the options for S3 compatible storages
+type S3Options struct {
+	// Endpoint is the S3 endpoint, e.g. s3.amazonaws.com or minio.example.com:9000
+	Endpoint string
+
+	// Region is the bucket region
+	Region string
+
+	// AccessKeyID and SecretAccessKey are the static credentials to use. If empty,
+	// credentials are read from the environment or the AWS credentials file.
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+
+	// Insecure uses plain http to connect to the endpoint
+	Insecure bool
+}
+
+// NewStorage returns the storage for the given location. Locations in the form of
+// s3://bucket/key are stored in S3, everything else is treated as a local file path.
+func NewStorage(location string, options *Options) (Storage, error) {
+	if options == nil {
+		options = &Options{}
+	}
+
+	if strings.HasPrefix(location, s3Scheme) {
+		bucket, key, _ := strings.Cut(strings.TrimPrefix(location, s3Scheme), "/")
+		if bucket == "" || key == "" {
+			return nil, fmt.Errorf("invalid s3 location %s, expected s3://bucket/key", location)
+		}
+
+		return newS3Storage(bucket, key, &options.S3)
+	}
+
+	path := strings.TrimPrefix(location, fileScheme)
+	if path == "" {
+		return nil, fmt.Errorf("snapshot location is empty")
+	}
+
+	return &fileStorage{path: path}, nil
+}
+
+type fileStorage struct {
+	path string
+}
+
+func (f *fileStorage) Target() string {
+	return f.path
+}
+
+func (f *fileStorage) Write(_ context.Context, r io.Reader) error {
+	err := os.MkdirAll(filepath.Dir(f.path), 0755)
+	if err != nil {
+		return fmt.Errorf("create directory: %w", err)
+	}
+
+	// write to a temporary file first, so we never leave a partial snapshot behind
+	tmpPath := f.path + ".tmp"
+	file, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(file, r)
+	if err != nil {
+		_ = file.Close()
+		_ = os.Remove(tmpPath)
+		return err
+	}
+
+	err = file.Close()
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+
+	return os.Rename(tmpPath, f.path)
+}
+
+func (f *fileStorage) Read(_ context.Context) (io.ReadCloser, error) {
+	return os.Open(f.path)
+}
+
+type s3Storage struct {
+	client *minio.Client
+	bucket string
+	key    string
+}
+
+func newS3Storage(bucket, key string, options *S3Options) (*s3Storage, error) {
+	endpoint := options.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultS3Endpoint
+	}
+
+	// allow specifying the endpoint as url
+	secure := !options.Insecure
+	if strings.HasPrefix(endpoint, "http://") {
+		secure = false
+	}
+	endpoint = strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(endpoint, "https://"), "http://"), "/")
+
+	var creds *credentials.Credentials
+	if options.AccessKeyID != "" || options.SecretAccessKey != "" {
+		creds = credentials.NewStaticV4(options.AccessKeyID, options.SecretAccessKey, options.SessionToken)
+	} else {
+		creds = credentials.NewChainCredentials([]credentials.Provider{
+			&credentials.EnvAWS{},
+			&credentials.EnvMinio{},
+			&credentials.FileAWSCredentials{},
+		})
+	}
+
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds:  creds,
+		Secure: secure,
+		Region: options.Region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create s3 client: %w", err)
+	}
+
+	return &s3Storage{
+		client: client,
+		bucket: bucket,
+		key:    key,
+	}, nil
+}
+
+func (s *s3Storage) Target() string {
+	return s3Scheme + s.bucket + "/" + s.key
+}
+
+func (s *s3Storage) Write(ctx context.Context, r io.Reader) error {
+	_, err := s.client.PutObject(ctx, s.bucket, s.key, r, -1, minio.PutObjectOptions{
+		ContentType: "application/gzip",
+		PartSize:    16 * 1024 * 1024,
+	})
+	if err != nil {
+		return fmt.Errorf("upload snapshot to %s: %w", s.Target(), err)
+	}
+
+	return nil
+}
+
+func (s *s3Storage) Read(ctx context.Context) (io.ReadCloser, error) {
+	object, err := s.client.GetObject(ctx, s.bucket, s.key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("download snapshot from %s: %w", s.Target(), err)
+	}
+
+	// make sure the object actually exists
+	_, err = object.Stat()
+	if err != nil {
+		_ = object.Close()
+		return nil, fmt.Errorf("download snapshot from %s: %w", s.Target(), err)
+	}
+
+	return object, nil
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 
 	"github.com/loft-sh/vcluster/pkg/config"
-	"github.com/loft-sh/vcluster/pkg/constants"
 	"github.com/loft-sh/vcluster/pkg/syncer/synccontext"
 	syncertypes "github.com/loft-sh/vcluster/pkg/syncer/types"
 	"github.com/loft-sh/vcluster/pkg/util/translate"
@@ -13,7 +12,6 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	controller2 "sigs.k8s.io/controller-runtime/pkg/controller"
 )
 
 func RegisterFakeSyncer(ctx *synccontext.RegisterContext, syncer syncertypes.FakeSyncer) error {
@@ -96,10 +94,7 @@ func (r *fakeSyncer) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 
 func (r *fakeSyncer) Register(ctx *synccontext.RegisterContext) error {
 	controller := ctrl.NewControllerManagedBy(ctx.VirtualManager).
-		WithOptions(controller2.Options{
-			MaxConcurrentReconciles: 10,
-			CacheSyncTimeout:        constants.DefaultCacheSyncTimeout,
-		}).
+		WithOptions(newControllerOptions(ctx.Config, r.syncer.Name())).
 		Named(r.syncer.Name()).
 		For(r.syncer.Resource())
 	var err error
@@ -0,0 +1,249 @@
+package syncer
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	vclusterconfig "github.com/loft-sh/vcluster/config"
+	"github.com/loft-sh/vcluster/pkg/config"
+	"github.com/loft-sh/vcluster/pkg/constants"
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	controller2 "sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
+)
+
+const (
+	defaultWorkers = 10
+
+	// maxPriorityWait is the maximum time a worker of a non priority syncer waits
+	// for priority syncers, this makes sure other syncers are never starved completely.
+	maxPriorityWait = time.Second
+)
+
+// defaultPriorityLanes is shared between all syncers of the vCluster
+var defaultPriorityLanes = newPriorityLanes()
+
+// priorityLanes keeps track of the queues of priority syncers, so that workers of
+// all other syncers can wait with dequeuing while there is priority work to do.
+type priorityLanes struct {
+	m      sync.Mutex
+	queues []workqueue.RateLimitingInterface
+
+	// idle is closed and replaced whenever a priority event is done
+	idle chan struct{}
+
+	inFlight atomic.Int64
+}
+
+func newPriorityLanes() *priorityLanes {
+	return &priorityLanes{
+		idle: make(chan struct{}),
+	}
+}
+
+func (p *priorityLanes) register(queue workqueue.RateLimitingInterface) {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	p.queues = append(p.queues, queue)
+}
+
+// busy returns true if a priority syncer has queued or in-flight events
+func (p *priorityLanes) busy() bool {
+	if p.inFlight.Load() > 0 {
+		return true
+	}
+
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	for _, queue := range p.queues {
+		if queue.Len() > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// done marks a priority event as done and wakes up all waiting workers
+func (p *priorityLanes) done() {
+	p.inFlight.Add(-1)
+
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	close(p.idle)
+	p.idle = make(chan struct{})
+}
+
+func (p *priorityLanes) waitChannel() <-chan struct{} {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	return p.idle
+}
+
+// wait blocks until no priority syncer is busy anymore or maxPriorityWait has passed.
+// It is called before an event is dequeued, so the event stays in the queue while waiting.
+func (p *priorityLanes) wait() {
+	timer := time.NewTimer(maxPriorityWait)
+	defer timer.Stop()
+
+	for {
+		// get the channel before checking, so we can't miss a done in between
+		idle := p.waitChannel()
+		if !p.busy() {
+			return
+		}
+
+		select {
+		case <-idle:
+		case <-timer.C:
+			return
+		}
+	}
+}
+
+// syncQueue wraps the controller workqueue to throttle the processing rate and to
+// give priority syncers precedence over all others.
+type syncQueue struct {
+	workqueue.RateLimitingInterface
+
+	limiter  *rate.Limiter
+	priority bool
+	lanes    *priorityLanes
+}
+
+func (q *syncQueue) Get() (interface{}, bool) {
+	// decide whether to yield to the priority syncers before taking an event out
+	// of the queue, so that no event is held back by a waiting worker
+	if !q.priority {
+		q.lanes.wait()
+	}
+
+	item, shutdown := q.RateLimitingInterface.Get()
+	if shutdown {
+		return item, shutdown
+	}
+	if q.priority {
+		q.lanes.inFlight.Add(1)
+	}
+
+	if q.limiter != nil {
+		_ = q.limiter.Wait(context.Background())
+	}
+
+	return item, false
+}
+
+func (q *syncQueue) Done(item interface{}) {
+	q.RateLimitingInterface.Done(item)
+
+	if q.priority {
+		q.lanes.done()
+	}
+}
+
+// syncerRegistry keeps track of the names of all syncers that created a workqueue
+type syncerRegistry struct {
+	m     sync.Mutex
+	names []string
+}
+
+func (r *syncerRegistry) add(name string) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	if !slices.Contains(r.names, name) {
+		r.names = append(r.names, name)
+	}
+}
+
+func (r *syncerRegistry) validate(prioritySyncers []string) error {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	for _, name := range prioritySyncers {
+		if !slices.Contains(r.names, name) {
+			names := slices.Clone(r.names)
+			slices.Sort(names)
+			return fmt.Errorf("experimental.syncSettings.workqueue.prioritySyncers: syncer %q is not registered, registered syncers are: %s", name, strings.Join(names, ", "))
+		}
+	}
+
+	return nil
+}
+
+// defaultSyncerRegistry holds all syncers registered through newControllerOptions
+var defaultSyncerRegistry = &syncerRegistry{}
+
+// ValidatePrioritySyncers returns an error if a configured priority syncer does not match
+// the name of a registered syncer. It needs to be called after all syncers were registered.
+func ValidatePrioritySyncers(vConfig *config.VirtualClusterConfig) error {
+	return defaultSyncerRegistry.validate(vConfig.Experimental.SyncSettings.Workqueue.PrioritySyncers)
+}
+
+// workqueueOptions returns the workqueue options for the given syncer, where options
+// configured for the specific syncer take precedence over the general ones.
+func workqueueOptions(vConfig *config.VirtualClusterConfig, name string) (vclusterconfig.SyncWorkqueueSyncer, bool) {
+	workqueueConfig := vConfig.Experimental.SyncSettings.Workqueue
+	options := workqueueConfig.SyncWorkqueueSyncer
+	if syncerOptions, ok := workqueueConfig.Syncers[name]; ok {
+		if syncerOptions.Workers > 0 {
+			options.Workers = syncerOptions.Workers
+		}
+		if syncerOptions.QPS > 0 {
+			options.QPS = syncerOptions.QPS
+		}
+		if syncerOptions.Burst > 0 {
+			options.Burst = syncerOptions.Burst
+		}
+	}
+
+	if options.Workers <= 0 {
+		options.Workers = defaultWorkers
+	}
+	if options.QPS > 0 && options.Burst <= 0 {
+		options.Burst = options.QPS
+	}
+
+	return options, slices.Contains(workqueueConfig.PrioritySyncers, name)
+}
+
+// newControllerOptions builds the controller options for the given syncer
+func newControllerOptions(vConfig *config.VirtualClusterConfig, name string) controller2.Options {
+	options, priority := workqueueOptions(vConfig, name)
+	defaultSyncerRegistry.add(name)
+	return controller2.Options{
+		MaxConcurrentReconciles: options.Workers,
+		CacheSyncTimeout:        constants.DefaultCacheSyncTimeout,
+		NewQueue: func(controllerName string, rateLimiter ratelimiter.RateLimiter) workqueue.RateLimitingInterface {
+			return newSyncQueue(controllerName, rateLimiter, options, priority, defaultPriorityLanes)
+		},
+	}
+}
+
+func newSyncQueue(name string, rateLimiter ratelimiter.RateLimiter, options vclusterconfig.SyncWorkqueueSyncer, priority bool, lanes *priorityLanes) *syncQueue {
+	queue := &syncQueue{
+		RateLimitingInterface: workqueue.NewRateLimitingQueueWithConfig(rateLimiter, workqueue.RateLimitingQueueConfig{
+			Name: name,
+		}),
+		priority: priority,
+		lanes:    lanes,
+	}
+	if options.QPS > 0 {
+		queue.limiter = rate.NewLimiter(rate.Limit(options.QPS), options.Burst)
+	}
+	if priority {
+		lanes.register(queue.RateLimitingInterface)
+	}
+
+	return queue
+}
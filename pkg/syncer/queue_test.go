@@ -0,0 +1,103 @@
+package syncer
+
+import (
+	"testing"
+	"time"
+
+	vclusterconfig "github.com/loft-sh/vcluster/config"
+	"github.com/loft-sh/vcluster/pkg/config"
+	"gotest.tools/v3/assert"
+	"k8s.io/client-go/util/workqueue"
+)
+
+func TestWorkqueueOptions(t *testing.T) {
+	vConfig := &config.VirtualClusterConfig{}
+	vConfig.Experimental.SyncSettings.Workqueue = vclusterconfig.SyncWorkqueue{
+		SyncWorkqueueSyncer: vclusterconfig.SyncWorkqueueSyncer{
+			Workers: 5,
+		},
+		PrioritySyncers: []string{"pod"},
+		Syncers: map[string]vclusterconfig.SyncWorkqueueSyncer{
+			"secret": {
+				Workers: 2,
+				QPS:     20,
+			},
+		},
+	}
+
+	options, priority := workqueueOptions(vConfig, "pod")
+	assert.DeepEqual(t, options, vclusterconfig.SyncWorkqueueSyncer{Workers: 5})
+	assert.Equal(t, priority, true)
+
+	options, priority = workqueueOptions(vConfig, "secret")
+	assert.DeepEqual(t, options, vclusterconfig.SyncWorkqueueSyncer{Workers: 2, QPS: 20, Burst: 20})
+	assert.Equal(t, priority, false)
+
+	options, _ = workqueueOptions(&config.VirtualClusterConfig{}, "configmap")
+	assert.DeepEqual(t, options, vclusterconfig.SyncWorkqueueSyncer{Workers: defaultWorkers})
+}
+
+func TestPriorityLanes(t *testing.T) {
+	lanes := newPriorityLanes()
+	priorityQueue := newSyncQueue("pod", workqueue.DefaultControllerRateLimiter(), vclusterconfig.SyncWorkqueueSyncer{}, true, lanes)
+	defer priorityQueue.ShutDown()
+	otherQueue := newSyncQueue("secret", workqueue.DefaultControllerRateLimiter(), vclusterconfig.SyncWorkqueueSyncer{}, false, lanes)
+	defer otherQueue.ShutDown()
+
+	priorityQueue.Add("pod")
+	otherQueue.Add("secret")
+	assert.Equal(t, lanes.busy(), true)
+
+	// process the priority event in the background
+	processed := make(chan struct{})
+	go func() {
+		item, _ := priorityQueue.Get()
+		time.Sleep(100 * time.Millisecond)
+
+		// the secret event is still queued while the worker waits
+		assert.Equal(t, otherQueue.Len(), 1)
+		close(processed)
+		priorityQueue.Done(item)
+	}()
+
+	// the secret event should only be handed out after the pod event was processed
+	start := time.Now()
+	item, shutdown := otherQueue.Get()
+	assert.Equal(t, shutdown, false)
+	assert.Assert(t, time.Since(start) < maxPriorityWait, "expected worker to be woken up when the priority event is done")
+	assert.Equal(t, item, "secret")
+	select {
+	case <-processed:
+	default:
+		t.Fatal("expected priority event to be processed first")
+	}
+	otherQueue.Done(item)
+	assert.Equal(t, lanes.busy(), false)
+}
+
+func TestSyncQueueRateLimit(t *testing.T) {
+	queue := newSyncQueue("secret", workqueue.DefaultControllerRateLimiter(), vclusterconfig.SyncWorkqueueSyncer{QPS: 10, Burst: 1}, false, newPriorityLanes())
+	defer queue.ShutDown()
+
+	queue.Add("a")
+	queue.Add("b")
+	queue.Add("c")
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		item, _ := queue.Get()
+		queue.Done(item)
+	}
+
+	// the first event is processed immediately, the other two are throttled to 10 per second
+	assert.Assert(t, time.Since(start) >= 150*time.Millisecond)
+}
+
+func TestValidatePrioritySyncers(t *testing.T) {
+	registry := &syncerRegistry{}
+	registry.add("pod")
+	registry.add("service")
+
+	assert.NilError(t, registry.validate([]string{"pod", "service"}))
+	assert.ErrorContains(t, registry.validate([]string{"pods"}), `syncer "pods" is not registered, registered syncers are: pod, service`)
+}
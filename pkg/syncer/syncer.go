@@ -7,7 +7,6 @@ import (
 	"time"
 
 	"github.com/loft-sh/vcluster/pkg/config"
-	"github.com/loft-sh/vcluster/pkg/constants"
 	"github.com/loft-sh/vcluster/pkg/scheme"
 	"github.com/loft-sh/vcluster/pkg/syncer/synccontext"
 	syncertypes "github.com/loft-sh/vcluster/pkg/syncer/types"
@@ -17,7 +16,6 @@ import (
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
-	controller2 "sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/loft-sh/vcluster/pkg/util/loghelper"
@@ -60,6 +58,8 @@ func NewSyncController(ctx *synccontext.RegisterContext, syncer syncertypes.Sync
 		virtualClient: ctx.VirtualManager.GetClient(),
 		options:       options,
 
+		syncStatus: !ctx.Config.Experimental.SyncSettings.DisableSyncStatus,
+
 		locker: locker.New(),
 	}, nil
 }
@@ -93,6 +93,8 @@ type SyncController struct {
 	virtualClient client.Client
 	options       *syncertypes.Options
 
+	syncStatus bool
+
 	locker *locker.Locker
 }
 
@@ -169,20 +171,24 @@ func (r *SyncController) Reconcile(ctx context.Context, origReq ctrl.Request) (_
 			return DeleteHostObject(syncContext, pObj, "virtual object uid is different")
 		}
 
-		return r.genericSyncer.Sync(syncContext, &synccontext.SyncEvent[client.Object]{
+		result, syncErr := r.genericSyncer.Sync(syncContext, &synccontext.SyncEvent[client.Object]{
 			Type:   syncEventType,
 			Source: syncEventSource,
 
 			Virtual: vObj,
 			Host:    pObj,
 		})
+		r.updateSyncStatus(syncContext, vObj, types.NamespacedName{Namespace: pObj.GetNamespace(), Name: pObj.GetName()}, true, syncErr)
+		return result, syncErr
 	} else if vObj != nil {
-		return r.genericSyncer.SyncToHost(syncContext, &synccontext.SyncToHostEvent[client.Object]{
+		result, syncErr := r.genericSyncer.SyncToHost(syncContext, &synccontext.SyncToHostEvent[client.Object]{
 			Type:   syncEventType,
 			Source: syncEventSource,
 
 			Virtual: vObj,
 		})
+		r.updateSyncStatus(syncContext, vObj, r.syncer.VirtualToHost(syncContext, vReq.NamespacedName, vObj), false, syncErr)
+		return result, syncErr
 	} else if pObj != nil {
 		if pObj.GetAnnotations() != nil {
 			if shouldSkip, ok := pObj.GetAnnotations()[translate.SkipBackSyncInMultiNamespaceMode]; ok && shouldSkip == "true" {
@@ -318,7 +324,9 @@ func (r *SyncController) excludePhysical(ctx *synccontext.SyncContext, pObj, vOb
 		if !excluderOk && vObj != nil {
 			msg := fmt.Sprintf("conflict: cannot sync virtual object %s/%s as unmanaged physical object %s/%s exists with desired name", vObj.GetNamespace(), vObj.GetName(), pObj.GetNamespace(), pObj.GetName())
 			r.vEventRecorder.Eventf(vObj, "Warning", "SyncError", msg)
-			return false, fmt.Errorf(msg)
+			err := fmt.Errorf(msg)
+			r.updateSyncStatus(ctx, vObj, types.NamespacedName{Namespace: pObj.GetNamespace(), Name: pObj.GetName()}, false, err)
+			return false, err
 		}
 
 		return true, nil
@@ -458,10 +466,7 @@ func (r *SyncController) enqueuePhysical(ctx context.Context, obj client.Object,
 func (r *SyncController) Register(ctx *synccontext.RegisterContext) error {
 	// build the basic controller
 	controller := ctrl.NewControllerManagedBy(ctx.VirtualManager).
-		WithOptions(controller2.Options{
-			MaxConcurrentReconciles: 10,
-			CacheSyncTimeout:        constants.DefaultCacheSyncTimeout,
-		}).
+		WithOptions(newControllerOptions(ctx.Config, r.syncer.Name())).
 		Named(r.syncer.Name()).
 		Watches(r.syncer.Resource(), newEventHandler(r.enqueueVirtual)).
 		WatchesRawSource(source.Kind(ctx.PhysicalManager.GetCache(), r.syncer.Resource(), newEventHandler(r.enqueuePhysical)))
@@ -493,13 +498,23 @@ func CreateHostObject(ctx *synccontext.SyncContext, vObj, pObj client.Object, ev
 			return ctrl.Result{RequeueAfter: time.Second}, nil
 		}
 		ctx.Log.Infof("error syncing %s %s/%s to host cluster: %v", gvk.Kind, vObj.GetNamespace(), vObj.GetName(), err)
-		eventRecorder.Eventf(vObj, "Warning", "SyncError", "Error syncing to host cluster: %v", err)
+		if isExceededQuotaError(err) {
+			eventRecorder.Eventf(vObj, "Warning", "ExceededQuota", "Host cluster rejected %s: %v", gvk.Kind, err)
+		} else {
+			eventRecorder.Eventf(vObj, "Warning", "SyncError", "Error syncing to host cluster: %v", err)
+		}
 		return ctrl.Result{}, err
 	}
 
 	return ctrl.Result{}, nil
 }
 
+// isExceededQuotaError checks if the host api server rejected the object because it would exceed
+// a resource quota of the host namespace
+func isExceededQuotaError(err error) bool {
+	return kerrors.IsForbidden(err) && strings.Contains(err.Error(), "exceeded quota")
+}
+
 func DeleteHostObject(ctx *synccontext.SyncContext, obj client.Object, reason string) (ctrl.Result, error) {
 	return deleteObject(ctx, obj, reason, false)
 }
@@ -2,6 +2,7 @@ package syncer
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"sort"
 	"testing"
@@ -18,6 +19,7 @@ import (
 	"github.com/moby/locker"
 	"gotest.tools/v3/assert"
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -66,7 +68,7 @@ func (s *mockSyncer) Sync(ctx *synccontext.SyncContext, event *synccontext.SyncE
 	newPObj := event.Host.DeepCopyObject().(client.Object)
 	newPObj.SetAnnotations(translate.HostAnnotations(event.Virtual, event.Host))
 	newPObj.SetLabels(translate.HostLabels(ctx, event.Virtual, event.Host))
-	return ctrl.Result{}, ctx.VirtualClient.Update(ctx, newPObj)
+	return ctrl.Result{}, ctx.PhysicalClient.Update(ctx, newPObj)
 }
 
 func (s *mockSyncer) SyncToVirtual(ctx *synccontext.SyncContext, event *synccontext.SyncToVirtualEvent[*corev1.Secret]) (_ ctrl.Result, retErr error) {
@@ -334,3 +336,136 @@ func TestReconcile(t *testing.T) {
 		}
 	}
 }
+
+func TestSyncStatus(t *testing.T) {
+	vSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "a",
+			Namespace: namespaceInVclusterA,
+			UID:       "123",
+		},
+	}
+	hostName := translate.NewSingleNamespaceTranslator(vclusterNamespace).HostName("a", namespaceInVclusterA)
+
+	failedStatus, err := json.Marshal(&syncertypes.SyncStatus{Phase: syncertypes.SyncStatusPhaseFailed, LastError: "previous error"})
+	assert.NilError(t, err)
+
+	testCases := []struct {
+		Name string
+
+		InitialVirtualAnnotations map[string]string
+		InitialPhysicalState      []runtime.Object
+		Reconciles                int
+
+		ExpectedPhase    syncertypes.SyncStatusPhase
+		ExpectedErrorMsg string
+	}{
+		{
+			Name: "should not set a status after sync down",
+		},
+		{
+			Name:          "should set synced once the host object exists",
+			Reconciles:    2,
+			ExpectedPhase: syncertypes.SyncStatusPhaseSynced,
+		},
+		{
+			Name:                      "should set pending after sync down if there was a status",
+			InitialVirtualAnnotations: map[string]string{translate.SyncStatusAnnotation: string(failedStatus)},
+			ExpectedPhase:             syncertypes.SyncStatusPhasePending,
+		},
+		{
+			Name: "should set failed if host object is not managed",
+			InitialPhysicalState: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      hostName,
+						Namespace: vclusterNamespace,
+					},
+				},
+			},
+			ExpectedPhase:    syncertypes.SyncStatusPhaseFailed,
+			ExpectedErrorMsg: "conflict: cannot sync virtual object default/a as unmanaged physical object",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			ctx := context.Background()
+			pClient := testingutil.NewFakeClient(scheme.Scheme, tc.InitialPhysicalState...)
+			initialSecret := vSecret.DeepCopy()
+			initialSecret.Annotations = tc.InitialVirtualAnnotations
+			vClient := testingutil.NewFakeClient(scheme.Scheme, initialSecret)
+
+			fakeContext := syncertesting.NewFakeRegisterContext(syncertesting.NewFakeConfig(), pClient, vClient)
+			syncerImpl, err := NewMockSyncer(fakeContext)
+			assert.NilError(t, err)
+			syncer := syncerImpl.(syncertypes.Syncer)
+
+			controller := &SyncController{
+				syncer: syncer,
+
+				genericSyncer: syncer.Syncer(),
+
+				log:            loghelper.New(syncer.Name()),
+				vEventRecorder: &testingutil.FakeEventRecorder{},
+				physicalClient: pClient,
+
+				currentNamespace:       fakeContext.CurrentNamespace,
+				currentNamespaceClient: fakeContext.CurrentNamespaceClient,
+
+				mappings: fakeContext.Mappings,
+
+				virtualClient: vClient,
+				options:       &syncertypes.Options{},
+
+				syncStatus: true,
+
+				locker: locker.New(),
+			}
+
+			for i := 0; i < max(tc.Reconciles, 1); i++ {
+				_, err = controller.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: vSecret.Name, Namespace: vSecret.Namespace}})
+			}
+			if tc.ExpectedErrorMsg != "" {
+				assert.ErrorContains(t, err, tc.ExpectedErrorMsg)
+			} else {
+				assert.NilError(t, err)
+			}
+
+			updatedSecret := &corev1.Secret{}
+			err = vClient.Get(ctx, types.NamespacedName{Name: vSecret.Name, Namespace: vSecret.Namespace}, updatedSecret)
+			assert.NilError(t, err)
+
+			syncStatus, err := GetSyncStatus(updatedSecret)
+			assert.NilError(t, err)
+			if tc.ExpectedPhase == "" {
+				assert.Assert(t, syncStatus == nil)
+			} else {
+				assert.Assert(t, syncStatus != nil)
+				assert.Equal(t, syncStatus.Phase, tc.ExpectedPhase)
+				assert.Equal(t, syncStatus.HostName, vclusterNamespace+"/"+hostName)
+				assert.Assert(t, !syncStatus.LastTransitionTime.IsZero())
+				if tc.ExpectedErrorMsg != "" {
+					assert.ErrorContains(t, errors.New(syncStatus.LastError), tc.ExpectedErrorMsg)
+				} else {
+					assert.Equal(t, syncStatus.LastError, "")
+				}
+			}
+
+			// the sync status should never be synced to the host object
+			hostSecret := &corev1.Secret{}
+			err = pClient.Get(ctx, types.NamespacedName{Name: hostName, Namespace: vclusterNamespace}, hostSecret)
+			assert.NilError(t, err)
+			_, ok := hostSecret.Annotations[translate.SyncStatusAnnotation]
+			assert.Assert(t, !ok)
+		})
+	}
+}
+
+func TestIsExceededQuotaError(t *testing.T) {
+	podsResource := schema.GroupResource{Resource: "pods"}
+
+	assert.Assert(t, isExceededQuotaError(kerrors.NewForbidden(podsResource, "a", errors.New("exceeded quota: vc-my-vcluster, requested: pods=1, used: pods=10, limited: pods=10"))))
+	assert.Assert(t, !isExceededQuotaError(kerrors.NewForbidden(podsResource, "a", errors.New("violates PodSecurity"))))
+	assert.Assert(t, !isExceededQuotaError(errors.New("exceeded quota")))
+}
@@ -0,0 +1,92 @@
+package syncer
+
+import (
+	"encoding/json"
+
+	"github.com/loft-sh/vcluster/pkg/syncer/synccontext"
+	syncertypes "github.com/loft-sh/vcluster/pkg/syncer/types"
+	"github.com/loft-sh/vcluster/pkg/util/translate"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetSyncStatus returns the sync status written by the syncer to the virtual object or nil if there is none
+func GetSyncStatus(vObj client.Object) (*syncertypes.SyncStatus, error) {
+	raw := vObj.GetAnnotations()[translate.SyncStatusAnnotation]
+	if raw == "" {
+		return nil, nil
+	}
+
+	return syncertypes.ParseSyncStatus(raw)
+}
+
+// updateSyncStatus writes the result of the last sync to the sync status annotation of the virtual object.
+// The annotation is only written if the sync failed, the host object exists or the object already has a status,
+// so that objects that are never synced to the host are not annotated. It is only patched if the status has
+// changed, to avoid triggering unnecessary reconciles.
+func (r *SyncController) updateSyncStatus(ctx *synccontext.SyncContext, vObj client.Object, hostName types.NamespacedName, hostExists bool, syncErr error) {
+	if !r.syncStatus || vObj == nil || vObj.GetDeletionTimestamp() != nil {
+		return
+	} else if syncErr != nil && kerrors.IsConflict(syncErr) {
+		// conflicts are retried right away and are not interesting for the user
+		return
+	} else if syncErr == nil && !hostExists && vObj.GetAnnotations()[translate.SyncStatusAnnotation] == "" {
+		return
+	}
+
+	oldStatus, err := GetSyncStatus(vObj)
+	if err != nil || oldStatus == nil {
+		oldStatus = &syncertypes.SyncStatus{}
+	}
+
+	newStatus := &syncertypes.SyncStatus{
+		Phase:                syncertypes.SyncStatusPhasePending,
+		HostName:             hostName.Name,
+		UID:                  vObj.GetUID(),
+		LastSyncedGeneration: oldStatus.LastSyncedGeneration,
+		LastTransitionTime:   oldStatus.LastTransitionTime,
+	}
+	if hostName.Namespace != "" {
+		newStatus.HostName = hostName.Namespace + "/" + hostName.Name
+	}
+	if syncErr != nil {
+		newStatus.Phase = syncertypes.SyncStatusPhaseFailed
+		newStatus.LastError = syncErr.Error()
+	} else if hostExists {
+		newStatus.Phase = syncertypes.SyncStatusPhaseSynced
+		newStatus.LastSyncedGeneration = vObj.GetGeneration()
+	}
+	if newStatus.Phase != oldStatus.Phase {
+		newStatus.LastTransitionTime = metav1.Now().Rfc3339Copy()
+	}
+
+	// check if there is anything to update
+	if *newStatus == *oldStatus {
+		return
+	}
+
+	rawStatus, err := json.Marshal(newStatus)
+	if err != nil {
+		ctx.Log.Infof("error encoding sync status of %s/%s: %v", vObj.GetNamespace(), vObj.GetName(), err)
+		return
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				translate.SyncStatusAnnotation: string(rawStatus),
+			},
+		},
+	})
+	if err != nil {
+		ctx.Log.Infof("error encoding sync status patch of %s/%s: %v", vObj.GetNamespace(), vObj.GetName(), err)
+		return
+	}
+
+	err = ctx.VirtualClient.Patch(ctx, vObj, client.RawPatch(types.MergePatchType, patch))
+	if err != nil && !kerrors.IsNotFound(err) {
+		ctx.Log.Infof("error updating sync status of %s/%s: %v", vObj.GetNamespace(), vObj.GetName(), err)
+	}
+}
@@ -69,6 +69,9 @@ func NewFakeRegisterContext(vConfig *config.VirtualClusterConfig, pClient *testi
 	util.EnsureCRD = func(_ context.Context, _ *rest.Config, _ []byte, _ schema.GroupVersionKind) error {
 		return nil
 	}
+	translate.EnsureCRDFromPhysicalCluster = func(_ context.Context, _ *rest.Config, _ *rest.Config, _ schema.GroupVersionKind) (bool, bool, error) {
+		return false, true, nil
+	}
 
 	resources.MustRegisterMappings(registerCtx)
 	return registerCtx
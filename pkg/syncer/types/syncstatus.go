@@ -0,0 +1,54 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type SyncStatusPhase string
+
+const (
+	// SyncStatusPhaseSynced means the virtual object was successfully synced to the host cluster
+	SyncStatusPhaseSynced SyncStatusPhase = "Synced"
+	// SyncStatusPhasePending means the syncer is waiting for something before it can sync the object
+	SyncStatusPhasePending SyncStatusPhase = "Pending"
+	// SyncStatusPhaseFailed means the last sync of the virtual object failed
+	SyncStatusPhaseFailed SyncStatusPhase = "Failed"
+)
+
+// SyncStatus is written as json to the vcluster.loft.sh/sync-status annotation of virtual objects
+// by the syncer framework to allow users within the virtual cluster to see why an object wasn't synced.
+type SyncStatus struct {
+	// Phase is the phase of the last sync
+	Phase SyncStatusPhase `json:"phase"`
+
+	// HostName is the namespace/name of the object in the host cluster
+	HostName string `json:"hostName,omitempty"`
+
+	// UID is the uid of the virtual object the status was written for. It prevents copies of the
+	// virtual object from inheriting the host name.
+	UID types.UID `json:"uid,omitempty"`
+
+	// LastSyncedGeneration is the generation of the virtual object that was last synced successfully
+	LastSyncedGeneration int64 `json:"lastSyncedGeneration,omitempty"`
+
+	// LastError is the error of the last failed sync
+	LastError string `json:"lastError,omitempty"`
+
+	// LastTransitionTime is the last time the phase changed
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// ParseSyncStatus parses the value of the sync status annotation
+func ParseSyncStatus(raw string) (*SyncStatus, error) {
+	syncStatus := &SyncStatus{}
+	err := json.Unmarshal([]byte(raw), syncStatus)
+	if err != nil {
+		return nil, fmt.Errorf("parse sync status: %w", err)
+	}
+
+	return syncStatus, nil
+}
@@ -1,27 +1,61 @@
 package translate
 
 import (
+	"bytes"
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"regexp"
 	"strings"
+	"text/template"
 
 	"github.com/loft-sh/vcluster/pkg/scheme"
 	"github.com/loft-sh/vcluster/pkg/syncer/synccontext"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 )
 
 var _ Translator = &multiNamespace{}
 
-func NewMultiNamespaceTranslator(currentNamespace string) Translator {
-	return &multiNamespace{
+const (
+	namespacePlaceholder = "\x00namespace\x00"
+	hashPlaceholder      = "\x00hash\x00"
+)
+
+// NewMultiNamespaceTranslator creates a translator that maps each virtual namespace to its own host namespace. The
+// host namespace name is rendered from the given go template, if empty the default hashed naming scheme is used.
+func NewMultiNamespaceTranslator(currentNamespace, hostNameTemplate string) (Translator, error) {
+	translator := &multiNamespace{
 		currentNamespace: currentNamespace,
 	}
+	if hostNameTemplate == "" {
+		return translator, nil
+	}
+
+	var err error
+	translator.hostNameTemplate, err = template.New("hostNameTemplate").Option("missingkey=error").Parse(hostNameTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("parse host namespace name template: %w", err)
+	}
+
+	translator.hostNameRegex, err = translator.compileHostNameRegex()
+	if err != nil {
+		return nil, fmt.Errorf("invalid host namespace name template %q: %w", hostNameTemplate, err)
+	}
+
+	return translator, nil
 }
 
 type multiNamespace struct {
 	currentNamespace string
+
+	hostNameTemplate *template.Template
+	hostNameRegex    *regexp.Regexp
 }
 
 func (s *multiNamespace) SingleNamespaceTarget() bool {
@@ -45,7 +79,7 @@ func (s *multiNamespace) HostNameCluster(name string) string {
 	return SafeConcatName("vcluster", name, "x", s.currentNamespace, "x", VClusterName)
 }
 
-func (s *multiNamespace) IsManaged(_ *synccontext.SyncContext, pObj client.Object) bool {
+func (s *multiNamespace) IsManaged(ctx *synccontext.SyncContext, pObj client.Object) bool {
 	// check if cluster scoped object
 	if pObj.GetNamespace() == "" {
 		return pObj.GetLabels()[MarkerLabel] == s.MarkerLabelCluster()
@@ -55,7 +89,7 @@ func (s *multiNamespace) IsManaged(_ *synccontext.SyncContext, pObj client.Objec
 	// If obj is not in the synced namespace OR
 	// If object-name annotation is not set OR
 	// If object-name annotation is different from actual name
-	if !s.IsTargetedNamespace(pObj.GetNamespace()) || pObj.GetAnnotations()[NameAnnotation] == "" {
+	if !s.IsTargetedNamespace(ctx, pObj.GetNamespace()) || pObj.GetAnnotations()[NameAnnotation] == "" {
 		return false
 	} else if pObj.GetAnnotations()[KindAnnotation] != "" {
 		gvk, err := apiutil.GVKForObject(pObj, scheme.Scheme)
@@ -67,8 +101,31 @@ func (s *multiNamespace) IsManaged(_ *synccontext.SyncContext, pObj client.Objec
 	return true
 }
 
-func (s *multiNamespace) IsTargetedNamespace(ns string) bool {
-	return strings.HasPrefix(ns, s.getNamespacePrefix()) && strings.HasSuffix(ns, getNamespaceSuffix(s.currentNamespace, VClusterName))
+func (s *multiNamespace) IsTargetedNamespace(ctx *synccontext.SyncContext, ns string) bool {
+	if s.hostNameRegex == nil {
+		return strings.HasPrefix(ns, s.getNamespacePrefix()) && strings.HasSuffix(ns, getNamespaceSuffix(s.currentNamespace, VClusterName))
+	} else if !s.hostNameRegex.MatchString(ns) {
+		return false
+	}
+
+	// templates might also match host namespaces of other vClusters, e.g. {{.vcluster}}-{{.namespace}} renders
+	// a-b-default for vCluster a-b, which also matches the template of vCluster a. So make sure the host namespace
+	// was created by this vCluster.
+	if ctx == nil || ctx.PhysicalClient == nil {
+		return false
+	}
+
+	pNamespace := &corev1.Namespace{}
+	err := ctx.PhysicalClient.Get(ctx, types.NamespacedName{Name: ns}, pNamespace)
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			klog.Errorf("error retrieving host namespace %s: %v", ns, err)
+		}
+
+		return false
+	}
+
+	return pNamespace.Labels[VClusterNameLabel] == VClusterName && pNamespace.Labels[VClusterNamespaceLabel] == s.currentNamespace
 }
 
 func (s *multiNamespace) getNamespacePrefix() string {
@@ -76,12 +133,77 @@ func (s *multiNamespace) getNamespacePrefix() string {
 }
 
 func (s *multiNamespace) HostNamespace(vNamespace string) string {
+	if s.hostNameTemplate != nil {
+		pNamespace, err := s.renderHostName(vNamespace, namespaceHash(vNamespace))
+		if err == nil {
+			return pNamespace
+		}
+
+		// the template was validated on creation, so this should never happen
+		klog.Errorf("error rendering host namespace name for %s: %v", vNamespace, err)
+	}
+
 	return hostNamespace(s.currentNamespace, vNamespace, s.getNamespacePrefix(), VClusterName)
 }
 
-func hostNamespace(currentNamespace, vNamespace, prefix, suffix string) string {
+func (s *multiNamespace) renderHostName(vNamespace, hash string) (string, error) {
+	buf := &bytes.Buffer{}
+	err := s.hostNameTemplate.Execute(buf, map[string]string{
+		"vcluster":          VClusterName,
+		"vclusterNamespace": s.currentNamespace,
+		"namespace":         vNamespace,
+		"hash":              hash,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// compileHostNameRegex renders the template with placeholders for the namespace and hash to build a regex
+// that matches all host namespaces created by this translator. It also checks that the template renders
+// valid and unique host namespace names.
+func (s *multiNamespace) compileHostNameRegex() (*regexp.Regexp, error) {
+	rendered, err := s.renderHostName(namespacePlaceholder, hashPlaceholder)
+	if err != nil {
+		return nil, err
+	} else if !strings.Contains(rendered, namespacePlaceholder) && !strings.Contains(rendered, hashPlaceholder) {
+		return nil, fmt.Errorf("template needs to reference .namespace or .hash")
+	} else if strings.ReplaceAll(strings.ReplaceAll(rendered, namespacePlaceholder, ""), hashPlaceholder, "") == "" {
+		return nil, fmt.Errorf("template needs to contain more than the virtual namespace name, as otherwise all host namespaces would be managed by vCluster")
+	}
+
+	expr := regexp.QuoteMeta(rendered)
+	expr = strings.ReplaceAll(expr, namespacePlaceholder, "[a-z0-9](?:[-a-z0-9]*[a-z0-9])?")
+	expr = strings.ReplaceAll(expr, hashPlaceholder, "[a-f0-9]{8}")
+	regex, err := regexp.Compile("^" + expr + "$")
+	if err != nil {
+		return nil, err
+	}
+
+	// check that the template does not alter the namespace and produces valid names
+	for _, vNamespace := range []string{"default", "kube-system"} {
+		pNamespace, err := s.renderHostName(vNamespace, namespaceHash(vNamespace))
+		if err != nil {
+			return nil, err
+		} else if errs := validation.IsDNS1123Label(pNamespace); len(errs) > 0 {
+			return nil, fmt.Errorf("rendered host namespace %q is invalid: %s", pNamespace, strings.Join(errs, ", "))
+		} else if !regex.MatchString(pNamespace) {
+			return nil, fmt.Errorf("template needs to use .namespace and .hash unmodified")
+		}
+	}
+
+	return regex, nil
+}
+
+func namespaceHash(vNamespace string) string {
 	sha := sha256.Sum256([]byte(vNamespace))
-	return fmt.Sprintf("%s-%s-%s", prefix, hex.EncodeToString(sha[0:])[0:8], getNamespaceSuffix(currentNamespace, suffix))
+	return hex.EncodeToString(sha[0:])[0:8]
+}
+
+func hostNamespace(currentNamespace, vNamespace, prefix, suffix string) string {
+	return fmt.Sprintf("%s-%s-%s", prefix, namespaceHash(vNamespace), getNamespaceSuffix(currentNamespace, suffix))
 }
 
 func getNamespaceSuffix(currentNamespace, suffix string) string {
@@ -0,0 +1,101 @@
+package translate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/loft-sh/vcluster/pkg/scheme"
+	"github.com/loft-sh/vcluster/pkg/syncer/synccontext"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestMultiNamespaceHostNameTemplate(t *testing.T) {
+	VClusterName = "my-vcluster"
+
+	translator, err := NewMultiNamespaceTranslator("vcluster-ns", "")
+	assert.NilError(t, err)
+	assert.Equal(t, translator.HostNamespace("default"), hostNamespace("vcluster-ns", "default", "vcluster", VClusterName))
+	assert.Assert(t, translator.IsTargetedNamespace(nil, translator.HostNamespace("default")))
+
+	newNamespace := func(name, vClusterName string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{VClusterNameLabel: vClusterName, VClusterNamespaceLabel: "vcluster-ns"}}}
+	}
+	ctx := &synccontext.SyncContext{
+		Context: context.Background(),
+		PhysicalClient: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+			newNamespace("my-vcluster-default", "my-vcluster"),
+			newNamespace("my-vcluster-kube-system", "my-vcluster"),
+			newNamespace("my-vcluster-b-default", "my-vcluster-b"),
+			newNamespace("vcluster-ns-"+namespaceHash("default"), "my-vcluster"),
+		).Build(),
+	}
+
+	translator, err = NewMultiNamespaceTranslator("vcluster-ns", "{{.vcluster}}-{{.namespace}}")
+	assert.NilError(t, err)
+	assert.Equal(t, translator.HostNamespace("default"), "my-vcluster-default")
+	assert.Assert(t, translator.IsTargetedNamespace(ctx, "my-vcluster-default"))
+	assert.Assert(t, translator.IsTargetedNamespace(ctx, "my-vcluster-kube-system"))
+	assert.Assert(t, !translator.IsTargetedNamespace(ctx, "other-default"))
+	assert.Assert(t, !translator.IsTargetedNamespace(ctx, "my-vcluster-"))
+
+	// namespaces matching the template that belong to another vCluster or do not exist are not targeted
+	assert.Assert(t, !translator.IsTargetedNamespace(ctx, "my-vcluster-b-default"))
+	assert.Assert(t, !translator.IsTargetedNamespace(ctx, "my-vcluster-other"))
+	assert.Assert(t, !translator.IsTargetedNamespace(nil, "my-vcluster-default"))
+
+	translator, err = NewMultiNamespaceTranslator("vcluster-ns", "{{.vclusterNamespace}}-{{.hash}}")
+	assert.NilError(t, err)
+	assert.Equal(t, translator.HostNamespace("default"), "vcluster-ns-"+namespaceHash("default"))
+	assert.Assert(t, translator.IsTargetedNamespace(ctx, translator.HostNamespace("default")))
+	assert.Assert(t, !translator.IsTargetedNamespace(ctx, "vcluster-ns-default"))
+}
+
+func TestMultiNamespaceInvalidHostNameTemplate(t *testing.T) {
+	VClusterName = "my-vcluster"
+
+	testCases := []struct {
+		name     string
+		template string
+		err      string
+	}{
+		{
+			name:     "invalid syntax",
+			template: "{{.vcluster",
+			err:      "parse host namespace name template",
+		},
+		{
+			name:     "unknown variable",
+			template: "{{.cluster}}-{{.namespace}}",
+			err:      `map has no entry for key "cluster"`,
+		},
+		{
+			name:     "no namespace",
+			template: "{{.vcluster}}",
+			err:      "template needs to reference .namespace or .hash",
+		},
+		{
+			name:     "only namespace",
+			template: "{{.namespace}}",
+			err:      "template needs to contain more than the virtual namespace name",
+		},
+		{
+			name:     "modified namespace",
+			template: `{{if eq .namespace "default"}}a{{else}}b{{end}}-{{.namespace}}`,
+			err:      "template needs to use .namespace and .hash unmodified",
+		},
+		{
+			name:     "invalid name",
+			template: "{{.vcluster}}_{{.namespace}}",
+			err:      `rendered host namespace "my-vcluster_default" is invalid`,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := NewMultiNamespaceTranslator("vcluster-ns", testCase.template)
+			assert.ErrorContains(t, err, testCase.err)
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package translate
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"text/template"
+
+	syncertypes "github.com/loft-sh/vcluster/pkg/syncer/types"
+	"github.com/loft-sh/vcluster/pkg/util/base36"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	NamingStrategyDefault  = "default"
+	NamingStrategyHash     = "hash"
+	NamingStrategyTemplate = "template"
+)
+
+// NamingStrategy translates the name of a namespaced virtual object into its host name within
+// the single host namespace all objects are synced to
+type NamingStrategy interface {
+	// HostName returns the host name for the given virtual name and namespace
+	HostName(vName, vNamespace string) string
+}
+
+// NewNamingStrategy creates the naming strategy with the given name
+func NewNamingStrategy(strategy, nameTemplate string) (NamingStrategy, error) {
+	switch strategy {
+	case "", NamingStrategyDefault:
+		return defaultNamingStrategy{}, nil
+	case NamingStrategyHash:
+		return hashNamingStrategy{}, nil
+	case NamingStrategyTemplate:
+		return newTemplateNamingStrategy(nameTemplate)
+	}
+
+	return nil, fmt.Errorf("unknown naming strategy %q, must be one of: %s, %s, %s", strategy, NamingStrategyDefault, NamingStrategyHash, NamingStrategyTemplate)
+}
+
+// RecordedHostName returns the host name the syncer recorded in the sync status of the virtual object. Host objects
+// keep this name even if the naming strategy is changed afterwards. The sync status can be written by anyone who can
+// update the virtual object, so the returned name must only be used after checking the host object with IsHostObjectOf.
+func RecordedHostName(vObj client.Object) (types.NamespacedName, bool) {
+	raw := vObj.GetAnnotations()[SyncStatusAnnotation]
+	if raw == "" {
+		return types.NamespacedName{}, false
+	}
+
+	syncStatus, err := syncertypes.ParseSyncStatus(raw)
+	if err != nil || syncStatus.HostName == "" || syncStatus.UID == "" || syncStatus.UID != vObj.GetUID() {
+		return types.NamespacedName{}, false
+	}
+
+	namespace, name, found := strings.Cut(syncStatus.HostName, "/")
+	if !found {
+		return types.NamespacedName{Name: namespace}, true
+	}
+
+	return types.NamespacedName{Namespace: namespace, Name: name}, true
+}
+
+// IsHostObjectOf returns true if the host object was synced by this vCluster from the given virtual object, which
+// is only known from the marker label and the name annotations the syncer sets on the host object.
+func IsHostObjectOf(pObj, vObj client.Object) bool {
+	if pObj.GetLabels()[MarkerLabel] != VClusterName {
+		return false
+	}
+
+	annotations := pObj.GetAnnotations()
+	if annotations[NameAnnotation] != vObj.GetName() || annotations[NamespaceAnnotation] != vObj.GetNamespace() {
+		return false
+	} else if annotations[UIDAnnotation] != "" && annotations[UIDAnnotation] != string(vObj.GetUID()) {
+		return false
+	}
+
+	return true
+}
+
+// defaultNamingStrategy concatenates name, namespace and vCluster name
+type defaultNamingStrategy struct{}
+
+func (defaultNamingStrategy) HostName(vName, vNamespace string) string {
+	return SingleNamespaceHostName(vName, vNamespace, VClusterName)
+}
+
+// hashNamingStrategy only uses a hash of name, namespace and vCluster name, which never needs to be shortened
+type hashNamingStrategy struct{}
+
+func (hashNamingStrategy) HostName(vName, vNamespace string) string {
+	if vName == "" {
+		return ""
+	}
+
+	digest := sha256.Sum256([]byte(strings.Join([]string{vName, "x", vNamespace, "x", VClusterName}, "-")))
+	return "vc-" + base36.EncodeBytes(digest[:])[0:20]
+}
+
+// templateNamingStrategy renders the host name from a user defined go template
+type templateNamingStrategy struct {
+	template *template.Template
+}
+
+func newTemplateNamingStrategy(nameTemplate string) (NamingStrategy, error) {
+	if nameTemplate == "" {
+		return nil, fmt.Errorf("template is required for naming strategy %q", NamingStrategyTemplate)
+	}
+
+	parsedTemplate, err := template.New("nameTemplate").Option("missingkey=error").Parse(nameTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("parse name template: %w", err)
+	}
+
+	strategy := &templateNamingStrategy{template: parsedTemplate}
+	first, err := strategy.execute("a", "b", "0a1b2c3d")
+	if err != nil {
+		return nil, fmt.Errorf("render name template: %w", err)
+	} else if errs := validation.IsDNS1123Label(first); len(errs) > 0 {
+		return nil, fmt.Errorf("rendered host name %q is invalid: %s", first, strings.Join(errs, ", "))
+	}
+
+	// templates such as {{.namespace}}-{{.name}} map different objects to the same host name, e.g. a-b/c and a/b-c,
+	// and collide with other vClusters in the same host namespace, so the hash has to be part of the name
+	rendered, err := strategy.execute("a", "b", "4e5f6a7b")
+	if err != nil {
+		return nil, fmt.Errorf("render name template: %w", err)
+	} else if rendered == first {
+		return nil, fmt.Errorf("name template needs to reference .hash")
+	}
+
+	return strategy, nil
+}
+
+func (s *templateNamingStrategy) HostName(vName, vNamespace string) string {
+	if vName == "" {
+		return ""
+	}
+
+	hostName, err := s.render(vName, vNamespace)
+	if err != nil {
+		// the template was validated on creation, so this should never happen
+		return SingleNamespaceHostName(vName, vNamespace, VClusterName)
+	}
+
+	return SafeConcatName(hostName)
+}
+
+func (s *templateNamingStrategy) render(vName, vNamespace string) (string, error) {
+	digest := sha256.Sum256([]byte(strings.Join([]string{vName, "x", vNamespace, "x", VClusterName}, "-")))
+	return s.execute(vName, vNamespace, hex.EncodeToString(digest[:])[0:8])
+}
+
+func (s *templateNamingStrategy) execute(vName, vNamespace, hash string) (string, error) {
+	buf := &bytes.Buffer{}
+	err := s.template.Execute(buf, map[string]string{
+		"name":      vName,
+		"namespace": vNamespace,
+		"vcluster":  VClusterName,
+		"hash":      hash,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(buf.String()), nil
+}
@@ -0,0 +1,113 @@
+package translate
+
+import (
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestNamingStrategy(t *testing.T) {
+	VClusterName = "my-vcluster"
+
+	namingStrategy, err := NewNamingStrategy("", "")
+	assert.NilError(t, err)
+	assert.Equal(t, namingStrategy.HostName("nginx", "default"), "nginx-x-default-x-my-vcluster")
+
+	namingStrategy, err = NewNamingStrategy(NamingStrategyHash, "")
+	assert.NilError(t, err)
+	assert.Equal(t, len(namingStrategy.HostName("nginx", "default")), 23)
+	assert.Assert(t, namingStrategy.HostName("nginx", "default") != namingStrategy.HostName("nginx", "other"))
+
+	namingStrategy, err = NewNamingStrategy(NamingStrategyTemplate, "{{.namespace}}-{{.name}}-{{.hash}}")
+	assert.NilError(t, err)
+	assert.Assert(t, strings.HasPrefix(namingStrategy.HostName("nginx", "default"), "default-nginx-"))
+	assert.Assert(t, namingStrategy.HostName("a-b", "c") != namingStrategy.HostName("a", "b-c"))
+	assert.Equal(t, len(namingStrategy.HostName("a-very-long-name-that-does-not-fit-into-a-label", "default-namespace")), 63)
+	assert.Equal(t, namingStrategy.HostName("", "default"), "")
+}
+
+func TestInvalidNamingStrategy(t *testing.T) {
+	testCases := []struct {
+		name     string
+		strategy string
+		template string
+		err      string
+	}{
+		{
+			name:     "unknown strategy",
+			strategy: "random",
+			err:      `unknown naming strategy "random"`,
+		},
+		{
+			name:     "missing template",
+			strategy: NamingStrategyTemplate,
+			err:      "template is required",
+		},
+		{
+			name:     "unknown variable",
+			strategy: NamingStrategyTemplate,
+			template: "{{.namespace}}-{{.object}}-{{.hash}}",
+			err:      `map has no entry for key "object"`,
+		},
+		{
+			name:     "missing hash",
+			strategy: NamingStrategyTemplate,
+			template: "{{.namespace}}-{{.name}}",
+			err:      "name template needs to reference .hash",
+		},
+		{
+			name:     "invalid name",
+			strategy: NamingStrategyTemplate,
+			template: "{{.namespace}}_{{.name}}-{{.hash}}",
+			err:      `rendered host name "b_a-0a1b2c3d" is invalid`,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := NewNamingStrategy(testCase.strategy, testCase.template)
+			assert.ErrorContains(t, err, testCase.err)
+		})
+	}
+}
+
+func TestRecordedHostName(t *testing.T) {
+	vObj := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		Name:        "nginx",
+		Namespace:   "default",
+		UID:         "123",
+		Annotations: map[string]string{SyncStatusAnnotation: `{"phase":"Synced","hostName":"test/nginx-x-default-x-my-vcluster","uid":"123"}`},
+	}}
+
+	hostName, ok := RecordedHostName(vObj)
+	assert.Assert(t, ok)
+	assert.Equal(t, hostName, types.NamespacedName{Namespace: "test", Name: "nginx-x-default-x-my-vcluster"})
+
+	// copies of the object do not inherit the host name
+	vObj.UID = "456"
+	_, ok = RecordedHostName(vObj)
+	assert.Assert(t, !ok)
+}
+
+func TestIsHostObjectOf(t *testing.T) {
+	vObj := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "default", UID: "123"}}
+	pObj := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Name:        "nginx-x-default-x-my-vcluster",
+		Labels:      map[string]string{MarkerLabel: VClusterName},
+		Annotations: map[string]string{NameAnnotation: "nginx", NamespaceAnnotation: "default"},
+	}}
+	assert.Assert(t, IsHostObjectOf(pObj, vObj))
+
+	// objects of other virtual objects
+	assert.Assert(t, !IsHostObjectOf(pObj, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "other", UID: "123"}}))
+
+	// objects with a different virtual uid
+	pObj.Annotations[UIDAnnotation] = "456"
+	assert.Assert(t, !IsHostObjectOf(pObj, vObj))
+
+	// objects not synced by the vCluster
+	assert.Assert(t, !IsHostObjectOf(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "certs"}}, vObj))
+}
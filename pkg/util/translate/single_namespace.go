@@ -18,13 +18,20 @@ import (
 var _ Translator = &singleNamespace{}
 
 func NewSingleNamespaceTranslator(targetNamespace string) Translator {
+	return NewSingleNamespaceTranslatorWithNamingStrategy(targetNamespace, defaultNamingStrategy{})
+}
+
+// NewSingleNamespaceTranslatorWithNamingStrategy creates a translator that uses the given naming strategy for host names
+func NewSingleNamespaceTranslatorWithNamingStrategy(targetNamespace string, namingStrategy NamingStrategy) Translator {
 	return &singleNamespace{
 		targetNamespace: targetNamespace,
+		namingStrategy:  namingStrategy,
 	}
 }
 
 type singleNamespace struct {
 	targetNamespace string
+	namingStrategy  NamingStrategy
 }
 
 func (s *singleNamespace) SingleNamespaceTarget() bool {
@@ -32,7 +39,11 @@ func (s *singleNamespace) SingleNamespaceTarget() bool {
 }
 
 func (s *singleNamespace) HostName(name, namespace string) string {
-	return SingleNamespaceHostName(name, namespace, VClusterName)
+	if s.namingStrategy == nil {
+		return SingleNamespaceHostName(name, namespace, VClusterName)
+	}
+
+	return s.namingStrategy.HostName(name, namespace)
 }
 
 func (s *singleNamespace) HostNameShort(name, namespace string) string {
@@ -70,7 +81,7 @@ func (s *singleNamespace) IsManaged(ctx *synccontext.SyncContext, pObj client.Ob
 	}
 
 	// is object not in our target namespace?
-	if !s.IsTargetedNamespace(pObj.GetNamespace()) {
+	if !s.IsTargetedNamespace(ctx, pObj.GetNamespace()) {
 		return false
 	} else if pObj.GetLabels()[MarkerLabel] != VClusterName {
 		return false
@@ -108,7 +119,7 @@ func (s *singleNamespace) IsManaged(ctx *synccontext.SyncContext, pObj client.Ob
 	return true
 }
 
-func (s *singleNamespace) IsTargetedNamespace(ns string) bool {
+func (s *singleNamespace) IsTargetedNamespace(_ *synccontext.SyncContext, ns string) bool {
 	return ns == s.targetNamespace
 }
 
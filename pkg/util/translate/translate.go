@@ -28,6 +28,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 )
 
+// VClusterNameLabel and VClusterNamespaceLabel are set on the host namespaces vCluster creates in multi namespace mode
+const (
+	VClusterNameLabel      = "vcluster.loft.sh/vcluster-name"
+	VClusterNamespaceLabel = "vcluster.loft.sh/vcluster-namespace"
+)
+
 var (
 	NamespaceLabel       = "vcluster.loft.sh/namespace"
 	MarkerLabel          = "vcluster.loft.sh/managed-by"
@@ -40,6 +46,9 @@ var (
 
 	ManagedAnnotationsAnnotation = "vcluster.loft.sh/managed-annotations"
 	ManagedLabelsAnnotation      = "vcluster.loft.sh/managed-labels"
+
+	// SyncStatusAnnotation holds the sync status of a virtual object and is never synced to the host object
+	SyncStatusAnnotation = "vcluster.loft.sh/sync-status"
 )
 
 const (
@@ -280,7 +289,7 @@ func applyAnnotations(fromAnnotations map[string]string, toAnnotations map[strin
 		toAnnotations = map[string]string{}
 	}
 
-	excludedKeys := []string{ManagedAnnotationsAnnotation, ManagedLabelsAnnotation}
+	excludedKeys := []string{ManagedAnnotationsAnnotation, ManagedLabelsAnnotation, SyncStatusAnnotation}
 	excludedKeys = append(excludedKeys, excludeAnnotations...)
 	mergedAnnotations, managedKeys := applyMaps(fromAnnotations, toAnnotations, ApplyMapsOptions{
 		ManagedKeys: strings.Split(toAnnotations[ManagedAnnotationsAnnotation], "\n"),
@@ -350,7 +359,8 @@ func applyMaps(fromMap, toMap map[string]string, opts ApplyMapsOptions) (map[str
 	return retMap, managedKeysStr
 }
 
-func EnsureCRDFromPhysicalCluster(ctx context.Context, pConfig *rest.Config, vConfig *rest.Config, groupVersionKind schema.GroupVersionKind) (bool, bool, error) {
+// EnsureCRDFromPhysicalCluster should be replaceable by unit tests
+var EnsureCRDFromPhysicalCluster = func(ctx context.Context, pConfig *rest.Config, vConfig *rest.Config, groupVersionKind schema.GroupVersionKind) (bool, bool, error) {
 	var isClusterScoped, hasStatusSubresource bool
 
 	vClient, err := apiextensionsv1clientset.NewForConfig(vConfig)
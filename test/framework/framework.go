@@ -119,7 +119,10 @@ func CreateFramework(ctx context.Context, scheme *runtime.Scheme) error {
 
 	var multiNamespaceMode bool
 	if os.Getenv("MULTINAMESPACE_MODE") == "true" {
-		translate.Default = translate.NewMultiNamespaceTranslator(ns)
+		translate.Default, err = translate.NewMultiNamespaceTranslator(ns, "")
+		if err != nil {
+			return err
+		}
 		multiNamespaceMode = true
 	} else {
 		translate.Default = translate.NewSingleNamespaceTranslator(ns)